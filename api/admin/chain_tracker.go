@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/chains"
+	"github.com/lasthyphen/dijetsnodego/database/backup"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+)
+
+var _ chains.Registrant = (*chainTracker)(nil)
+
+// chainTracker records the linear chains running on this node so that their
+// last accepted blocks can be included in backup manifests.
+type chainTracker struct {
+	lock    sync.Mutex
+	engines []common.Engine
+}
+
+func (c *chainTracker) RegisterChain(_ string, engine common.Engine) {
+	if _, ok := engine.GetVM().(block.ChainVM); !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.engines = append(c.engines, engine)
+}
+
+// lastAccepted returns the last accepted block of every tracked chain.
+func (c *chainTracker) lastAccepted(ctx context.Context) ([]backup.Chain, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	lastAccepted := make([]backup.Chain, 0, len(c.engines))
+	for _, engine := range c.engines {
+		chain, err := getLastAccepted(ctx, engine)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get last accepted block of %s: %w",
+				engine.Context().ChainID,
+				err,
+			)
+		}
+		lastAccepted = append(lastAccepted, chain)
+	}
+	return lastAccepted, nil
+}
+
+func getLastAccepted(ctx context.Context, engine common.Engine) (backup.Chain, error) {
+	chainCtx := engine.Context()
+	vm := engine.GetVM().(block.ChainVM)
+
+	chainCtx.Lock.Lock()
+	defer chainCtx.Lock.Unlock()
+
+	blkID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return backup.Chain{}, err
+	}
+	blk, err := vm.GetBlock(ctx, blkID)
+	if err != nil {
+		return backup.Chain{}, err
+	}
+	return backup.Chain{
+		ChainID:            chainCtx.ChainID,
+		LastAcceptedID:     blkID,
+		LastAcceptedHeight: json.Uint64(blk.Height()),
+	}, nil
+}
@@ -6,9 +6,12 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/database/backup"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/rpc"
 )
@@ -29,6 +32,13 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	CreateBackup(ctx context.Context, path string, options ...rpc.Option) (*backup.Manifest, error)
+	RestoreBackup(ctx context.Context, path, dbDir, dbType, chainDataDir string, options ...rpc.Option) (*backup.Manifest, error)
+	AddPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error
+	DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	ListBans(ctx context.Context, options ...rpc.Option) ([]network.Ban, error)
+	ReloadConfig(ctx context.Context, options ...rpc.Option) ([]string, []string, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -139,3 +149,61 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) CreateBackup(ctx context.Context, path string, options ...rpc.Option) (*backup.Manifest, error) {
+	res := &CreateBackupReply{}
+	err := c.requester.SendRequest(ctx, "admin.createBackup", &CreateBackupArgs{
+		Path: path,
+	}, res, options...)
+	return res.Manifest, err
+}
+
+func (c *client) RestoreBackup(
+	ctx context.Context,
+	path string,
+	dbDir string,
+	dbType string,
+	chainDataDir string,
+	options ...rpc.Option,
+) (*backup.Manifest, error) {
+	res := &RestoreBackupReply{}
+	err := c.requester.SendRequest(ctx, "admin.restoreBackup", &RestoreBackupArgs{
+		Path:         path,
+		DBDir:        dbDir,
+		DBType:       dbType,
+		ChainDataDir: chainDataDir,
+	}, res, options...)
+	return res.Manifest, err
+}
+
+func (c *client) AddPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.addPeer", &AddPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.disconnectPeer", &DisconnectPeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banNode", &BanNodeArgs{
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ListBans(ctx context.Context, options ...rpc.Option) ([]network.Ban, error) {
+	res := &ListBansReply{}
+	err := c.requester.SendRequest(ctx, "admin.listBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+func (c *client) ReloadConfig(ctx context.Context, options ...rpc.Option) ([]string, []string, error) {
+	res := &ReloadConfigReply{}
+	err := c.requester.SendRequest(ctx, "admin.reloadConfig", struct{}{}, res, options...)
+	return res.ReloadedKeys, res.RestartRequiredKeys, err
+}
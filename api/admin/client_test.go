@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/database/backup"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/rpc"
 )
@@ -64,6 +67,18 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *CreateBackupReply:
+		response := mc.response.(*CreateBackupReply)
+		*p = *response
+	case *RestoreBackupReply:
+		response := mc.response.(*RestoreBackupReply)
+		*p = *response
+	case *ListBansReply:
+		response := mc.response.(*ListBansReply)
+		*p = *response
+	case *ReloadConfigReply:
+		response := mc.response.(*ReloadConfigReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -385,3 +400,109 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateBackup(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedManifest := &backup.Manifest{NetworkID: 1}
+		mockClient := client{requester: NewMockClient(&CreateBackupReply{
+			Manifest: expectedManifest,
+		}, nil)}
+
+		manifest, err := mockClient.CreateBackup(context.Background(), "backup.zip")
+		require.NoError(t, err)
+		require.Equal(t, expectedManifest, manifest)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&CreateBackupReply{}, errors.New("some error"))}
+
+		_, err := mockClient.CreateBackup(context.Background(), "backup.zip")
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestRestoreBackup(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedManifest := &backup.Manifest{NetworkID: 1}
+		mockClient := client{requester: NewMockClient(&RestoreBackupReply{
+			Manifest: expectedManifest,
+		}, nil)}
+
+		manifest, err := mockClient.RestoreBackup(context.Background(), "backup.zip", "db", "leveldb", "chainData")
+		require.NoError(t, err)
+		require.Equal(t, expectedManifest, manifest)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&RestoreBackupReply{}, errors.New("some error"))}
+
+		_, err := mockClient.RestoreBackup(context.Background(), "backup.zip", "db", "leveldb", "chainData")
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestBanNode(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.BanNode(context.Background(), ids.GenerateTestNodeID(), time.Hour)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
+func TestListBans(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedBans := []network.Ban{{
+			NodeID: ids.GenerateTestNodeID(),
+			Until:  time.Unix(1000, 0),
+		}}
+		mockClient := client{requester: NewMockClient(&ListBansReply{
+			Bans: expectedBans,
+		}, nil)}
+
+		bans, err := mockClient.ListBans(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expectedBans, bans)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListBansReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ListBans(context.Background())
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReloadedKeys := []string{"log-level"}
+		expectedRestartRequiredKeys := []string{"http-port"}
+		mockClient := client{requester: NewMockClient(&ReloadConfigReply{
+			ReloadedKeys:        expectedReloadedKeys,
+			RestartRequiredKeys: expectedRestartRequiredKeys,
+		}, nil)}
+
+		reloadedKeys, restartRequiredKeys, err := mockClient.ReloadConfig(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expectedReloadedKeys, reloadedKeys)
+		require.Equal(t, expectedRestartRequiredKeys, restartRequiredKeys)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ReloadConfigReply{}, errors.New("some error"))}
+
+		_, _, err := mockClient.ReloadConfig(context.Background())
+
+		require.EqualError(t, err, "some error")
+	})
+}
@@ -5,8 +5,12 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -15,14 +19,19 @@ import (
 	"github.com/lasthyphen/dijetsnodego/api"
 	"github.com/lasthyphen/dijetsnodego/api/server"
 	"github.com/lasthyphen/dijetsnodego/chains"
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/backup"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/perms"
 	"github.com/lasthyphen/dijetsnodego/utils/profiler"
+	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms"
 	"github.com/lasthyphen/dijetsnodego/vms/registry"
 )
@@ -37,8 +46,20 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errNoPath       = errors.New("path must be specified")
+	errNodeDir      = errors.New("can't restore into the directories used by this node")
+	errNoDuration   = errors.New("duration must be specified")
+	errNoReloader   = errors.New("config reloading is not supported")
 )
 
+// Reloader re-reads the config of the node and applies the settings that can
+// change while the node is running.
+type Reloader interface {
+	// ReloadConfig returns the keys that were applied and the keys that
+	// changed but only take effect after a restart.
+	ReloadConfig() (reloadedKeys []string, restartRequiredKeys []string, err error)
+}
+
 type Config struct {
 	Log          logging.Logger
 	ProfileDir   string
@@ -46,14 +67,25 @@ type Config struct {
 	NodeConfig   interface{}
 	ChainManager chains.Manager
 	HTTPServer   server.PathAdderWithReadLock
+	Network      network.Network
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	NetworkID    uint32
+	// DB is the current database of the node
+	DB database.Database
+	// DBPath is the directory that contains the node's versioned databases
+	DBPath       string
+	ChainDataDir string
+	// Reloader is used to reload the config of the node. If nil,
+	// admin.reloadConfig returns an error.
+	Reloader Reloader
 }
 
 // Admin is the API service for node admin management
 type Admin struct {
 	Config
 	profiler profiler.Profiler
+	chains   *chainTracker
 }
 
 // NewService returns a new admin API service.
@@ -63,9 +95,12 @@ func NewService(config Config) (*common.HTTPHandler, error) {
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	chains := &chainTracker{}
+	config.ChainManager.AddRegistrant(chains)
 	if err := newServer.RegisterService(&Admin{
 		Config:   config,
 		profiler: profiler.New(config.ProfileDir),
+		chains:   chains,
 	}, "admin"); err != nil {
 		return nil, err
 	}
@@ -314,3 +349,234 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	reply.NewVMs, err = ids.GetRelevantAliases(a.VMManager, loadedVMs)
 	return err
 }
+
+// CreateBackupArgs are the arguments for calling CreateBackup
+type CreateBackupArgs struct {
+	// Path is the file, on the node's filesystem, to write the archive to.
+	// It must not already exist.
+	Path string `json:"path"`
+}
+
+// CreateBackupReply contains the manifest of the created archive
+type CreateBackupReply struct {
+	Manifest *backup.Manifest `json:"manifest"`
+}
+
+// CreateBackup writes a compressed archive of the node's current database and
+// chain data directory to [args.Path] while the node keeps running.
+func (a *Admin) CreateBackup(r *http.Request, args *CreateBackupArgs, reply *CreateBackupReply) error {
+	a.Log.Debug("Admin: CreateBackup called",
+		logging.UserString("path", args.Path),
+	)
+
+	if args.Path == "" {
+		return errNoPath
+	}
+
+	// The last accepted blocks are read before the database snapshot is taken
+	// so that the archived database is guaranteed to contain them.
+	lastAccepted, err := a.chains.lastAccepted(r.Context())
+	if err != nil {
+		return err
+	}
+	manifest := &backup.Manifest{
+		NetworkID:       json.Uint32(a.NetworkID),
+		NodeVersion:     version.CurrentApp.String(),
+		DatabaseVersion: version.CurrentDatabase.String(),
+		Timestamp:       time.Now().UTC(),
+		Chains:          lastAccepted,
+	}
+
+	f, err := os.OpenFile(args.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	err = backup.Create(f, a.DB, a.ChainDataDir, manifest)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Don't leave a partial archive behind.
+		_ = os.Remove(args.Path)
+		return err
+	}
+
+	reply.Manifest = manifest
+	return nil
+}
+
+// RestoreBackupArgs are the arguments for calling RestoreBackup
+type RestoreBackupArgs struct {
+	// Path is the archive, on the node's filesystem, to restore from.
+	Path string `json:"path"`
+	// DBDir is the database directory of the node that will use the restored
+	// database, as passed to it with --db-dir.
+	DBDir  string `json:"dbDir"`
+	DBType string `json:"dbType"`
+	// ChainDataDir is the directory to restore the chain data into. If empty,
+	// the chain data is not restored.
+	ChainDataDir string `json:"chainDataDir"`
+}
+
+// RestoreBackupReply contains the manifest of the restored archive
+type RestoreBackupReply struct {
+	Manifest *backup.Manifest `json:"manifest"`
+}
+
+// RestoreBackup restores an archive created by CreateBackup into empty
+// directories, which can then be used to start another node. The directories
+// used by this node can't be restored into while it is running.
+func (a *Admin) RestoreBackup(_ *http.Request, args *RestoreBackupArgs, reply *RestoreBackupReply) error {
+	a.Log.Debug("Admin: RestoreBackup called",
+		logging.UserString("path", args.Path),
+		logging.UserString("dbDir", args.DBDir),
+		logging.UserString("dbType", args.DBType),
+		logging.UserString("chainDataDir", args.ChainDataDir),
+	)
+
+	if args.Path == "" || args.DBDir == "" {
+		return errNoPath
+	}
+
+	manifest, err := backup.ReadManifest(args.Path)
+	if err != nil {
+		return err
+	}
+	dbPath := filepath.Join(args.DBDir, constants.NetworkName(uint32(manifest.NetworkID)))
+	sameDir, err := isSameDir(dbPath, a.DBPath)
+	if err != nil {
+		return err
+	}
+	if sameDir {
+		return fmt.Errorf("%w: %s", errNodeDir, args.DBDir)
+	}
+	if args.ChainDataDir != "" {
+		sameDir, err := isSameDir(args.ChainDataDir, a.ChainDataDir)
+		if err != nil {
+			return err
+		}
+		if sameDir {
+			return fmt.Errorf("%w: %s", errNodeDir, args.ChainDataDir)
+		}
+	}
+
+	reply.Manifest, err = backup.Restore(backup.RestoreConfig{
+		ArchivePath:  args.Path,
+		DBDir:        args.DBDir,
+		DBType:       args.DBType,
+		ChainDataDir: args.ChainDataDir,
+	}, a.Log)
+	return err
+}
+
+func isSameDir(a, b string) (bool, error) {
+	absA, err := filepath.Abs(a)
+	if err != nil {
+		return false, err
+	}
+	absB, err := filepath.Abs(b)
+	if err != nil {
+		return false, err
+	}
+	return absA == absB, nil
+}
+
+// AddPeerArgs are the arguments for calling AddPeer
+type AddPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// AddPeer attempts to connect to a node at the given IP. The node will keep
+// attempting to connect to it until it is disconnected or banned.
+func (a *Admin) AddPeer(_ *http.Request, args *AddPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: AddPeer called",
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+	)
+
+	ip, err := ips.ToIPPort(args.IP)
+	if err != nil {
+		return err
+	}
+	return a.Network.AddPeer(args.NodeID, ip)
+}
+
+// DisconnectPeerArgs are the arguments for calling DisconnectPeer
+type DisconnectPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// DisconnectPeer closes the connection to a node. Validators are reconnected
+// to, so they should be banned to keep them disconnected.
+func (a *Admin) DisconnectPeer(_ *http.Request, args *DisconnectPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: DisconnectPeer called",
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	return a.Network.DisconnectPeer(args.NodeID)
+}
+
+// BanNodeArgs are the arguments for calling BanNode
+type BanNodeArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Duration is how long the node is banned for, e.g. "24h".
+	Duration string `json:"duration"`
+}
+
+// BanNode disconnects from a node and refuses connections to it for the given
+// duration. Bans are kept across restarts.
+func (a *Admin) BanNode(_ *http.Request, args *BanNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("Admin: BanNode called",
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("duration", args.Duration),
+	)
+
+	if args.Duration == "" {
+		return errNoDuration
+	}
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	return a.Network.Ban(args.NodeID, duration)
+}
+
+// ListBansReply are the nodes that are currently banned
+type ListBansReply struct {
+	Bans []network.Ban `json:"bans"`
+}
+
+// ListBans returns the nodes that are currently banned
+func (a *Admin) ListBans(_ *http.Request, _ *struct{}, reply *ListBansReply) error {
+	a.Log.Debug("Admin: ListBans called")
+
+	reply.Bans = a.Network.Bans()
+	return nil
+}
+
+// ReloadConfigReply are the results of reloading the config of the node
+type ReloadConfigReply struct {
+	// ReloadedKeys are the config keys that changed and were applied
+	ReloadedKeys []string `json:"reloadedKeys"`
+	// RestartRequiredKeys are the config keys that changed but will only be
+	// applied after the node is restarted
+	RestartRequiredKeys []string `json:"restartRequiredKeys"`
+}
+
+// ReloadConfig re-reads the config of the node and applies the settings that
+// can change while the node is running.
+func (a *Admin) ReloadConfig(_ *http.Request, _ *struct{}, reply *ReloadConfigReply) error {
+	a.Log.Debug("Admin: ReloadConfig called")
+
+	if a.Reloader == nil {
+		return errNoReloader
+	}
+	reloadedKeys, restartRequiredKeys, err := a.Reloader.ReloadConfig()
+	if err != nil {
+		return err
+	}
+	reply.ReloadedKeys = reloadedKeys
+	reply.RestartRequiredKeys = restartRequiredKeys
+	return nil
+}
@@ -6,13 +6,17 @@ package admin
 import (
 	"errors"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/lasthyphen/dijetsnodego/database/leveldb"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/vms"
 	"github.com/lasthyphen/dijetsnodego/vms/registry"
@@ -121,3 +125,60 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	require.Equal(t, err, errOops)
 }
+
+func TestCreateAndRestoreBackup(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	nodeDBDir := t.TempDir()
+	admin := &Admin{
+		Config: Config{
+			Log:          logging.NoLog{},
+			NetworkID:    constants.LocalID,
+			DB:           db,
+			DBPath:       filepath.Join(nodeDBDir, constants.LocalName),
+			ChainDataDir: t.TempDir(),
+		},
+		chains: &chainTracker{},
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "backup.zip")
+	createReply := &CreateBackupReply{}
+	require.NoError(admin.CreateBackup(&http.Request{}, &CreateBackupArgs{
+		Path: archivePath,
+	}, createReply))
+	require.Equal(uint64(1), uint64(createReply.Manifest.NumKeys))
+
+	// Existing archives are never overwritten.
+	err := admin.CreateBackup(&http.Request{}, &CreateBackupArgs{
+		Path: archivePath,
+	}, &CreateBackupReply{})
+	require.Error(err)
+
+	// The node's own directories can't be restored into.
+	err = admin.RestoreBackup(nil, &RestoreBackupArgs{
+		Path:   archivePath,
+		DBDir:  nodeDBDir,
+		DBType: leveldb.Name,
+	}, &RestoreBackupReply{})
+	require.ErrorIs(err, errNodeDir)
+
+	err = admin.RestoreBackup(nil, &RestoreBackupArgs{
+		Path:         archivePath,
+		DBDir:        t.TempDir(),
+		DBType:       leveldb.Name,
+		ChainDataDir: admin.ChainDataDir,
+	}, &RestoreBackupReply{})
+	require.ErrorIs(err, errNodeDir)
+
+	restoreReply := &RestoreBackupReply{}
+	require.NoError(admin.RestoreBackup(nil, &RestoreBackupArgs{
+		Path:         archivePath,
+		DBDir:        t.TempDir(),
+		DBType:       leveldb.Name,
+		ChainDataDir: t.TempDir(),
+	}, restoreReply))
+	require.Equal(createReply.Manifest, restoreReply.Manifest)
+}
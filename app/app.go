@@ -26,6 +26,15 @@ type App interface {
 	ExitCode() (int, error)
 }
 
+// Reloader is implemented by applications that can reload their config while
+// running.
+type Reloader interface {
+	// Reload re-reads the config of the application and applies the settings
+	// that can change while it is running. Reload should only be called after
+	// [Start].
+	Reload() error
+}
+
 func Run(app App) int {
 	// start running the application
 	if err := app.Start(); err != nil {
@@ -37,10 +46,22 @@ func Run(app App) int {
 	signal.Notify(signals, syscall.SIGINT)
 	signal.Notify(signals, syscall.SIGTERM)
 
+	// register signals to reload the config of the application
+	reloader, reloadable := app.(Reloader)
+	if reloadable {
+		signal.Notify(signals, syscall.SIGHUP)
+	}
+
 	// start up a new go routine to handle attempts to kill the application
 	var eg errgroup.Group
 	eg.Go(func() error {
-		for range signals {
+		for sig := range signals {
+			if reloadable && sig == syscall.SIGHUP {
+				// Failures to reload are reported by the application, and
+				// the previous config remains in use.
+				_ = reloader.Reload()
+				continue
+			}
 			return app.Stop()
 		}
 		return nil
@@ -33,7 +33,8 @@ var (
 	stakingPortName = fmt.Sprintf("%s-staking", constants.AppName)
 	httpPortName    = fmt.Sprintf("%s-http", constants.AppName)
 
-	_ app.App = (*process)(nil)
+	_ app.App      = (*process)(nil)
+	_ app.Reloader = (*process)(nil)
 )
 
 // process is a wrapper around a node that runs in this process
@@ -129,6 +130,7 @@ func (p *process) Start() error {
 	// Note that if the node config said to not dynamically resolve and
 	// update our public IP, [p.config.IPUdater] is a no-op implementation.
 	go p.config.IPUpdater.Dispatch(log)
+	go p.config.IPv6Updater.Dispatch(log)
 
 	if err := p.node.Initialize(&p.config, log, logFactory); err != nil {
 		log.Fatal("error initializing node",
@@ -136,6 +138,7 @@ func (p *process) Start() error {
 		)
 		mapper.UnmapAllPorts()
 		p.config.IPUpdater.Stop()
+		p.config.IPv6Updater.Stop()
 		log.Stop()
 		logFactory.Close()
 		return err
@@ -155,6 +158,7 @@ func (p *process) Start() error {
 		defer func() {
 			mapper.UnmapAllPorts()
 			p.config.IPUpdater.Stop()
+			p.config.IPv6Updater.Stop()
 
 			// If [p.node.Dispatch()] panics, then we should log the panic and
 			// then re-raise the panic. This is why the above defer is broken
@@ -177,6 +181,25 @@ func (p *process) Stop() error {
 	return nil
 }
 
+// Reload re-reads the config of the node and applies the settings that can
+// change while the node is running.
+func (p *process) Reload() error {
+	reloadedKeys, restartRequiredKeys, err := p.node.ReloadConfig()
+	if err != nil {
+		p.node.Log.Error("failed to reload config",
+			zap.Error(err),
+		)
+		return err
+	}
+	if len(restartRequiredKeys) > 0 {
+		p.node.Log.Warn("some config changes require a restart",
+			zap.Strings("reloadedKeys", reloadedKeys),
+			zap.Strings("restartRequiredKeys", restartRequiredKeys),
+		)
+	}
+	return nil
+}
+
 // ExitCode returns the exit code that the node is reporting. This function
 // blocks until the node has been shut down.
 func (p *process) ExitCode() (int, error) {
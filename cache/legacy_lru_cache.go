@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+// LRU is a key value store with bounded size. If the size is attempted to be
+// exceeded, then an element is removed from the cache before the insertion is
+// done, based on evicting the least recently used value. Keys must be
+// comparable, as defined by https://golang.org/ref/spec#Comparison_operators.
+//
+// LRU is untyped and is kept for packages, such as coreth, that predate the
+// generic caches. New code should use NewLRU.
+type LRU struct {
+	lru  lru[interface{}, interface{}]
+	Size int
+}
+
+func (c *LRU) Put(key, value interface{}) {
+	c.lru.lock.Lock()
+	defer c.lru.lock.Unlock()
+
+	c.resize()
+	c.lru.put(key, value)
+}
+
+func (c *LRU) Get(key interface{}) (interface{}, bool) {
+	c.lru.lock.Lock()
+	defer c.lru.lock.Unlock()
+
+	c.resize()
+	value, ok := c.lru.get(key)
+	if !ok {
+		return struct{}{}, false
+	}
+	return value, true
+}
+
+func (c *LRU) Evict(key interface{}) {
+	c.lru.lock.Lock()
+	defer c.lru.lock.Unlock()
+
+	c.resize()
+	c.lru.evict(key)
+}
+
+func (c *LRU) Flush() {
+	c.lru.lock.Lock()
+	defer c.lru.lock.Unlock()
+
+	c.resize()
+	c.lru.flush()
+}
+
+// resize gives the wrapped cache the size of [c.Size], which may be changed at
+// any time.
+// Assumes [c.lru.lock] is held.
+func (c *LRU) resize() {
+	if c.Size <= 0 {
+		c.Size = 1
+	}
+	c.lru.size = c.Size
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestLegacyLRU(t *testing.T) {
+	require := require.New(t)
+
+	cache := &LRU{Size: 1}
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+
+	_, found := cache.Get(id1)
+	require.False(found)
+
+	cache.Put(id1, 1)
+	val, found := cache.Get(id1)
+	require.True(found)
+	require.Equal(1, val)
+
+	cache.Put(id2, 2)
+	_, found = cache.Get(id1)
+	require.False(found)
+	val, found = cache.Get(id2)
+	require.True(found)
+	require.Equal(2, val)
+
+	cache.Evict(id2)
+	_, found = cache.Get(id2)
+	require.False(found)
+
+	cache.Put(id1, 1)
+	cache.Flush()
+	_, found = cache.Get(id1)
+	require.False(found)
+}
@@ -10,88 +10,93 @@ import (
 
 const minCacheSize = 32
 
-var _ Cacher = (*LRU)(nil)
+var _ Cacher[struct{}, struct{}] = (*lru[struct{}, struct{}])(nil)
 
-type entry struct {
-	Key   interface{}
-	Value interface{}
+type entry[K, V any] struct {
+	Key   K
+	Value V
 }
 
-// LRU is a key value store with bounded size. If the size is attempted to be
+// NewLRU returns a cache that holds at most [size] entries.
+func NewLRU[K comparable, V any](size int) Cacher[K, V] {
+	return &lru[K, V]{size: size}
+}
+
+// lru is a key value store with bounded size. If the size is attempted to be
 // exceeded, then an element is removed from the cache before the insertion is
 // done, based on evicting the least recently used value.
-type LRU struct {
+type lru[K comparable, V any] struct {
 	lock      sync.Mutex
-	entryMap  map[interface{}]*list.Element
+	entryMap  map[K]*list.Element
 	entryList *list.List
-	Size      int
+	size      int
 }
 
-func (c *LRU) Put(key, value interface{}) {
+func (c *lru[K, V]) Put(key K, value V) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.put(key, value)
 }
 
-func (c *LRU) Get(key interface{}) (interface{}, bool) {
+func (c *lru[K, V]) Get(key K) (V, bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	return c.get(key)
 }
 
-func (c *LRU) Evict(key interface{}) {
+func (c *lru[K, V]) Evict(key K) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.evict(key)
 }
 
-func (c *LRU) Flush() {
+func (c *lru[K, V]) Flush() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.flush()
 }
 
-func (c *LRU) init() {
+func (c *lru[K, V]) init() {
 	if c.entryMap == nil {
-		c.entryMap = make(map[interface{}]*list.Element, minCacheSize)
+		c.entryMap = make(map[K]*list.Element, minCacheSize)
 	}
 	if c.entryList == nil {
 		c.entryList = list.New()
 	}
-	if c.Size <= 0 {
-		c.Size = 1
+	if c.size <= 0 {
+		c.size = 1
 	}
 }
 
-func (c *LRU) resize() {
-	for c.entryList.Len() > c.Size {
+func (c *lru[K, V]) resize() {
+	for c.entryList.Len() > c.size {
 		e := c.entryList.Front()
 		c.entryList.Remove(e)
 
-		val := e.Value.(*entry)
+		val := e.Value.(*entry[K, V])
 		delete(c.entryMap, val.Key)
 	}
 }
 
-func (c *LRU) put(key, value interface{}) {
+func (c *lru[K, V]) put(key K, value V) {
 	c.init()
 	c.resize()
 
 	if e, ok := c.entryMap[key]; !ok {
-		if c.entryList.Len() >= c.Size {
+		if c.entryList.Len() >= c.size {
 			e = c.entryList.Front()
 			c.entryList.MoveToBack(e)
 
-			val := e.Value.(*entry)
+			val := e.Value.(*entry[K, V])
 			delete(c.entryMap, val.Key)
 			val.Key = key
 			val.Value = value
 		} else {
-			e = c.entryList.PushBack(&entry{
+			e = c.entryList.PushBack(&entry[K, V]{
 				Key:   key,
 				Value: value,
 			})
@@ -100,25 +105,26 @@ func (c *LRU) put(key, value interface{}) {
 	} else {
 		c.entryList.MoveToBack(e)
 
-		val := e.Value.(*entry)
+		val := e.Value.(*entry[K, V])
 		val.Value = value
 	}
 }
 
-func (c *LRU) get(key interface{}) (interface{}, bool) {
+func (c *lru[K, V]) get(key K) (V, bool) {
 	c.init()
 	c.resize()
 
 	if e, ok := c.entryMap[key]; ok {
 		c.entryList.MoveToBack(e)
 
-		val := e.Value.(*entry)
+		val := e.Value.(*entry[K, V])
 		return val.Value, true
 	}
-	return struct{}{}, false
+	var zero V
+	return zero, false
 }
 
-func (c *LRU) evict(key interface{}) {
+func (c *lru[K, V]) evict(key K) {
 	c.init()
 	c.resize()
 
@@ -128,9 +134,9 @@ func (c *LRU) evict(key interface{}) {
 	}
 }
 
-func (c *LRU) flush() {
+func (c *lru[K, V]) flush() {
 	c.init()
 
-	c.entryMap = make(map[interface{}]*list.Element, minCacheSize)
+	c.entryMap = make(map[K]*list.Element, minCacheSize)
 	c.entryList = list.New()
 }
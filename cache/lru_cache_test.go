@@ -10,19 +10,19 @@ import (
 )
 
 func TestLRU(t *testing.T) {
-	cache := &LRU{Size: 1}
+	cache := NewLRU[ids.ID, int](1)
 
 	TestBasic(t, cache)
 }
 
 func TestLRUEviction(t *testing.T) {
-	cache := &LRU{Size: 2}
+	cache := NewLRU[ids.ID, int](2)
 
 	TestEviction(t, cache)
 }
 
 func TestLRUResize(t *testing.T) {
-	cache := LRU{Size: 2}
+	cache := lru[ids.ID, int]{size: 2}
 
 	id1 := ids.ID{1}
 	id2 := ids.ID{2}
@@ -40,7 +40,7 @@ func TestLRUResize(t *testing.T) {
 		t.Fatalf("Retrieved wrong value")
 	}
 
-	cache.Size = 1
+	cache.size = 1
 
 	if _, found := cache.Get(id1); found {
 		t.Fatalf("Retrieve value when none exists")
@@ -50,7 +50,7 @@ func TestLRUResize(t *testing.T) {
 		t.Fatalf("Retrieved wrong value")
 	}
 
-	cache.Size = 0
+	cache.size = 0
 
 	if _, found := cache.Get(id1); found {
 		t.Fatalf("Retrieve value when none exists")
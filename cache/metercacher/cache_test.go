@@ -8,13 +8,18 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/require"
+
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/lasthyphen/dijetsnodego/cache"
+	"github.com/lasthyphen/dijetsnodego/ids"
 )
 
 func TestInterface(t *testing.T) {
 	for _, test := range cache.CacherTests {
-		cache := &cache.LRU{Size: test.Size}
-		c, err := New("", prometheus.NewRegistry(), cache)
+		cache := cache.NewLRU[ids.ID, int](test.Size)
+		c, err := New[ids.ID, int]("", prometheus.NewRegistry(), cache)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -22,3 +27,33 @@ func TestInterface(t *testing.T) {
 		test.Func(t, c)
 	}
 }
+
+func TestSizerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	sizedCache := cache.NewSizedLRU(4, func(_ ids.ID, value int) int {
+		return value
+	})
+	c, err := New("", registry, sizedCache)
+	require.NoError(err)
+
+	c.Put(ids.ID{1}, 2)
+	c.Put(ids.ID{2}, 1)
+	c.Put(ids.ID{3}, 3)
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	values := make(map[string]float64)
+	for _, metric := range metrics {
+		switch metric.GetType() {
+		case dto.MetricType_GAUGE:
+			values[metric.GetName()] = metric.GetMetric()[0].GetGauge().GetValue()
+		case dto.MetricType_COUNTER:
+			values[metric.GetName()] = metric.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	require.Equal(float64(4), values["bytes"])
+	require.Equal(float64(1), values["evict"])
+}
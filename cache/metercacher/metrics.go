@@ -8,6 +8,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/lasthyphen/dijetsnodego/cache"
 	"github.com/lasthyphen/dijetsnodego/utils/metric"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 )
@@ -32,6 +33,33 @@ func newCounterMetric(namespace, name string, reg prometheus.Registerer, errs *w
 	return c
 }
 
+func newSizerMetrics(namespace string, sizer cache.Sizer, reg prometheus.Registerer, errs *wrappers.Errs) {
+	size := prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "bytes",
+			Help:      "total size (in bytes) of the entries in the cache",
+		},
+		func() float64 {
+			return float64(sizer.Size())
+		},
+	)
+	evict := prometheus.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "evict",
+			Help:      "# of entries evicted to honor the size bound of the cache",
+		},
+		func() float64 {
+			return float64(sizer.Evictions())
+		},
+	)
+	errs.Add(
+		reg.Register(size),
+		reg.Register(evict),
+	)
+}
+
 type metrics struct {
 	get,
 	put metric.Averager
@@ -40,14 +68,20 @@ type metrics struct {
 	miss prometheus.Counter
 }
 
+// Initialize registers the cache metrics. If [sizer] is non-nil, the size of
+// the cache and the number of evictions are reported as well.
 func (m *metrics) Initialize(
 	namespace string,
 	reg prometheus.Registerer,
+	sizer cache.Sizer,
 ) error {
 	errs := wrappers.Errs{}
 	m.get = newAveragerMetric(namespace, "get", reg, &errs)
 	m.put = newAveragerMetric(namespace, "put", reg, &errs)
 	m.hit = newCounterMetric(namespace, "hit", reg, &errs)
 	m.miss = newCounterMetric(namespace, "miss", reg, &errs)
+	if sizer != nil {
+		newSizerMetrics(namespace, sizer, reg, &errs)
+	}
 	return errs.Err
 }
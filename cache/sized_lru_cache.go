@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+var (
+	_ Cacher[struct{}, struct{}] = (*sizedLRU[struct{}, struct{}])(nil)
+	_ Sizer                      = (*sizedLRU[struct{}, struct{}])(nil)
+)
+
+// sizedLRU is a key value store with bounded size. If the size is attempted to
+// be exceeded, then elements are removed from the cache until the bound is
+// honored, based on evicting the least recently used value.
+//
+// Unlike lru, the size of the cache is the sum of the sizes of its entries, as
+// reported by the provided size function, rather than the number of entries.
+// The number of entries may optionally be bounded as well.
+type sizedLRU[K comparable, V any] struct {
+	lock        sync.Mutex
+	entryMap    map[K]*list.Element
+	entryList   *list.List
+	maxEntries  int // If 0, the number of entries is unbounded.
+	maxSize     int
+	currentSize int
+	size        func(K, V) int
+	evictions   uint64
+}
+
+// NewSizedLRU returns a cache that holds at most [maxSize] worth of entries,
+// where the size of each entry is calculated by [size]. The returned cache
+// implements Sizer.
+func NewSizedLRU[K comparable, V any](maxSize int, size func(K, V) int) Cacher[K, V] {
+	return newSizedLRU(0, maxSize, size)
+}
+
+// NewBoundedLRU returns a cache that holds at most [maxEntries] entries and at
+// most [maxSize] worth of entries, where the size of each entry is calculated
+// by [size]. As with NewLRU, a non-positive [maxEntries] is treated as 1. The
+// returned cache implements Sizer.
+func NewBoundedLRU[K comparable, V any](maxEntries, maxSize int, size func(K, V) int) Cacher[K, V] {
+	if maxEntries <= 0 {
+		maxEntries = 1
+	}
+	return newSizedLRU(maxEntries, maxSize, size)
+}
+
+func newSizedLRU[K comparable, V any](maxEntries, maxSize int, size func(K, V) int) *sizedLRU[K, V] {
+	return &sizedLRU[K, V]{
+		entryMap:   make(map[K]*list.Element, minCacheSize),
+		entryList:  list.New(),
+		maxEntries: maxEntries,
+		maxSize:    maxSize,
+		size:       size,
+	}
+}
+
+func (c *sizedLRU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *sizedLRU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *sizedLRU[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *sizedLRU[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *sizedLRU[K, V]) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.currentSize
+}
+
+func (c *sizedLRU[K, V]) Evictions() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.evictions
+}
+
+func (c *sizedLRU[K, V]) put(key K, value V) {
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*entry[K, V])
+		c.currentSize -= c.size(val.Key, val.Value)
+		val.Value = value
+	} else {
+		c.entryMap[key] = c.entryList.PushBack(&entry[K, V]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	c.currentSize += c.size(key, value)
+
+	// If the new entry is larger than the cache, it will be evicted as well.
+	for c.currentSize > c.maxSize || (c.maxEntries > 0 && c.entryList.Len() > c.maxEntries) {
+		e := c.entryList.Front()
+		c.entryList.Remove(e)
+
+		val := e.Value.(*entry[K, V])
+		delete(c.entryMap, val.Key)
+		c.currentSize -= c.size(val.Key, val.Value)
+		c.evictions++
+	}
+}
+
+func (c *sizedLRU[K, V]) get(key K) (V, bool) {
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*entry[K, V])
+		return val.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *sizedLRU[K, V]) evict(key K) {
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.Remove(e)
+		delete(c.entryMap, key)
+
+		val := e.Value.(*entry[K, V])
+		c.currentSize -= c.size(val.Key, val.Value)
+	}
+}
+
+func (c *sizedLRU[K, V]) flush() {
+	c.entryMap = make(map[K]*list.Element, minCacheSize)
+	c.entryList = list.New()
+	c.currentSize = 0
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestSizedLRU(t *testing.T) {
+	for _, test := range CacherTests {
+		cache := NewSizedLRU(test.Size, func(ids.ID, int) int {
+			return 1
+		})
+		test.Func(t, cache)
+	}
+}
+
+func TestSizedLRUWeights(t *testing.T) {
+	require := require.New(t)
+
+	// Each entry is weighted by its value.
+	cache := newSizedLRU(0, 10, func(_ ids.ID, value int) int {
+		return value
+	})
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+
+	cache.Put(id1, 4)
+	cache.Put(id2, 5)
+	require.Equal(9, cache.currentSize)
+
+	// Adding [id3] requires evicting [id1].
+	cache.Put(id3, 2)
+	_, found := cache.Get(id1)
+	require.False(found)
+	require.Equal(7, cache.Size())
+	require.Equal(uint64(1), cache.Evictions())
+
+	// Growing [id2] requires evicting [id3].
+	cache.Get(id3)
+	cache.Put(id2, 9)
+	_, found = cache.Get(id3)
+	require.False(found)
+	val, found := cache.Get(id2)
+	require.True(found)
+	require.Equal(9, val)
+	require.Equal(9, cache.currentSize)
+
+	require.Equal(uint64(2), cache.Evictions())
+
+	// Entries larger than the cache are never retained.
+	cache.Put(id1, 11)
+	_, found = cache.Get(id1)
+	require.False(found)
+	require.Zero(cache.Size())
+	require.Equal(uint64(4), cache.Evictions())
+
+	// Explicit evictions aren't counted.
+	cache.Put(id1, 3)
+	cache.Evict(id1)
+	require.Zero(cache.Size())
+	require.Equal(uint64(4), cache.Evictions())
+
+	cache.Put(id1, 3)
+	cache.Flush()
+	require.Zero(cache.currentSize)
+	_, found = cache.Get(id1)
+	require.False(found)
+}
+
+func TestBoundedLRU(t *testing.T) {
+	for _, test := range CacherTests {
+		cache := NewBoundedLRU(test.Size, 1000, func(ids.ID, int) int {
+			return 1
+		})
+		test.Func(t, cache)
+	}
+}
+
+func TestBoundedLRUEntries(t *testing.T) {
+	require := require.New(t)
+
+	// The number of entries is bounded even though their size isn't.
+	cache := NewBoundedLRU(2, 1000, func(ids.ID, int) int {
+		return 1
+	})
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+
+	cache.Put(id1, 1)
+	cache.Put(id2, 2)
+	cache.Put(id3, 3)
+	_, found := cache.Get(id1)
+	require.False(found)
+	_, found = cache.Get(id2)
+	require.True(found)
+	_, found = cache.Get(id3)
+	require.True(found)
+
+	// A non-positive number of entries is treated as 1.
+	cache = NewBoundedLRU(0, 1000, func(ids.ID, int) int {
+		return 1
+	})
+	cache.Put(id1, 1)
+	cache.Put(id2, 2)
+	_, found = cache.Get(id1)
+	require.False(found)
+	_, found = cache.Get(id2)
+	require.True(found)
+}
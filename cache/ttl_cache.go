@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
+)
+
+var _ Cacher[struct{}, struct{}] = (*ttlLRU[struct{}, struct{}])(nil)
+
+type ttlEntry[V any] struct {
+	value  V
+	expiry time.Time
+}
+
+// NewTTL returns a cache that holds at most [size] entries. Entries expire
+// [ttl] after they were last put into the cache.
+func NewTTL[K comparable, V any](size int, ttl time.Duration) Cacher[K, V] {
+	return newTTL[K, V](size, ttl)
+}
+
+func newTTL[K comparable, V any](size int, ttl time.Duration) *ttlLRU[K, V] {
+	return &ttlLRU[K, V]{
+		lru: lru[K, ttlEntry[V]]{size: size},
+		ttl: ttl,
+	}
+}
+
+// ttlLRU is an lru cache whose entries additionally expire [ttl] after they
+// were last put into the cache. Expired entries are removed lazily, when they
+// are next looked up or when they are evicted to make room for new entries.
+type ttlLRU[K comparable, V any] struct {
+	lock  sync.Mutex
+	lru   lru[K, ttlEntry[V]]
+	ttl   time.Duration
+	clock mockable.Clock
+}
+
+func (c *ttlLRU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lru.put(key, ttlEntry[V]{
+		value:  value,
+		expiry: c.clock.Time().Add(c.ttl),
+	})
+}
+
+func (c *ttlLRU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.lru.get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if c.clock.Time().After(e.expiry) {
+		c.lru.evict(key)
+
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *ttlLRU[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lru.evict(key)
+}
+
+func (c *ttlLRU[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lru.flush()
+}
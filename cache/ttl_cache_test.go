@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestTTL(t *testing.T) {
+	for _, test := range CacherTests {
+		cache := NewTTL[ids.ID, int](test.Size, time.Hour)
+		test.Func(t, cache)
+	}
+}
+
+func TestTTLExpiry(t *testing.T) {
+	require := require.New(t)
+
+	cache := newTTL[ids.ID, int](2, time.Minute)
+	now := time.Now()
+	cache.clock.Set(now)
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+
+	cache.Put(id1, 1)
+	cache.clock.Set(now.Add(time.Minute / 2))
+	cache.Put(id2, 2)
+
+	val, found := cache.Get(id1)
+	require.True(found)
+	require.Equal(1, val)
+
+	// [id1] expires before [id2] because it was put first.
+	cache.clock.Set(now.Add(time.Minute + time.Second))
+	_, found = cache.Get(id1)
+	require.False(found)
+	val, found = cache.Get(id2)
+	require.True(found)
+	require.Equal(2, val)
+
+	// Putting an entry again refreshes its expiry.
+	cache.Put(id2, 3)
+	cache.clock.Set(now.Add(2 * time.Minute))
+	val, found = cache.Get(id2)
+	require.True(found)
+	require.Equal(3, val)
+}
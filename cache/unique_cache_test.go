@@ -14,7 +14,7 @@ type evictable struct {
 	evicted int
 }
 
-func (e *evictable) Key() interface{} {
+func (e *evictable) Key() ids.ID {
 	return e.id
 }
 
@@ -23,21 +23,21 @@ func (e *evictable) Evict() {
 }
 
 func TestEvictableLRU(t *testing.T) {
-	cache := EvictableLRU{}
+	cache := EvictableLRU[ids.ID, *evictable]{}
 
 	expectedValue1 := &evictable{id: ids.ID{1}}
-	if returnedValue := cache.Deduplicate(expectedValue1).(*evictable); returnedValue != expectedValue1 {
+	if returnedValue := cache.Deduplicate(expectedValue1); returnedValue != expectedValue1 {
 		t.Fatalf("Returned unknown value")
 	} else if expectedValue1.evicted != 0 {
 		t.Fatalf("Value was evicted unexpectedly")
-	} else if returnedValue := cache.Deduplicate(expectedValue1).(*evictable); returnedValue != expectedValue1 {
+	} else if returnedValue := cache.Deduplicate(expectedValue1); returnedValue != expectedValue1 {
 		t.Fatalf("Returned unknown value")
 	} else if expectedValue1.evicted != 0 {
 		t.Fatalf("Value was evicted unexpectedly")
 	}
 
 	expectedValue2 := &evictable{id: ids.ID{2}}
-	returnedValue := cache.Deduplicate(expectedValue2).(*evictable)
+	returnedValue := cache.Deduplicate(expectedValue2)
 	switch {
 	case returnedValue != expectedValue2:
 		t.Fatalf("Returned unknown value")
@@ -50,7 +50,7 @@ func TestEvictableLRU(t *testing.T) {
 	cache.Size = 2
 
 	expectedValue3 := &evictable{id: ids.ID{2}}
-	returnedValue = cache.Deduplicate(expectedValue3).(*evictable)
+	returnedValue = cache.Deduplicate(expectedValue3)
 	switch {
 	case returnedValue != expectedValue2:
 		t.Fatalf("Returned unknown value")
@@ -18,6 +18,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/lasthyphen/dijetsnodego/api/health"
 	"github.com/lasthyphen/dijetsnodego/api/keystore"
 	"github.com/lasthyphen/dijetsnodego/api/metrics"
@@ -27,6 +29,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/network"
+	"github.com/lasthyphen/dijetsnodego/network/throttling"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowball"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/avalanche/state"
@@ -51,6 +54,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms"
 	"github.com/lasthyphen/dijetsnodego/vms/metervm"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
 	"github.com/lasthyphen/dijetsnodego/vms/proposervm"
 	"github.com/lasthyphen/dijetsnodego/vms/tracedvm"
 
@@ -116,6 +120,10 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Sets the config of the chains of [subnetID] that are created after this
+	// call.
+	SetSubnetConfig(subnetID ids.ID, config SubnetConfig)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters)
@@ -211,6 +219,10 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Rate-limits the bandwidth consumed by the messages of each subnet. The
+	// chains are registered with it as they are created.
+	SubnetThrottler throttling.SubnetBandwidthThrottler
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -231,6 +243,8 @@ type manager struct {
 	unblockChainCreatorCh  chan struct{}
 	chainCreatorShutdownCh chan struct{}
 
+	// Also guards [SubnetConfigs], which is replaced rather than modified as
+	// it's shared with the node's config.
 	subnetsLock sync.Mutex
 	// Key: Subnet's ID
 	// Value: Subnet description
@@ -377,6 +391,10 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	// Notify those that registered to be notified when a new chain is created
 	m.notifyRegistrants(chain.Name, chain.Engine)
 
+	// Charge the messages received for the new chain to its subnet's
+	// bandwidth budget.
+	m.SubnetThrottler.RegisterChain(chainParams.ID, chainParams.SubnetID)
+
 	// Allows messages to be routed to the new chain. If the handler hasn't been
 	// started and a message is forwarded, then the message will block until the
 	// handler is started.
@@ -430,6 +448,12 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, fmt.Errorf("error while registering vm's metrics %w", err)
 	}
 
+	// Without a BLS key, this node can't sign Warp messages.
+	var warpSigner warp.Signer
+	if m.StakingBLSKey != nil {
+		warpSigner = warp.NewSigner(m.StakingBLSKey, chainParams.ID)
+	}
+
 	ctx := &snow.ConsensusContext{
 		Context: &snow.Context{
 			NetworkID: m.NetworkID,
@@ -452,6 +476,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 			StakingCertLeaf:   m.StakingCert.Leaf,
 			StakingLeafSigner: m.StakingCert.PrivateKey.(crypto.Signer),
 			StakingBLSKey:     m.StakingBLSKey,
+			WarpSigner:        warpSigner,
 			ChainDataDir:      chainDataDir,
 		},
 		DecisionAcceptor:  m.DecisionAcceptorGroup,
@@ -462,7 +487,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	// before it's first access would cause a panic.
 	ctx.SetState(snow.Initializing)
 
-	if subnetConfig, ok := m.SubnetConfigs[chainParams.SubnetID]; ok {
+	if subnetConfig, ok := m.getSubnetConfig(chainParams.SubnetID); ok {
 		if subnetConfig.ValidatorOnly {
 			ctx.SetValidatorOnly()
 		}
@@ -504,7 +529,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	consensusParams := m.ConsensusParams
 	// short circuit it before reading from subnetConfigs
 	if chainParams.SubnetID != constants.PrimaryNetworkID {
-		if subnetConfig, ok := m.SubnetConfigs[chainParams.SubnetID]; ok {
+		if subnetConfig, ok := m.getSubnetConfig(chainParams.SubnetID); ok {
 			consensusParams = subnetConfig.ConsensusParameters
 		}
 	}
@@ -618,7 +643,7 @@ func (m *manager) createAvalancheChain(
 	gossipConfig := m.GossipConfig
 	// short circuit it before reading from subnetConfigs
 	if ctx.SubnetID != constants.PrimaryNetworkID {
-		if subnetConfig, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
+		if subnetConfig, ok := m.getSubnetConfig(ctx.SubnetID); ok {
 			gossipConfig = subnetConfig.GossipConfig
 		}
 	}
@@ -833,7 +858,7 @@ func (m *manager) createSnowmanChain(
 	gossipConfig := m.GossipConfig
 	// short circuit it before reading from subnetConfigs
 	if ctx.SubnetID != constants.PrimaryNetworkID {
-		if subnetConfig, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
+		if subnetConfig, ok := m.getSubnetConfig(ctx.SubnetID); ok {
 			gossipConfig = subnetConfig.GossipConfig
 		}
 	}
@@ -914,7 +939,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	minBlockDelay := proposervm.DefaultMinBlockDelay
-	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
+	if subnetCfg, ok := m.getSubnetConfig(ctx.SubnetID); ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 	}
 	m.Log.Info("creating proposervm wrapper",
@@ -1107,6 +1132,26 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().GetState() == snow.NormalOp
 }
 
+// SetSubnetConfig sets the config of the chains of [subnetID] that are created
+// after this call
+func (m *manager) SetSubnetConfig(subnetID ids.ID, config SubnetConfig) {
+	m.subnetsLock.Lock()
+	defer m.subnetsLock.Unlock()
+
+	subnetConfigs := make(map[ids.ID]SubnetConfig, len(m.SubnetConfigs)+1)
+	maps.Copy(subnetConfigs, m.SubnetConfigs)
+	subnetConfigs[subnetID] = config
+	m.SubnetConfigs = subnetConfigs
+}
+
+func (m *manager) getSubnetConfig(subnetID ids.ID) (SubnetConfig, bool) {
+	m.subnetsLock.Lock()
+	defer m.subnetsLock.Unlock()
+
+	config, ok := m.SubnetConfigs[subnetID]
+	return config, ok
+}
+
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
 	m.subnetsLock.Lock()
 	defer m.subnetsLock.Unlock()
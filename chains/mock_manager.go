@@ -54,6 +54,8 @@ func (mm MockManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (mm MockManager) SetSubnetConfig(ids.ID, SubnetConfig) {}
+
 func (mm MockManager) Lookup(s string) (ids.ID, error) {
 	id, err := ids.FromString(s)
 	if err == nil {
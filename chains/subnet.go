@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network/throttling"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/avalanche"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/sender"
@@ -38,6 +39,11 @@ type SubnetConfig struct {
 	// building a snowman++ block.
 	// TODO: Remove this flag once all VMs throttle their own block production.
 	ProposerMinBlockDelay time.Duration `json:"proposerMinBlockDelay" yaml:"proposerMinBlockDelay"`
+
+	// BandwidthThrottlerConfig is the bandwidth budget shared by the messages
+	// received for this Subnet's Chains. If the refill rate is 0, the messages
+	// aren't rate-limited.
+	BandwidthThrottlerConfig throttling.BandwidthThrottlerConfig `json:"bandwidthThrottlerConfig" yaml:"bandwidthThrottlerConfig"`
 }
 
 type subnet struct {
@@ -37,6 +37,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/staking"
 	"github.com/lasthyphen/dijetsnodego/trace"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
 	"github.com/lasthyphen/dijetsnodego/utils/dynamicip"
@@ -48,6 +49,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/storage"
 	"github.com/lasthyphen/dijetsnodego/utils/timer"
+	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsnodego/vms/proposervm"
@@ -61,7 +63,9 @@ const (
 )
 
 var (
-	deprecatedKeys = map[string]string{}
+	deprecatedKeys = map[string]string{
+		NetworkCompressionEnabledKey: fmt.Sprintf("use --%s instead", NetworkCompressionTypeKey),
+	}
 
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
@@ -78,6 +82,7 @@ var (
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errTracingEndpointEmpty          = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
+	errSubnetBurstTooSmall           = fmt.Errorf("subnet bandwidth max burst size must be at least %d", constants.DefaultMaxMessageSize)
 )
 
 func GetRunnerConfig(v *viper.Viper) (runner.Config, error) {
@@ -410,7 +415,6 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		},
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
-		CompressionEnabled:           v.GetBool(NetworkCompressionEnabledKey),
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              v.GetBool(NetworkAllowPrivateIPsKey),
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -419,6 +423,57 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+
+		OutboundPriorityQueueEnabled: v.GetBool(NetworkOutboundPriorityQueueEnabledKey),
+		AddressBookMaxAge:            v.GetDuration(NetworkAddressBookMaxAgeKey),
+
+		CaptureDir:         GetExpandedArg(v, NetworkCaptureDirKey),
+		CaptureMaxFileSize: int(v.GetUint(NetworkCaptureMaxFileSizeKey)),
+		CaptureMaxFiles:    int(v.GetUint(NetworkCaptureMaxFilesKey)),
+
+		Transport: v.GetString(NetworkTransportKey),
+
+		ReputationConfig: network.ReputationConfig{
+			PenaltyHalflife:     v.GetDuration(NetworkReputationPenaltyHalflifeKey),
+			SampleThreshold:     v.GetFloat64(NetworkReputationSampleThresholdKey),
+			DisconnectThreshold: v.GetFloat64(NetworkReputationDisconnectThresholdKey),
+		},
+	}
+
+	// Disabling compression with the deprecated [NetworkCompressionEnabledKey]
+	// takes precedence over [NetworkCompressionTypeKey].
+	if v.IsSet(NetworkCompressionEnabledKey) && !v.GetBool(NetworkCompressionEnabledKey) {
+		config.CompressionType = compression.TypeNone
+	} else {
+		compressionType, err := compression.TypeFromString(v.GetString(NetworkCompressionTypeKey))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse %s: %w", NetworkCompressionTypeKey, err)
+		}
+		config.CompressionType = compressionType
+	}
+
+	allowedNodeIDs, err := getNodeIDs(v, NetworkAllowedNodeIDsKey)
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.AllowedNodeIDs = allowedNodeIDs
+
+	deniedNodeIDs, err := getNodeIDs(v, NetworkDeniedNodeIDsKey)
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.DeniedNodeIDs = deniedNodeIDs
+
+	privateNodeIDs, err := getNodeIDs(v, NetworkPrivateNodeIDsKey)
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.PrivateNodeIDs = privateNodeIDs
+
+	for nodeID := range config.AllowedNodeIDs {
+		if config.DeniedNodeIDs.Contains(nodeID) {
+			return network.Config{}, fmt.Errorf("%s can't be both in %s and %s", nodeID, NetworkAllowedNodeIDsKey, NetworkDeniedNodeIDsKey)
+		}
 	}
 
 	switch {
@@ -450,6 +505,18 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.AddressBookMaxAge < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkAddressBookMaxAgeKey)
+	case config.CaptureDir != "" && config.CaptureMaxFileSize == 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkCaptureMaxFileSizeKey)
+	case config.ReputationConfig.PenaltyHalflife <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkReputationPenaltyHalflifeKey)
+	case config.ReputationConfig.SampleThreshold < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationSampleThresholdKey)
+	case config.ReputationConfig.DisconnectThreshold < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationDisconnectThresholdKey)
+	case config.Transport != network.TCPTransport && config.Transport != network.QUICTransport:
+		return network.Config{}, fmt.Errorf("%s must be one of [%s, %s]", NetworkTransportKey, network.TCPTransport, network.QUICTransport)
 	}
 	return config, nil
 }
@@ -578,6 +645,11 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		return node.IPConfig{}, fmt.Errorf("only one of --%s and --%s can be given", PublicIPKey, PublicIPResolutionServiceKey)
 	}
 
+	ipv6Port, ipv6Updater, err := getIPv6Config(v, stakingPort, ipResolutionFreq)
+	if err != nil {
+		return node.IPConfig{}, err
+	}
+
 	if publicIP != "" {
 		// User specified a specific public IP to use.
 		ip := net.ParseIP(publicIP)
@@ -588,6 +660,8 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 			IPPort:           ips.NewDynamicIPPort(ip, stakingPort),
 			IPUpdater:        dynamicip.NewNoUpdater(),
 			IPResolutionFreq: ipResolutionFreq,
+			IPv6Port:         ipv6Port,
+			IPv6Updater:      ipv6Updater,
 			Nat:              nat.NewNoRouter(),
 		}, nil
 	}
@@ -613,6 +687,8 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 				ipResolutionFreq,
 			),
 			IPResolutionFreq: ipResolutionFreq,
+			IPv6Port:         ipv6Port,
+			IPv6Updater:      ipv6Updater,
 			Nat:              nat.NewNoRouter(),
 		}, nil
 	}
@@ -630,9 +706,44 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		IPPort:                ips.NewDynamicIPPort(ip, stakingPort),
 		IPUpdater:             dynamicip.NewNoUpdater(),
 		IPResolutionFreq:      ipResolutionFreq,
+		IPv6Port:              ipv6Port,
+		IPv6Updater:           ipv6Updater,
 	}, nil
 }
 
+// getIPv6Config returns the IPv6 IP that a dual-stack node advertises in
+// addition to its public IP, and the updater of that IP. If the node isn't
+// dual-stack, the returned IP is nil. NAT traversal isn't attempted for IPv6
+// IPs, as they don't need to be translated.
+func getIPv6Config(v *viper.Viper, stakingPort uint16, ipResolutionFreq time.Duration) (ips.DynamicIPPort, dynamicip.Updater, error) {
+	publicIPv6 := v.GetString(PublicIPv6Key)
+	ipv6ResolutionService := v.GetString(PublicIPv6ResolutionServiceKey)
+	switch {
+	case publicIPv6 != "" && ipv6ResolutionService != "":
+		return nil, nil, fmt.Errorf("only one of --%s and --%s can be given", PublicIPv6Key, PublicIPv6ResolutionServiceKey)
+	case publicIPv6 != "":
+		ip := net.ParseIP(publicIPv6)
+		if ip == nil || ip.To4() != nil {
+			return nil, nil, fmt.Errorf("invalid IPv6 Address %s", publicIPv6)
+		}
+		return ips.NewDynamicIPPort(ip, stakingPort), dynamicip.NewNoUpdater(), nil
+	case ipv6ResolutionService != "":
+		resolver, err := dynamicip.NewIPv6Resolver(ipv6ResolutionService)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't create IPv6 IP resolver: %w", err)
+		}
+
+		ip, err := resolver.Resolve()
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't resolve public IPv6 IP: %w", err)
+		}
+		ipv6Port := ips.NewDynamicIPPort(ip, stakingPort)
+		return ipv6Port, dynamicip.NewUpdater(ipv6Port, resolver, ipResolutionFreq), nil
+	default:
+		return nil, dynamicip.NewNoUpdater(), nil
+	}
+}
+
 func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	config := profiler.Config{
 		Dir:         GetExpandedArg(v, ProfileDirKey),
@@ -848,11 +959,23 @@ func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 			AddPrimaryNetworkDelegatorFee: v.GetUint64(AddPrimaryNetworkDelegatorFeeKey),
 			AddSubnetValidatorFee:         v.GetUint64(AddSubnetValidatorFeeKey),
 			AddSubnetDelegatorFee:         v.GetUint64(AddSubnetDelegatorFeeKey),
+			DynamicFeeConfig:              genesis.GetTxFeeConfig(networkID).DynamicFeeConfig,
 		}
 	}
 	return genesis.GetTxFeeConfig(networkID)
 }
 
+func getDynamicFeesTime(v *viper.Viper, networkID uint32) (time.Time, error) {
+	if networkID == constants.MainnetID || networkID == constants.TahoeID || !v.IsSet(DynamicFeesTimeKey) {
+		return version.GetDynamicFeesTime(networkID), nil
+	}
+	dynamicFeesTime, err := time.Parse(time.RFC3339, v.GetString(DynamicFeesTimeKey))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("couldn't parse %s: %w", DynamicFeesTimeKey, err)
+	}
+	return dynamicFeesTime, nil
+}
+
 func getGenesisData(v *viper.Viper, networkID uint32) ([]byte, ids.ID, error) {
 	// try first loading genesis content directly from flag/env-var
 	if v.IsSet(GenesisConfigContentKey) {
@@ -889,6 +1012,22 @@ func getWhitelistedSubnets(v *viper.Viper) (set.Set[ids.ID], error) {
 	return whitelistedSubnetIDs, nil
 }
 
+// getNodeIDs parses the comma separated list of node ids set for [key].
+func getNodeIDs(v *viper.Viper, key string) (set.Set[ids.NodeID], error) {
+	nodeIDs := set.Set[ids.NodeID]{}
+	for _, id := range strings.Split(v.GetString(key), ",") {
+		if id == "" {
+			continue
+		}
+		nodeID, err := ids.NodeIDFromString(id)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s node id %q: %w", key, id, err)
+		}
+		nodeIDs.Add(nodeID)
+	}
+	return nodeIDs, nil
+}
+
 func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, error) {
 	var (
 		configBytes []byte
@@ -1152,6 +1291,12 @@ func parseSubnetConfigs(data []byte, defaultSubnetConfig chains.SubnetConfig) (c
 	if err := defaultSubnetConfig.ConsensusParameters.Valid(); err != nil {
 		return chains.SubnetConfig{}, fmt.Errorf("invalid consensus parameters: %w", err)
 	}
+
+	// Messages larger than the max burst size could never be handled.
+	bandwidthConfig := defaultSubnetConfig.BandwidthThrottlerConfig
+	if bandwidthConfig.RefillRate != 0 && bandwidthConfig.MaxBurstSize < constants.DefaultMaxMessageSize {
+		return chains.SubnetConfig{}, errSubnetBurstTooSmall
+	}
 	return defaultSubnetConfig, nil
 }
 
@@ -1359,6 +1504,10 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 
 	// Tx Fee
 	nodeConfig.TxFeeConfig = getTxFeeConfig(v, nodeConfig.NetworkID)
+	nodeConfig.DynamicFeesTime, err = getDynamicFeesTime(v, nodeConfig.NetworkID)
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	// Genesis Data
 	nodeConfig.GenesisBytes, nodeConfig.DjtxAssetID, err = getGenesisData(v, nodeConfig.NetworkID)
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/chains"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -459,6 +461,28 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 			},
 			errMessage: "",
 		},
+		"bandwidth burst size too small": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"bandwidthThrottlerConfig":{"bandwidthRefillRate": 1024, "bandwidthMaxBurstRate": 1024} }`,
+			testF: func(require *require.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				require.Nil(given)
+			},
+			errMessage: "subnet bandwidth max burst size must be at least",
+		},
+		"bandwidth config": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"bandwidthThrottlerConfig":{"bandwidthRefillRate": 1048576, "bandwidthMaxBurstRate": 4194304} }`,
+			testF: func(require *require.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+				require.Equal(uint64(1048576), config.BandwidthThrottlerConfig.RefillRate)
+				require.Equal(uint64(4194304), config.BandwidthThrottlerConfig.MaxBurstSize)
+				// must still respect defaults
+				require.Equal(20, config.ConsensusParameters.K)
+			},
+			errMessage: "",
+		},
 	}
 
 	for name, test := range tests {
@@ -629,3 +653,70 @@ func setupViper(configFilePath string) *viper.Viper {
 	}
 	return v
 }
+
+func TestGetNetworkConfigNodeIDs(t *testing.T) {
+	require := require.New(t)
+
+	sentryID := ids.GenerateTestNodeID()
+	validatorID := ids.GenerateTestNodeID()
+
+	v := setupViperFlags()
+	v.Set(NetworkAllowedNodeIDsKey, sentryID.String())
+	v.Set(NetworkPrivateNodeIDsKey, validatorID.String())
+	config, err := getNetworkConfig(v, time.Second)
+	require.NoError(err)
+	require.True(config.AllowedNodeIDs.Contains(sentryID))
+	require.Zero(config.DeniedNodeIDs.Len())
+	require.True(config.PrivateNodeIDs.Contains(validatorID))
+
+	v.Set(NetworkDeniedNodeIDsKey, sentryID.String())
+	_, err = getNetworkConfig(v, time.Second)
+	require.Error(err)
+
+	v = setupViperFlags()
+	v.Set(NetworkDeniedNodeIDsKey, "not a node id")
+	_, err = getNetworkConfig(v, time.Second)
+	require.Error(err)
+}
+
+func TestGetNetworkConfigTransport(t *testing.T) {
+	require := require.New(t)
+
+	v := setupViperFlags()
+	config, err := getNetworkConfig(v, time.Second)
+	require.NoError(err)
+	require.Equal(network.TCPTransport, config.Transport)
+
+	v.Set(NetworkTransportKey, network.QUICTransport)
+	config, err = getNetworkConfig(v, time.Second)
+	require.NoError(err)
+	require.Equal(network.QUICTransport, config.Transport)
+
+	v.Set(NetworkTransportKey, "udp")
+	_, err = getNetworkConfig(v, time.Second)
+	require.Error(err)
+}
+
+func TestGetIPv6Config(t *testing.T) {
+	require := require.New(t)
+
+	v := setupViperFlags()
+	ipv6Port, ipv6Updater, err := getIPv6Config(v, 9651, time.Minute)
+	require.NoError(err)
+	require.Nil(ipv6Port)
+	require.NotNil(ipv6Updater)
+
+	v.Set(PublicIPv6Key, "2001:db8::1")
+	ipv6Port, _, err = getIPv6Config(v, 9651, time.Minute)
+	require.NoError(err)
+	require.Equal("[2001:db8::1]:9651", ipv6Port.IPPort().String())
+
+	v.Set(PublicIPv6Key, "1.2.3.4")
+	_, _, err = getIPv6Config(v, 9651, time.Minute)
+	require.Error(err)
+
+	v.Set(PublicIPv6Key, "2001:db8::1")
+	v.Set(PublicIPv6ResolutionServiceKey, "opendns")
+	_, _, err = getIPv6Config(v, 9651, time.Minute)
+	require.Error(err)
+}
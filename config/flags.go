@@ -18,8 +18,11 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/database/leveldb"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
 	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/network"
 	"github.com/lasthyphen/dijetsnodego/trace"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/ulimit"
 	"github.com/lasthyphen/dijetsnodego/utils/units"
@@ -112,9 +115,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(AddPrimaryNetworkDelegatorFeeKey, genesis.LocalParams.AddPrimaryNetworkDelegatorFee, "Transaction fee, in nDJTX, for transactions that add new primary network delegators")
 	fs.Uint64(AddSubnetValidatorFeeKey, genesis.LocalParams.AddSubnetValidatorFee, "Transaction fee, in nDJTX, for transactions that add new subnet validators")
 	fs.Uint64(AddSubnetDelegatorFeeKey, genesis.LocalParams.AddSubnetDelegatorFee, "Transaction fee, in nDJTX, for transactions that add new subnet delegators")
+	fs.String(DynamicFeesTimeKey, "", "Time, in RFC3339 format, at which the P-chain switches to dynamic fees. If empty, dynamic fees are not activated. Ignored when running standard networks")
 
 	// Database
-	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s}", leveldb.Name, memdb.Name))
+	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s, %s}", leveldb.Name, pebble.Name, memdb.Name))
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
@@ -149,6 +153,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty")
 	fs.Duration(PublicIPResolutionFreqKey, 5*time.Minute, "Frequency at which this node resolves/updates its public IP and renew NAT mappings, if applicable")
 	fs.String(PublicIPResolutionServiceKey, "", "Only acceptable values are 'ifconfigco', 'opendns' or 'ifconfigme'. When provided, the node will use that service to periodically resolve/update its public IP")
+	fs.String(PublicIPv6Key, "", fmt.Sprintf("Public IPv6 IP of this node for P2P communication, advertised in addition to its public IP. If empty, the node only advertises one IP unless %s is given", PublicIPv6ResolutionServiceKey))
+	fs.String(PublicIPv6ResolutionServiceKey, "", "Only acceptable values are 'ifconfigco', 'opendns' or 'ifconfigme'. When provided, the node will use that service to periodically resolve/update its public IPv6 IP, advertised in addition to its public IP")
 
 	// Inbound Connection Throttling
 	fs.Duration(InboundConnUpgradeThrottlerCooldownKey, 10*time.Second, "Upgrade an inbound connection from a given IP at most once per this duration. If 0, don't rate-limit inbound connection upgrades")
@@ -168,11 +174,24 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.Bool(NetworkCompressionEnabledKey, true, "If true, compress certain outbound messages. This node will be able to parse compressed inbound messages regardless of this flag's value")
+	fs.String(NetworkCompressionTypeKey, compression.TypeGzip.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s, %s]. Messages are compressed with %s for peers that don't support the selected type. This node will be able to parse compressed inbound messages regardless of this flag's value", compression.TypeGzip, compression.TypeZstd, compression.TypeNone, compression.TypeGzip))
 	fs.Duration(NetworkMaxClockDifferenceKey, time.Minute, "Max allowed clock difference value between this node and peers")
 	fs.Bool(NetworkAllowPrivateIPsKey, true, "Allows the node to initiate outbound connection attempts to peers with private IPs")
 	fs.Bool(NetworkRequireValidatorToConnectKey, false, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
+	fs.String(NetworkAllowedNodeIDsKey, "", "Comma separated list of node ids this node will exclusively connect to. If non-empty, connections with all other nodes are refused. A validator run behind sentries should list its sentries here, and connect to them with --bootstrap-ids and --bootstrap-ips")
+	fs.String(NetworkDeniedNodeIDsKey, "", "Comma separated list of node ids this node will never connect to")
+	fs.String(NetworkPrivateNodeIDsKey, "", "Comma separated list of node ids whose IPs are never gossiped to peers. A sentry should list the validator it protects here")
 	fs.Uint(NetworkPeerReadBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Bool(NetworkOutboundPriorityQueueEnabledKey, false, "If true, outbound messages to each peer are queued in separate lanes for handshake, consensus, bootstrap and app messages, which are scheduled by weight and have their own byte limits. Otherwise, outbound messages to each peer are queued in a single FIFO")
+	fs.Duration(NetworkAddressBookMaxAgeKey, 7*24*time.Hour, "Maximum amount of time a persisted peer IP is kept without being learned again or confirmed by a connection. Persisted peer IPs are reconnected to on startup. If 0, peer IPs are not persisted")
+	fs.String(NetworkCaptureDirKey, "", "Directory every message exchanged with peers is captured to, for replaying it later. If empty, messages are not captured")
+	fs.Uint(NetworkCaptureMaxFileSizeKey, 100, "Size, in megabytes, a capture file can grow to before it is rotated")
+	fs.Uint(NetworkCaptureMaxFilesKey, 10, "Maximum number of rotated capture files that are kept. If 0, all rotated capture files are kept")
+	fs.String(NetworkTransportKey, network.TCPTransport, fmt.Sprintf("Transport used to connect to peers. Must be one of [%s, %s]. With %s, the staking port is also opened as a UDP port and peers that don't accept %s connections are dialed over %s", network.TCPTransport, network.QUICTransport, network.QUICTransport, network.QUICTransport, network.TCPTransport))
+	fs.Duration(NetworkReputationPenaltyHalflifeKey, 10*time.Minute, "Amount of time after which the penalty a peer received for misbehaving is halved. Peers are penalized for being benched, sending invalid messages, being throttled, gossiping invalid IP signatures and running incompatible versions")
+	fs.Float64(NetworkReputationSampleThresholdKey, 5, "Penalty at which a peer is only gossiped to if there aren't enough peers with a lower penalty. If 0, peers are gossiped to regardless of their penalty")
+	fs.Float64(NetworkReputationDisconnectThresholdKey, 20, "Penalty at which this node disconnects from a peer and refuses its connections until the penalty decays. If 0, peers are never disconnected because of their penalty")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
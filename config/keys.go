@@ -22,6 +22,7 @@ const (
 	AddPrimaryNetworkDelegatorFeeKey                   = "add-primary-network-delegator-fee"
 	AddSubnetValidatorFeeKey                           = "add-subnet-validator-fee"
 	AddSubnetDelegatorFeeKey                           = "add-subnet-delegator-fee"
+	DynamicFeesTimeKey                                 = "dynamic-fees-time"
 	UptimeRequirementKey                               = "uptime-requirement"
 	MinValidatorStakeKey                               = "min-validator-stake"
 	MaxValidatorStakeKey                               = "max-validator-stake"
@@ -40,6 +41,8 @@ const (
 	PublicIPKey                                        = "public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
+	PublicIPv6Key                                      = "public-ipv6"
+	PublicIPv6ResolutionServiceKey                     = "public-ipv6-resolution-service"
 	InboundConnUpgradeThrottlerCooldownKey             = "inbound-connection-throttling-cooldown"
 	InboundThrottlerMaxConnsPerSecKey                  = "inbound-connection-throttling-max-conns-per-sec"
 	OutboundConnectionThrottlingRpsKey                 = "outbound-connection-throttling-rps"
@@ -95,11 +98,24 @@ const (
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionEnabledKey                       = "network-compression-enabled"
+	NetworkCompressionTypeKey                          = "network-compression-type"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
+	NetworkAllowedNodeIDsKey                           = "network-allowed-node-ids"
+	NetworkDeniedNodeIDsKey                            = "network-denied-node-ids"
+	NetworkPrivateNodeIDsKey                           = "network-private-node-ids"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkAddressBookMaxAgeKey                        = "network-address-book-max-age"
+	NetworkOutboundPriorityQueueEnabledKey             = "network-outbound-priority-queue-enabled"
+	NetworkCaptureDirKey                               = "network-capture-dir"
+	NetworkCaptureMaxFileSizeKey                       = "network-capture-max-file-size"
+	NetworkCaptureMaxFilesKey                          = "network-capture-max-files"
+	NetworkTransportKey                                = "network-transport"
+	NetworkReputationPenaltyHalflifeKey                = "network-reputation-penalty-halflife"
+	NetworkReputationSampleThresholdKey                = "network-reputation-sample-threshold"
+	NetworkReputationDisconnectThresholdKey            = "network-reputation-disconnect-threshold"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
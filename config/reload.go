@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/lasthyphen/dijetsnodego/chains"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/node"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+var (
+	_ node.ConfigReloader = (*configReloader)(nil)
+
+	// reloadableKeys are the keys whose settings are applied when the config
+	// is reloaded. All other keys only take effect after a restart.
+	// WhitelistedSubnetsKey is only reloadable while subnets are added to it,
+	// as subnets can't stop being tracked while the node is running.
+	reloadableKeys = set.Set[string]{
+		LogLevelKey:                                 {},
+		LogDisplayLevelKey:                          {},
+		NetworkHealthMinPeersKey:                    {},
+		NetworkHealthMaxTimeSinceMsgReceivedKey:     {},
+		NetworkHealthMaxTimeSinceMsgSentKey:         {},
+		NetworkHealthMaxSendFailRateKey:             {},
+		NetworkPeerListNumValidatorIPsKey:           {},
+		NetworkPeerListValidatorGossipSizeKey:       {},
+		NetworkPeerListNonValidatorGossipSizeKey:    {},
+		NetworkPeerListPeersGossipSizeKey:           {},
+		InboundThrottlerBandwidthRefillRateKey:      {},
+		InboundThrottlerBandwidthMaxBurstSizeKey:    {},
+		InboundThrottlerMaxProcessingMsgsPerNodeKey: {},
+		InboundThrottlerAtLargeAllocSizeKey:         {},
+		InboundThrottlerVdrAllocSizeKey:             {},
+		InboundThrottlerNodeMaxAtLargeBytesKey:      {},
+		OutboundThrottlerAtLargeAllocSizeKey:        {},
+		OutboundThrottlerVdrAllocSizeKey:            {},
+		OutboundThrottlerNodeMaxAtLargeBytesKey:     {},
+		InboundConnUpgradeThrottlerCooldownKey:      {},
+		BenchlistFailThresholdKey:                   {},
+		BenchlistDurationKey:                        {},
+		BenchlistMinFailingDurationKey:              {},
+		ChainAliasesFileKey:                         {},
+		ChainAliasesContentKey:                      {},
+		WhitelistedSubnetsKey:                       {},
+	}
+)
+
+type configReloader struct {
+	args []string
+
+	lock sync.Mutex
+	// startViper is the config the node was started with. Keys that differ
+	// from it and aren't reloadable require a restart.
+	startViper *viper.Viper
+	// lastViper is the config that was last applied.
+	lastViper *viper.Viper
+	// config is the node config that was last applied.
+	config node.Config
+
+	// pendingViper and pendingConfig were returned by the last call to Reload
+	// and become the applied config when they are committed.
+	pendingViper  *viper.Viper
+	pendingConfig node.Config
+}
+
+// NewConfigReloader returns a reloader that re-reads the config of a node that
+// was started with the command line arguments [args]. [v] and [config] must be
+// the configs that were built from [args].
+func NewConfigReloader(v *viper.Viper, config node.Config, args []string) node.ConfigReloader {
+	return &configReloader{
+		args:       args,
+		startViper: v,
+		lastViper:  v,
+		config:     config,
+	}
+}
+
+// Reload re-reads the flags, environment variables and config file of the
+// node. Only the settings of the reloadable keys are re-parsed, as parsing the
+// others can have side effects, such as resolving the public IP of the node.
+// The returned config is only considered applied once Commit is called.
+func (r *configReloader) Reload() (*node.Config, []string, []string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := BuildViper(BuildFlagSet(), r.args)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	config := r.config
+	loggingConfig, err := getLoggingConfig(v)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	config.LoggingConfig.LogLevel = loggingConfig.LogLevel
+	config.LoggingConfig.DisplayLevel = loggingConfig.DisplayLevel
+
+	config.NetworkConfig, err = getNetworkConfig(v, r.config.NetworkConfig.HealthConfig.SendFailRateHalflife)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	config.BenchlistConfig, err = getBenchlistConfig(v, config.ConsensusParams.Alpha, config.ConsensusParams.K)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	config.ChainAliases, err = getChainAliases(v)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	whitelistedSubnets, err := getWhitelistedSubnets(v)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// Subnets can only be added to the tracked subnets while the node is
+	// running.
+	removedSubnets := set.NewSet[ids.ID](r.config.WhitelistedSubnets.Len())
+	removedSubnets.Union(r.config.WhitelistedSubnets)
+	removedSubnets.Difference(whitelistedSubnets)
+	canReloadSubnets := removedSubnets.Len() == 0
+	if canReloadSubnets {
+		newSubnets := set.NewSet[ids.ID](whitelistedSubnets.Len())
+		newSubnets.Union(whitelistedSubnets)
+		newSubnets.Difference(r.config.WhitelistedSubnets)
+
+		newSubnetConfigs, err := getSubnetConfigs(v, newSubnets.List())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		config.WhitelistedSubnets = whitelistedSubnets
+		config.SubnetConfigs = make(map[ids.ID]chains.SubnetConfig, len(r.config.SubnetConfigs)+len(newSubnetConfigs))
+		maps.Copy(config.SubnetConfigs, r.config.SubnetConfigs)
+		maps.Copy(config.SubnetConfigs, newSubnetConfigs)
+	}
+
+	reloadedKeys := set.Set[string]{}
+	for _, key := range changedKeys(r.lastViper, v) {
+		if reloadableKeys.Contains(key) && (key != WhitelistedSubnetsKey || canReloadSubnets) {
+			reloadedKeys.Add(key)
+		}
+	}
+	// The content of the chain aliases file may have changed even though its
+	// path didn't.
+	if !reflect.DeepEqual(r.config.ChainAliases, config.ChainAliases) && !reloadedKeys.Contains(ChainAliasesContentKey) {
+		reloadedKeys.Add(ChainAliasesFileKey)
+	}
+
+	restartRequiredKeys := []string{}
+	for _, key := range changedKeys(r.startViper, v) {
+		if !reloadableKeys.Contains(key) {
+			restartRequiredKeys = append(restartRequiredKeys, key)
+		}
+	}
+	if !canReloadSubnets {
+		restartRequiredKeys = append(restartRequiredKeys, WhitelistedSubnetsKey)
+		slices.Sort(restartRequiredKeys)
+	}
+
+	r.pendingViper = v
+	r.pendingConfig = config
+
+	reloadedKeysList := reloadedKeys.List()
+	slices.Sort(reloadedKeysList)
+	return &config, reloadedKeysList, restartRequiredKeys, nil
+}
+
+// Commit makes the config returned by the last call to Reload the config that
+// later reloads are compared against.
+func (r *configReloader) Commit() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pendingViper == nil {
+		return
+	}
+	r.lastViper = r.pendingViper
+	r.config = r.pendingConfig
+	r.pendingViper = nil
+	r.pendingConfig = node.Config{}
+}
+
+// changedKeys returns the sorted keys whose values differ between [before] and
+// [after]. Values are compared by their string representation, as the type of
+// a value depends on whether it was read from a flag or from the config file.
+func changedKeys(before, after *viper.Viper) []string {
+	keys := set.Set[string]{}
+	keys.Add(before.AllKeys()...)
+	keys.Add(after.AllKeys()...)
+
+	changed := []string{}
+	for key := range keys {
+		if fmt.Sprint(before.Get(key)) != fmt.Sprint(after.Get(key)) {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
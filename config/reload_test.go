@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/node"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+func TestConfigReloader(t *testing.T) {
+	require := require.New(t)
+
+	rootPath := t.TempDir()
+	configFilePath := setupConfigJSON(t, rootPath, `{
+		"log-level": "info",
+		"http-port": 9650,
+		"network-peer-list-num-validator-ips": 15
+	}`)
+	args := []string{fmt.Sprintf("--%s=%s", ConfigFileKey, configFilePath)}
+
+	v, err := BuildViper(BuildFlagSet(), args)
+	require.NoError(err)
+
+	config := node.Config{}
+	config.ConsensusParams = getConsensusConfig(v)
+	config.LoggingConfig, err = getLoggingConfig(v)
+	require.NoError(err)
+	config.NetworkConfig, err = getNetworkConfig(v, v.GetDuration(HealthCheckAveragerHalflifeKey))
+	require.NoError(err)
+
+	reloader := NewConfigReloader(v, config, args)
+
+	// Reloading an unchanged config doesn't change anything.
+	reloadedConfig, reloadedKeys, restartRequiredKeys, err := reloader.Reload()
+	require.NoError(err)
+	require.Empty(reloadedKeys)
+	require.Empty(restartRequiredKeys)
+	require.Equal(logging.Info, reloadedConfig.LoggingConfig.LogLevel)
+	require.EqualValues(15, reloadedConfig.NetworkConfig.PeerListNumValidatorIPs)
+	reloader.Commit()
+
+	setupConfigJSON(t, rootPath, `{
+		"log-level": "debug",
+		"http-port": 9651,
+		"network-peer-list-num-validator-ips": 20
+	}`)
+
+	reloadedConfig, reloadedKeys, restartRequiredKeys, err = reloader.Reload()
+	require.NoError(err)
+	require.Equal([]string{LogLevelKey, NetworkPeerListNumValidatorIPsKey}, reloadedKeys)
+	require.Equal([]string{HTTPPortKey}, restartRequiredKeys)
+	require.Equal(logging.Debug, reloadedConfig.LoggingConfig.LogLevel)
+	require.EqualValues(20, reloadedConfig.NetworkConfig.PeerListNumValidatorIPs)
+
+	// The changes are reported again until they are committed, as a node
+	// that failed to apply them still runs with the previous config.
+	_, reloadedKeys, restartRequiredKeys, err = reloader.Reload()
+	require.NoError(err)
+	require.Equal([]string{LogLevelKey, NetworkPeerListNumValidatorIPsKey}, reloadedKeys)
+	require.Equal([]string{HTTPPortKey}, restartRequiredKeys)
+	reloader.Commit()
+
+	// Keys that require a restart are reported until the node is restarted.
+	_, reloadedKeys, restartRequiredKeys, err = reloader.Reload()
+	require.NoError(err)
+	require.Empty(reloadedKeys)
+	require.Equal([]string{HTTPPortKey}, restartRequiredKeys)
+
+	// Subnets can be added to the tracked subnets.
+	subnetID0 := ids.GenerateTestID()
+	subnetID1 := ids.GenerateTestID()
+	setupConfigJSON(t, rootPath, fmt.Sprintf(`{
+		"log-level": "debug",
+		"http-port": 9651,
+		"network-peer-list-num-validator-ips": 20,
+		"%s": "%s,%s"
+	}`, WhitelistedSubnetsKey, subnetID0, subnetID1))
+
+	reloadedConfig, reloadedKeys, restartRequiredKeys, err = reloader.Reload()
+	require.NoError(err)
+	require.Equal([]string{WhitelistedSubnetsKey}, reloadedKeys)
+	require.Equal([]string{HTTPPortKey}, restartRequiredKeys)
+	require.Equal(set.Set[ids.ID]{subnetID0: {}, subnetID1: {}}, reloadedConfig.WhitelistedSubnets)
+	reloader.Commit()
+
+	// Subnets can only stop being tracked after a restart.
+	setupConfigJSON(t, rootPath, fmt.Sprintf(`{
+		"log-level": "debug",
+		"http-port": 9651,
+		"network-peer-list-num-validator-ips": 20,
+		"%s": "%s"
+	}`, WhitelistedSubnetsKey, subnetID0))
+
+	reloadedConfig, reloadedKeys, restartRequiredKeys, err = reloader.Reload()
+	require.NoError(err)
+	require.Empty(reloadedKeys)
+	require.Equal([]string{HTTPPortKey, WhitelistedSubnetsKey}, restartRequiredKeys)
+	require.Equal(set.Set[ids.ID]{subnetID0: {}, subnetID1: {}}, reloadedConfig.WhitelistedSubnets)
+}
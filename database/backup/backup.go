@@ -0,0 +1,457 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/migrate"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/perms"
+	"github.com/lasthyphen/dijetsnodego/version"
+)
+
+const (
+	// ManifestFileName is the name of the archive entry that contains the
+	// JSON encoded [Manifest].
+	ManifestFileName = "manifest.json"
+
+	// DatabaseFileName is the name of the archive entry that contains the
+	// key/value pairs of the database.
+	DatabaseFileName = "db"
+
+	// ChainDataDirName is the name of the archive directory that contains
+	// the files of the chain data directory.
+	ChainDataDirName = "chainData"
+)
+
+var (
+	errMissingEntry     = errors.New("archive entry missing")
+	errInvalidEntry     = errors.New("invalid archive entry")
+	errNotEmpty         = errors.New("restore target is not empty")
+	errKeyCountMismatch = errors.New("key count mismatch")
+	errChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Chain describes the state of a chain at the time a backup was created.
+type Chain struct {
+	ChainID            ids.ID      `json:"chainID"`
+	LastAcceptedID     ids.ID      `json:"lastAcceptedID"`
+	LastAcceptedHeight json.Uint64 `json:"lastAcceptedHeight"`
+}
+
+// Manifest describes the contents of a backup archive.
+type Manifest struct {
+	NetworkID       json.Uint32 `json:"networkID"`
+	NodeVersion     string      `json:"nodeVersion"`
+	DatabaseVersion string      `json:"databaseVersion"`
+	Timestamp       time.Time   `json:"timestamp"`
+	// Chains are the linear chains that were running when the backup was
+	// created. The last accepted blocks are read before the database snapshot
+	// is taken, so the archived database contains at least these blocks.
+	Chains []Chain `json:"chains"`
+	// NumKeys is the number of key/value pairs in the archived database.
+	NumKeys json.Uint64 `json:"numKeys"`
+	// Checksum is the checksum of the archived database, as calculated by
+	// [migrate.Checksum].
+	Checksum ids.ID `json:"checksum"`
+}
+
+// Create writes an archive containing a snapshot of [db] and the contents of
+// [chainDataDir] to [w]. [db] must support snapshots so that a consistent view
+// of it can be archived while it is being written to.
+//
+// Files in [chainDataDir] are copied one at a time, so chains that write to
+// the directory during the backup may be archived in an intermediate state.
+//
+// [manifest.NumKeys] and [manifest.Checksum] are populated by Create.
+func Create(w io.Writer, db database.Database, chainDataDir string, manifest *Manifest) error {
+	snapshot, err := database.NewSnapshot(db)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	zw := zip.NewWriter(w)
+	numKeys, checksum, err := writeDatabase(zw, snapshot)
+	if err != nil {
+		return fmt.Errorf("couldn't archive database: %w", err)
+	}
+	manifest.NumKeys = json.Uint64(numKeys)
+	manifest.Checksum = checksum
+
+	if chainDataDir != "" {
+		if err := writeDir(zw, chainDataDir, ChainDataDirName); err != nil {
+			return fmt.Errorf("couldn't archive chain data: %w", err)
+		}
+	}
+
+	// The manifest is written last so that it can include the database
+	// checksum.
+	manifestWriter, err := zw.Create(ManifestFileName)
+	if err != nil {
+		return err
+	}
+	manifestBytes, err := stdjson.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := manifestWriter.Write(manifestBytes); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeDatabase writes every key/value pair in [db] to a new archive entry.
+// Each key and value is prefixed with its uvarint encoded length, which
+// matches the stream of bytes hashed by [migrate.Checksum].
+func writeDatabase(zw *zip.Writer, db database.Iteratee) (uint64, ids.ID, error) {
+	entry, err := zw.Create(DatabaseFileName)
+	if err != nil {
+		return 0, ids.Empty, err
+	}
+
+	hasher := sha256.New()
+	w := bufio.NewWriter(io.MultiWriter(entry, hasher))
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		numKeys   uint64
+		lenPrefix [binary.MaxVarintLen64]byte
+	)
+	for it.Next() {
+		for _, b := range [][]byte{it.Key(), it.Value()} {
+			n := binary.PutUvarint(lenPrefix[:], uint64(len(b)))
+			if _, err := w.Write(lenPrefix[:n]); err != nil {
+				return 0, ids.Empty, err
+			}
+			if _, err := w.Write(b); err != nil {
+				return 0, ids.Empty, err
+			}
+		}
+		numKeys++
+	}
+	if err := it.Error(); err != nil {
+		return 0, ids.Empty, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, ids.Empty, err
+	}
+
+	checksum, err := ids.ToID(hasher.Sum(nil))
+	return numKeys, checksum, err
+}
+
+// writeDir writes every regular file under [dir] into the archive directory
+// [name].
+func writeDir(zw *zip.Writer, dir string, name string) error {
+	return filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(relPath))
+		header.Method = zip.Deflate
+
+		entry, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(entry, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	})
+}
+
+// ReadManifest returns the manifest of the archive stored at [archivePath].
+func ReadManifest(archivePath string) (*Manifest, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return readManifest(&zr.Reader)
+}
+
+func readManifest(zr *zip.Reader) (*Manifest, error) {
+	f, err := zr.Open(ManifestFileName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errMissingEntry, ManifestFileName)
+	}
+	defer f.Close()
+
+	manifest := &Manifest{}
+	if err := stdjson.NewDecoder(f).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("couldn't parse manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+type RestoreConfig struct {
+	// ArchivePath is the backup archive to restore from.
+	ArchivePath string
+	// DBDir is the directory to restore the database into. This is the same
+	// directory that is passed to the node with --db-dir.
+	DBDir    string
+	DBType   string
+	DBConfig []byte
+	// ChainDataDir is the directory to restore the chain data into. If empty,
+	// the chain data is not restored.
+	ChainDataDir string
+	// BatchSize is the number of bytes to buffer before writing to the
+	// database.
+	BatchSize int
+}
+
+// Restore writes the contents of the archive at [config.ArchivePath] into
+// [config.DBDir] and [config.ChainDataDir]. The database is restored into
+// "<DBDir>/<networkName>/<databaseVersion>", or
+// "<DBDir>/<networkName>/pebble/<databaseVersion>" for pebble, which must be
+// empty, as must [config.ChainDataDir].
+func Restore(config RestoreConfig, log logging.Logger) (*Manifest, error) {
+	openDB, err := migrate.NewOpener(config.DBType)
+	if err != nil {
+		return nil, err
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = migrate.DefaultBatchSize
+	}
+
+	zr, err := zip.OpenReader(config.ArchivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	manifest, err := readManifest(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
+	dbVersion, err := version.Parse(manifest.DatabaseVersion)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse database version: %w", err)
+	}
+
+	dbPath := filepath.Join(
+		config.DBDir,
+		constants.NetworkName(uint32(manifest.NetworkID)),
+	)
+	if config.DBType == pebble.Name {
+		// The node keeps its pebble databases in their own directory.
+		dbPath = filepath.Join(dbPath, pebble.Name)
+	}
+	dbPath = filepath.Join(dbPath, dbVersion.String())
+	if err := requireEmptyDir(dbPath); err != nil {
+		return nil, err
+	}
+	if config.ChainDataDir != "" {
+		if err := requireEmptyDir(config.ChainDataDir); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info("restoring database",
+		zap.String("path", dbPath),
+		zap.Uint64("numKeys", uint64(manifest.NumKeys)),
+	)
+	db, err := openDB(dbPath, config.DBConfig, log, "", prometheus.NewRegistry())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open db: %w", err)
+	}
+	err = restoreDatabase(&zr.Reader, db, manifest, config.BatchSize)
+	// Close errors are dropped in favor of reporting the restore error.
+	closeErr := db.Close()
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("couldn't restore database: %w", err)
+	case closeErr != nil:
+		return nil, closeErr
+	}
+
+	if config.ChainDataDir != "" {
+		log.Info("restoring chain data",
+			zap.String("path", config.ChainDataDir),
+		)
+		if err := restoreDir(&zr.Reader, ChainDataDirName, config.ChainDataDir); err != nil {
+			return nil, fmt.Errorf("couldn't restore chain data: %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+// restoreDatabase writes the archived key/value pairs into [db] and verifies
+// the result against [manifest].
+func restoreDatabase(zr *zip.Reader, db database.Database, manifest *Manifest, batchSize int) error {
+	f, err := zr.Open(DatabaseFileName)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errMissingEntry, DatabaseFileName)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	batch := db.NewBatch()
+	for {
+		key, err := readBytes(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		value, err := readBytes(r)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errInvalidEntry, err)
+		}
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	numKeys, checksum, err := migrate.Checksum(db)
+	if err != nil {
+		return err
+	}
+	if numKeys != uint64(manifest.NumKeys) {
+		return fmt.Errorf("%w: manifest has %d keys but database has %d",
+			errKeyCountMismatch,
+			manifest.NumKeys,
+			numKeys,
+		)
+	}
+	if checksum != manifest.Checksum {
+		return fmt.Errorf("%w: manifest has %s but database has %s",
+			errChecksumMismatch,
+			manifest.Checksum,
+			checksum,
+		)
+	}
+	return nil
+}
+
+// readBytes reads a uvarint length prefixed byte slice from [r]. If [r] is
+// exhausted before any bytes are read, [io.EOF] is returned.
+func readBytes(r *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidEntry, err)
+	}
+	return b, nil
+}
+
+// restoreDir writes every file in the archive directory [name] into [dir].
+func restoreDir(zr *zip.Reader, name string, dir string) error {
+	prefix := name + "/"
+	for _, f := range zr.File {
+		if !strings.HasPrefix(f.Name, prefix) || strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+
+		// Entries are rejected if they would be written outside of [dir].
+		relPath := path.Clean(strings.TrimPrefix(f.Name, prefix))
+		if path.IsAbs(relPath) || relPath == ".." || strings.HasPrefix(relPath, "../") {
+			return fmt.Errorf("%w: %s", errInvalidEntry, f.Name)
+		}
+		filePath := filepath.Join(dir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(filePath), perms.ReadWriteExecute); err != nil {
+			return err
+		}
+		if err := restoreFile(f, filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func restoreFile(f *zip.File, filePath string) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	w, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// requireEmptyDir returns an error if [dir] exists and contains any files.
+func requireEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: %s", errNotEmpty, dir)
+	}
+	return nil
+}
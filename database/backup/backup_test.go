@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"archive/zip"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/leveldb"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/database/migrate"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/perms"
+	"github.com/lasthyphen/dijetsnodego/version"
+)
+
+func populate(t *testing.T, db database.Database, numKeys int) {
+	require := require.New(t)
+
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		value := []byte(fmt.Sprintf("value-%d", i))
+		require.NoError(db.Put(key, value))
+	}
+}
+
+func newManifest() *Manifest {
+	return &Manifest{
+		NetworkID:       json.Uint32(constants.LocalID),
+		NodeVersion:     version.CurrentApp.String(),
+		DatabaseVersion: version.CurrentDatabase.String(),
+		Timestamp:       time.Unix(1, 0).UTC(),
+		Chains: []Chain{
+			{
+				ChainID:            ids.GenerateTestID(),
+				LastAcceptedID:     ids.GenerateTestID(),
+				LastAcceptedHeight: 10,
+			},
+		},
+	}
+}
+
+func createArchive(t *testing.T, db database.Database, chainDataDir string, manifest *Manifest) string {
+	require := require.New(t)
+
+	archivePath := filepath.Join(t.TempDir(), "backup.zip")
+	f, err := os.Create(archivePath)
+	require.NoError(err)
+	require.NoError(Create(f, db, chainDataDir, manifest))
+	require.NoError(f.Close())
+	return archivePath
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	for _, dbType := range []string{leveldb.Name, pebble.Name} {
+		t.Run(dbType, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			populate(t, db, 1000)
+			expectedKeys, expectedChecksum, err := migrate.Checksum(db)
+			require.NoError(err)
+
+			chainDataDir := t.TempDir()
+			chainDir := filepath.Join(chainDataDir, ids.GenerateTestID().String())
+			require.NoError(os.MkdirAll(chainDir, perms.ReadWriteExecute))
+			require.NoError(os.WriteFile(filepath.Join(chainDir, "data"), []byte("chain data"), perms.ReadWrite))
+
+			manifest := newManifest()
+			archivePath := createArchive(t, db, chainDataDir, manifest)
+			require.Equal(expectedKeys, uint64(manifest.NumKeys))
+			require.Equal(expectedChecksum, manifest.Checksum)
+
+			// Writes after the backup was created must not be restored.
+			require.NoError(db.Put([]byte("late"), []byte("write")))
+
+			readManifest, err := ReadManifest(archivePath)
+			require.NoError(err)
+			require.Equal(manifest, readManifest)
+
+			dbDir := t.TempDir()
+			restoredChainDataDir := filepath.Join(t.TempDir(), "chainData")
+			restoredManifest, err := Restore(RestoreConfig{
+				ArchivePath:  archivePath,
+				DBDir:        dbDir,
+				DBType:       dbType,
+				ChainDataDir: restoredChainDataDir,
+				BatchSize:    128,
+			}, logging.NoLog{})
+			require.NoError(err)
+			require.Equal(manifest, restoredManifest)
+
+			dbPath := filepath.Join(dbDir, constants.LocalName)
+			if dbType == pebble.Name {
+				dbPath = filepath.Join(dbPath, pebble.Name)
+			}
+			openDB, err := migrate.NewOpener(dbType)
+			require.NoError(err)
+			restoredDB, err := openDB(
+				filepath.Join(dbPath, version.CurrentDatabase.String()),
+				nil,
+				logging.NoLog{},
+				"",
+				prometheus.NewRegistry(),
+			)
+			require.NoError(err)
+			numKeys, checksum, err := migrate.Checksum(restoredDB)
+			require.NoError(err)
+			require.NoError(restoredDB.Close())
+			require.Equal(expectedKeys, numKeys)
+			require.Equal(expectedChecksum, checksum)
+
+			restoredChainDir := filepath.Join(restoredChainDataDir, filepath.Base(chainDir))
+			chainData, err := os.ReadFile(filepath.Join(restoredChainDir, "data"))
+			require.NoError(err)
+			require.Equal([]byte("chain data"), chainData)
+		})
+	}
+}
+
+func TestCreateSnapshotNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	// Embedding the interface hides the memdb's snapshot support.
+	db := struct{ database.Database }{memdb.New()}
+	err := Create(io.Discard, db, "", newManifest())
+	require.ErrorIs(err, database.ErrSnapshotNotSupported)
+}
+
+func TestRestoreNotEmpty(t *testing.T) {
+	require := require.New(t)
+
+	archivePath := createArchive(t, memdb.New(), "", newManifest())
+
+	chainDataDir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(chainDataDir, "data"), nil, perms.ReadWrite))
+
+	_, err := Restore(RestoreConfig{
+		ArchivePath:  archivePath,
+		DBDir:        t.TempDir(),
+		DBType:       leveldb.Name,
+		ChainDataDir: chainDataDir,
+	}, logging.NoLog{})
+	require.ErrorIs(err, errNotEmpty)
+}
+
+func TestRestoreDetectsMismatch(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	populate(t, db, 10)
+
+	manifest := newManifest()
+	archivePath := createArchive(t, db, "", manifest)
+
+	// Rewrite the archive with a manifest that doesn't match the database.
+	zr, err := zip.OpenReader(archivePath)
+	require.NoError(err)
+	tamperedPath := filepath.Join(t.TempDir(), "tampered.zip")
+	f, err := os.Create(tamperedPath)
+	require.NoError(err)
+	zw := zip.NewWriter(f)
+	for _, entry := range zr.File {
+		if entry.Name == ManifestFileName {
+			continue
+		}
+		require.NoError(zw.Copy(entry))
+	}
+	require.NoError(zr.Close())
+
+	manifest.Checksum = ids.GenerateTestID()
+	manifestBytes, err := stdjson.Marshal(manifest)
+	require.NoError(err)
+	w, err := zw.Create(ManifestFileName)
+	require.NoError(err)
+	_, err = w.Write(manifestBytes)
+	require.NoError(err)
+	require.NoError(zw.Close())
+	require.NoError(f.Close())
+
+	_, err = Restore(RestoreConfig{
+		ArchivePath: tamperedPath,
+		DBDir:       t.TempDir(),
+		DBType:      leveldb.Name,
+	}, logging.NoLog{})
+	require.ErrorIs(err, errChecksumMismatch)
+}
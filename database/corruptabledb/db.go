@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -67,6 +68,19 @@ func (db *Database) Delete(key []byte) error {
 	return db.handleError(db.Database.Delete(key))
 }
 
+// NewSnapshot returns a snapshot of the underlying database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	snapshot, err := database.NewSnapshot(db.Database)
+	if err == database.ErrSnapshotNotSupported {
+		// An unsupported operation doesn't indicate corruption
+		return nil, err
+	}
+	return snapshot, db.handleError(err)
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
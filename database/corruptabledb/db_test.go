@@ -22,6 +22,14 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		db := New(baseDB)
+		test(t, db)
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		baseDB := memdb.New()
@@ -135,6 +135,32 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithRange(start, end),
+		db:       db,
+	}
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithRange(start, end),
+		db:       db,
+	}
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
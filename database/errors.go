@@ -9,4 +9,6 @@ import "errors"
 var (
 	ErrClosed   = errors.New("closed")
 	ErrNotFound = errors.New("not found")
+
+	ErrSnapshotNotSupported = errors.New("snapshots not supported")
 )
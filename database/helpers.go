@@ -177,3 +177,21 @@ func ClearPrefix(readerDB Iteratee, deleterDB KeyValueDeleter, prefix []byte) er
 	}
 	return iterator.Error()
 }
+
+// PrefixUpperBound returns the smallest key that is larger than every key with
+// the provided prefix. If no such key exists, nil is returned, which
+// represents an unbounded upper limit.
+//
+// PrefixUpperBound can be used with NewIteratorWithRange and
+// NewReverseIteratorWithRange to iterate over the keys with a given prefix.
+func PrefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := make([]byte, i+1)
+			copy(upperBound, prefix)
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
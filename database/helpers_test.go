@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixUpperBound(t *testing.T) {
+	tests := []struct {
+		prefix   []byte
+		expected []byte
+	}{
+		{
+			prefix:   nil,
+			expected: nil,
+		},
+		{
+			prefix:   []byte{0x01},
+			expected: []byte{0x02},
+		},
+		{
+			prefix:   []byte{0x01, 0xFF},
+			expected: []byte{0x02},
+		},
+		{
+			prefix:   []byte{0xFF, 0xFF},
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, PrefixUpperBound(test.prefix))
+	}
+}
@@ -59,4 +59,13 @@ type Iteratee interface {
 	// database content with a particular key prefix starting at a specified
 	// key.
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
+
+	// NewIteratorWithRange creates an iterator over a subset of database
+	// content with keys in the range [start, end). If [end] is empty, the
+	// range isn't bounded from above.
+	NewIteratorWithRange(start, end []byte) Iterator
+
+	// NewReverseIteratorWithRange creates an iterator over the same keys as
+	// NewIteratorWithRange, but in the reverse order.
+	NewReverseIteratorWithRange(start, end []byte) Iterator
 }
@@ -64,9 +64,11 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iter)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -325,16 +327,43 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
+	return &iter{
+		db:       db,
+		Iterator: db.DB.NewIterator(startAndPrefixRange(start, prefix), nil),
 	}
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database keys in [start, end)
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
 	return &iter{
 		db:       db,
-		Iterator: db.DB.NewIterator(iterRange, nil),
+		Iterator: db.DB.NewIterator(newRange(start, end), nil),
 	}
 }
 
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database keys in [start, end)
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return &iter{
+		db:       db,
+		Iterator: db.DB.NewIterator(newRange(start, end), nil),
+		reverse:  true,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snap, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: snap,
+	}, nil
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -437,10 +466,88 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writerDeleter.Delete(key)
 }
 
+// snapshot is a wrapper around a levelDB snapshot to conform to the database
+// interfaces.
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(new(util.Range), nil),
+	}
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(&util.Range{Start: start}, nil),
+	}
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil),
+	}
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(startAndPrefixRange(start, prefix), nil),
+	}
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot keys in [start, end)
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(newRange(start, end), nil),
+	}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot keys in [start, end)
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(newRange(start, end), nil),
+		reverse:  true,
+	}
+}
+
 type iter struct {
 	db *Database
 	iterator.Iterator
 
+	// reverse is true iff the iterator moves from the last key to the first
+	reverse     bool
+	initialized bool
+
 	key, val []byte
 	err      error
 }
@@ -454,7 +561,16 @@ func (it *iter) Next() bool {
 		return false
 	}
 
-	hasNext := it.Iterator.Next()
+	var hasNext bool
+	switch {
+	case !it.reverse:
+		hasNext = it.Iterator.Next()
+	case it.initialized:
+		hasNext = it.Iterator.Prev()
+	default:
+		it.initialized = true
+		hasNext = it.Iterator.Last()
+	}
 	if hasNext {
 		it.key = utils.CopyBytes(it.Iterator.Key())
 		it.val = utils.CopyBytes(it.Iterator.Value())
@@ -480,9 +596,31 @@ func (it *iter) Value() []byte {
 	return it.val
 }
 
+// startAndPrefixRange returns the range of keys that start with [prefix] and
+// are greater than or equal to [start]
+func startAndPrefixRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
+
+// newRange returns the range of keys in [start, end). An empty [end] is
+// treated as unbounded.
+func newRange(start, end []byte) *util.Range {
+	if len(end) == 0 {
+		end = nil
+	}
+	return &util.Range{
+		Start: start,
+		Limit: end,
+	}
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -32,6 +32,20 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		folder := t.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		test(t, db)
+
+		// The database may have been closed by the test, so we don't care if it
+		// errors here.
+		_ = db.Close()
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		folder := f.TempDir()
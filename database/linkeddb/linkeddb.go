@@ -4,6 +4,7 @@
 package linkeddb
 
 import (
+	"bytes"
 	"sync"
 
 	"golang.org/x/exp/maps"
@@ -34,6 +35,9 @@ type LinkedDB interface {
 
 	NewIterator() database.Iterator
 	NewIteratorWithStart(start []byte) database.Iterator
+	NewIteratorWithRange(start, end []byte) database.Iterator
+	NewReverseIterator() database.Iterator
+	NewReverseIteratorWithRange(start, end []byte) database.Iterator
 }
 
 type linkedDB struct {
@@ -45,7 +49,7 @@ type linkedDB struct {
 	headKeyIsSynced, headKeyExists, headKeyIsUpdated, updatedHeadKeyExists bool
 	headKey, updatedHeadKey                                                []byte
 	// these variables provide caching for the nodes.
-	nodeCache    cache.Cacher // key -> *node
+	nodeCache    cache.Cacher[string, *node] // key -> *node
 	updatedNodes map[string]*node
 
 	// db is the underlying database that this list is stored in.
@@ -64,7 +68,7 @@ type node struct {
 
 func New(db database.Database, cacheSize int) LinkedDB {
 	return &linkedDB{
-		nodeCache:    &cache.LRU{Size: cacheSize},
+		nodeCache:    cache.NewLRU[string, *node](cacheSize),
 		updatedNodes: make(map[string]*node),
 		db:           db,
 		batch:        db.NewBatch(),
@@ -253,6 +257,92 @@ func (ldb *linkedDB) NewIteratorWithStart(start []byte) database.Iterator {
 	return ldb.NewIterator()
 }
 
+// NewIteratorWithRange returns an iterator that starts at [start] and stops
+// before [end].
+// This iterator does not guarantee that keys are returned in lexicographic
+// order.
+// If [start] is not in the list, starts iterating from the list head. If [end]
+// is not in the list, or doesn't follow [start], iterates until the list tail.
+func (ldb *linkedDB) NewIteratorWithRange(start, end []byte) database.Iterator {
+	it := ldb.NewIteratorWithStart(start).(*iterator)
+	if hasEndKey, err := ldb.Has(end); err == nil && hasEndKey {
+		it.hasEnd = true
+		it.end = end
+	}
+	return it
+}
+
+// NewReverseIterator returns an iterator that starts at the list tail and
+// moves towards the list head. This returns keys in the order they were
+// originally added to the list.
+// Finding the tail requires walking the whole list, so the first call to Next
+// is linear in the size of the list.
+func (ldb *linkedDB) NewReverseIterator() database.Iterator {
+	return &iterator{
+		ldb:     ldb,
+		reverse: true,
+	}
+}
+
+// NewReverseIteratorWithRange returns an iterator over the same keys as
+// NewIteratorWithRange, but in the reverse order. This requires that [start]
+// precedes [end] in the list if both are present.
+// If [end] is not in the list, starts iterating from the list tail, which
+// requires walking the whole list.
+func (ldb *linkedDB) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	it := &iterator{
+		ldb:         ldb,
+		initialized: true,
+		reverse:     true,
+	}
+	if hasStartKey, err := ldb.Has(start); err == nil && hasStartKey {
+		it.hasEnd = true
+		it.end = start
+	}
+
+	ldb.lock.RLock()
+	defer ldb.lock.RUnlock()
+
+	// Start from the node before the end key
+	endNode, err := ldb.getNode(end)
+	switch {
+	case err == database.ErrNotFound:
+		// If the end key isn't present, start from the tail
+		tailKey, err := ldb.getTailKey()
+		it.nextKey = tailKey
+		it.exhausted = err != nil
+		if err != database.ErrNotFound {
+			it.err = err
+		}
+	case err != nil:
+		it.exhausted = true
+		it.err = err
+	default:
+		it.nextKey = endNode.Previous
+		it.exhausted = !endNode.HasPrevious || (it.hasEnd && bytes.Equal(end, start))
+	}
+	return it
+}
+
+// getTailKey returns the key of the last node in the list. Assumes the read
+// lock is held.
+func (ldb *linkedDB) getTailKey() ([]byte, error) {
+	key, err := ldb.getHeadKey()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		n, err := ldb.getNode(key)
+		if err != nil {
+			return nil, err
+		}
+		if !n.HasNext {
+			return key, nil
+		}
+		key = n.Next
+	}
+}
+
 func (ldb *linkedDB) getHeadKey() ([]byte, error) {
 	// If the ldb read lock is held, then there needs to be additional
 	// synchronization here to avoid racy behavior.
@@ -300,8 +390,7 @@ func (ldb *linkedDB) getNode(key []byte) (node, error) {
 	defer ldb.cacheLock.Unlock()
 
 	keyStr := string(key)
-	if nodeIntf, exists := ldb.nodeCache.Get(keyStr); exists {
-		n := nodeIntf.(*node)
+	if n, exists := ldb.nodeCache.Get(keyStr); exists {
 		if n == nil {
 			return node{}, database.ErrNotFound
 		}
@@ -310,9 +399,7 @@ func (ldb *linkedDB) getNode(key []byte) (node, error) {
 
 	nodeBytes, err := ldb.db.Get(nodeKey(key))
 	if err == database.ErrNotFound {
-		// Passing [nil] without the pointer cast would result in a panic when
-		// performing the type assertion in the above cache check.
-		ldb.nodeCache.Put(keyStr, (*node)(nil))
+		ldb.nodeCache.Put(keyStr, nil)
 		return node{}, err
 	}
 	if err != nil {
@@ -366,6 +453,14 @@ type iterator struct {
 	initialized, exhausted bool
 	key, value, nextKey    []byte
 	err                    error
+
+	// reverse is true iff the iterator moves from the list tail towards the
+	// list head.
+	reverse bool
+	// If hasEnd is true, iteration stops before [end] is returned when moving
+	// forwards, or after [end] is returned when moving in reverse.
+	hasEnd bool
+	end    []byte
 }
 
 func (it *iterator) Next() bool {
@@ -382,7 +477,15 @@ func (it *iterator) Next() bool {
 	// If the iterator was not yet initialized, do it now.
 	if !it.initialized {
 		it.initialized = true
-		headKey, err := it.ldb.getHeadKey()
+		var (
+			firstKey []byte
+			err      error
+		)
+		if it.reverse {
+			firstKey, err = it.ldb.getTailKey()
+		} else {
+			firstKey, err = it.ldb.getHeadKey()
+		}
 		if err == database.ErrNotFound {
 			it.exhausted = true
 			it.key = nil
@@ -396,7 +499,14 @@ func (it *iterator) Next() bool {
 			it.err = err
 			return false
 		}
-		it.nextKey = headKey
+		it.nextKey = firstKey
+	}
+
+	if !it.reverse && it.hasEnd && bytes.Equal(it.nextKey, it.end) {
+		it.exhausted = true
+		it.key = nil
+		it.value = nil
+		return false
 	}
 
 	nextNode, err := it.ldb.getNode(it.nextKey)
@@ -415,8 +525,13 @@ func (it *iterator) Next() bool {
 	}
 	it.key = it.nextKey
 	it.value = nextNode.Value
-	it.nextKey = nextNode.Next
-	it.exhausted = !nextNode.HasNext
+	if it.reverse {
+		it.nextKey = nextNode.Previous
+		it.exhausted = !nextNode.HasPrevious || (it.hasEnd && bytes.Equal(it.key, it.end))
+	} else {
+		it.nextKey = nextNode.Next
+		it.exhausted = !nextNode.HasNext
+	}
 	return true
 }
 
@@ -326,6 +326,82 @@ func TestMultipleLinkedDBIteratorStart(t *testing.T) {
 	iterator.Release()
 }
 
+func TestLinkedDBIteratorRange(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	// The list is ordered from the most recently added key to the least
+	// recently added key: key3, key2, key1, key0.
+	keys := [][]byte{
+		[]byte("key0"),
+		[]byte("key1"),
+		[]byte("key2"),
+		[]byte("key3"),
+	}
+	for _, key := range keys {
+		require.NoError(ldb.Put(key, key))
+	}
+
+	tests := []struct {
+		name         string
+		it           database.Iterator
+		expectedKeys [][]byte
+	}{
+		{
+			name:         "forward range",
+			it:           ldb.NewIteratorWithRange(keys[2], keys[0]),
+			expectedKeys: [][]byte{keys[2], keys[1]},
+		},
+		{
+			name:         "forward range missing end",
+			it:           ldb.NewIteratorWithRange(keys[2], []byte("missing")),
+			expectedKeys: [][]byte{keys[2], keys[1], keys[0]},
+		},
+		{
+			name:         "forward empty range",
+			it:           ldb.NewIteratorWithRange(keys[1], keys[1]),
+			expectedKeys: nil,
+		},
+		{
+			name:         "reverse",
+			it:           ldb.NewReverseIterator(),
+			expectedKeys: keys,
+		},
+		{
+			name:         "reverse range",
+			it:           ldb.NewReverseIteratorWithRange(keys[2], keys[0]),
+			expectedKeys: [][]byte{keys[1], keys[2]},
+		},
+		{
+			name:         "reverse range missing start",
+			it:           ldb.NewReverseIteratorWithRange([]byte("missing"), keys[1]),
+			expectedKeys: [][]byte{keys[2], keys[3]},
+		},
+		{
+			name:         "reverse range missing end",
+			it:           ldb.NewReverseIteratorWithRange(keys[2], []byte("missing")),
+			expectedKeys: [][]byte{keys[0], keys[1], keys[2]},
+		},
+		{
+			name:         "reverse empty range",
+			it:           ldb.NewReverseIteratorWithRange(keys[1], keys[1]),
+			expectedKeys: nil,
+		},
+	}
+	for _, test := range tests {
+		keys := [][]byte(nil)
+		for test.it.Next() {
+			keys = append(keys, test.it.Key())
+			require.Equal(test.it.Key(), test.it.Value(), test.name)
+		}
+		require.NoError(test.it.Error(), test.name)
+		require.Equal(test.expectedKeys, keys, test.name)
+		test.it.Release()
+	}
+}
+
 func TestSingleLinkedDBIteratorStart(t *testing.T) {
 	require := require.New(t)
 
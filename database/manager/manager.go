@@ -17,6 +17,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database/leveldb"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/database/meterdb"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
 	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
 	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
@@ -96,6 +97,28 @@ func NewLevelDB(
 	)
 }
 
+// NewPebbleDB creates a database manager of pebble instances at [filePath]
+// by creating a database instance from each directory with a version <=
+// [currentVersion].
+func NewPebbleDB(
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	currentVersion *version.Semantic,
+	namespace string,
+	reg prometheus.Registerer,
+) (Manager, error) {
+	return new(
+		pebble.New,
+		dbDirPath,
+		dbConfig,
+		log,
+		currentVersion,
+		namespace,
+		reg,
+	)
+}
+
 // new creates a database manager at [filePath] by creating a database instance
 // from each directory with a version <= [currentVersion]. If
 // [includePreviousVersions], opens previous database versions and includes them
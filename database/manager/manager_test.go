@@ -15,6 +15,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database/leveldb"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/database/meterdb"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
 	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/version"
@@ -50,6 +51,36 @@ func TestNewSingleLevelDB(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestNewSinglePebbleDB(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	v1 := version.Semantic1_0_0
+
+	dbPath := filepath.Join(dir, v1.String())
+	db, err := pebble.New(dbPath, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	err = db.Close()
+	require.NoError(err)
+
+	manager, err := NewPebbleDB(dir, nil, logging.NoLog{}, v1, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	semDB := manager.Current()
+	cmp := semDB.Version.Compare(v1)
+	require.Equal(0, cmp, "incorrect version on current database")
+
+	_, exists := manager.Previous()
+	require.False(exists, "there should be no previous database")
+
+	dbs := manager.GetDatabases()
+	require.Len(dbs, 1)
+
+	err = manager.Close()
+	require.NoError(err)
+}
+
 func TestNewCreatesSingleDB(t *testing.T) {
 	require := require.New(t)
 
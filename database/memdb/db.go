@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"github.com/lasthyphen/dijetsnodego/database"
@@ -24,9 +25,10 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iterator)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -135,24 +137,42 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.db))
-	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
-	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([][]byte, 0, len(keys))
-	for _, key := range keys {
-		values = append(values, db.db[key])
+	return newIterator(db, db.db, startAndPrefixFilter(start, prefix), false)
+}
+
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(start, end, false)
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(start, end, true)
+}
+
+func (db *Database) newRangeIterator(start, end []byte, reverse bool) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
-	return &iterator{
-		db:     db,
-		keys:   keys,
-		values: values,
+	return newIterator(db, db.db, rangeFilter(start, end), reverse)
+}
+
+// NewSnapshot returns a read-only view of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
 	}
+
+	// Values are never modified in place, so a shallow copy of the map is
+	// sufficient to isolate the snapshot from future writes.
+	return &snapshot{
+		db:   db,
+		data: maps.Clone(db.db),
+	}, nil
 }
 
 func (db *Database) Compact(_, _ []byte) error {
@@ -246,6 +266,61 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// newIterator returns an iterator over the keys in [data] that satisfy
+// [include]. If [reverse] is true, the keys are returned in descending order.
+// The iterator will report [database.ErrClosed] once [db] is closed. Assumes
+// the lock protecting [data] is held.
+func newIterator(
+	db *Database,
+	data map[string][]byte,
+	include func(key string) bool,
+	reverse bool,
+) database.Iterator {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if include(key) {
+			keys = append(keys, key)
+		}
+	}
+	// Keys need to be in sorted order
+	if reverse {
+		slices.SortFunc(keys, func(a, b string) int {
+			return strings.Compare(b, a)
+		})
+	} else {
+		slices.Sort(keys)
+	}
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, data[key])
+	}
+	return &iterator{
+		db:     db,
+		keys:   keys,
+		values: values,
+	}
+}
+
+// startAndPrefixFilter returns a filter that includes the keys that start
+// with [prefix] and are greater than or equal to [start].
+func startAndPrefixFilter(start, prefix []byte) func(string) bool {
+	startString := string(start)
+	prefixString := string(prefix)
+	return func(key string) bool {
+		return strings.HasPrefix(key, prefixString) && key >= startString
+	}
+}
+
+// rangeFilter returns a filter that includes the keys in [start, end). An
+// empty [end] is treated as unbounded.
+func rangeFilter(start, end []byte) func(string) bool {
+	startString := string(start)
+	endString := string(end)
+	return func(key string) bool {
+		return key >= startString && (len(endString) == 0 || key < endString)
+	}
+}
+
 type iterator struct {
 	db          *Database
 	initialized bool
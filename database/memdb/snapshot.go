@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package memdb
+
+import (
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/nodb"
+	"github.com/lasthyphen/dijetsnodego/utils"
+)
+
+var _ database.Snapshot = (*snapshot)(nil)
+
+// snapshot is a copy of the contents of a memory database at the time the
+// snapshot was taken.
+type snapshot struct {
+	db *Database
+
+	lock sync.RWMutex
+	// data is set to nil once the snapshot has been released
+	data map[string][]byte
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	_, ok := s.data[string(key)]
+	return ok, nil
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if entry, ok := s.data[string(key)]; ok {
+		return utils.CopyBytes(entry), nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.db, s.data, startAndPrefixFilter(start, prefix), false)
+}
+
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return s.newRangeIterator(start, end, false)
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return s.newRangeIterator(start, end, true)
+}
+
+func (s *snapshot) newRangeIterator(start, end []byte, reverse bool) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.db, s.data, rangeFilter(start, end), reverse)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.data = nil
+}
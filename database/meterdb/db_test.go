@@ -26,6 +26,15 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		db, err := New("", prometheus.NewRegistry(), baseDB)
+		require.NoError(t, err)
+		test(t, db)
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		baseDB := memdb.New()
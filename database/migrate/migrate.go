@@ -0,0 +1,418 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/leveldb"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/perms"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/version"
+)
+
+const (
+	// ProgressFileName is the name of the file, stored in the destination
+	// directory, that records how far each versioned database has been
+	// copied.
+	ProgressFileName = "migration.json"
+
+	// DefaultBatchSize is the default number of bytes that are buffered
+	// before being written to the destination database.
+	DefaultBatchSize = 4 * units.MiB
+)
+
+var (
+	errUnknownDBType    = errors.New("unknown database type")
+	errSameDirectory    = errors.New("source and destination directories must differ")
+	errNoDatabases      = errors.New("no versioned databases found")
+	errKeyCountMismatch = errors.New("key count mismatch")
+	errChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Opener creates, or opens, a database stored at the provided path.
+type Opener func(
+	path string,
+	config []byte,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+) (database.Database, error)
+
+// NewOpener returns the [Opener] of the on-disk database backend named
+// [dbType].
+func NewOpener(dbType string) (Opener, error) {
+	switch dbType {
+	case leveldb.Name:
+		return leveldb.New, nil
+	case pebble.Name:
+		return pebble.New, nil
+	default:
+		return nil, fmt.Errorf("%w: %q should be one of {%s, %s}",
+			errUnknownDBType,
+			dbType,
+			leveldb.Name,
+			pebble.Name,
+		)
+	}
+}
+
+type Config struct {
+	// SourcePath is the directory that contains the versioned databases to
+	// copy. This is the same layout that is used by the database manager.
+	SourcePath   string
+	SourceType   string
+	SourceConfig []byte
+
+	// DestPath is the directory that the versioned databases will be copied
+	// into.
+	DestPath   string
+	DestType   string
+	DestConfig []byte
+
+	// BatchSize is the number of bytes that are buffered before being written
+	// to the destination database. Progress is recorded after every batch.
+	BatchSize int
+}
+
+// Result reports the verified contents of a migrated versioned database.
+type Result struct {
+	Version  *version.Semantic
+	Keys     uint64
+	Checksum ids.ID
+}
+
+// progress is the persisted state of a migration. It allows an interrupted
+// migration to be resumed without re-copying completed databases.
+type progress struct {
+	// Databases maps each database version to the state of its copy.
+	Databases map[string]*dbProgress `json:"databases"`
+}
+
+type dbProgress struct {
+	// LastKey is the last key that was durably written to the destination.
+	LastKey []byte `json:"lastKey"`
+	// Complete is true once every key has been copied and verified.
+	Complete bool `json:"complete"`
+}
+
+// Run copies every versioned database in [config.SourcePath] into
+// [config.DestPath]. Databases are copied key by key, which preserves every
+// prefixed namespace. Once a database has been copied, the number of keys
+// and a checksum over the contents of both databases are compared.
+//
+// If Run is interrupted, calling it again with the same [config] will resume
+// the migration from the last recorded batch.
+func Run(config Config, log logging.Logger) ([]Result, error) {
+	srcPath, err := filepath.Abs(config.SourcePath)
+	if err != nil {
+		return nil, err
+	}
+	dstPath, err := filepath.Abs(config.DestPath)
+	if err != nil {
+		return nil, err
+	}
+	if srcPath == dstPath {
+		return nil, errSameDirectory
+	}
+
+	openSrc, err := NewOpener(config.SourceType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %w", err)
+	}
+	openDst, err := NewOpener(config.DestType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %w", err)
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+
+	versions, err := versionedDatabases(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dstPath, perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+	progressPath := filepath.Join(dstPath, ProgressFileName)
+	state, err := readProgress(progressPath)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(versions))
+	for _, dbVersion := range versions {
+		versionStr := dbVersion.String()
+		dbState, ok := state.Databases[versionStr]
+		if !ok {
+			dbState = &dbProgress{}
+			state.Databases[versionStr] = dbState
+		}
+
+		src, err := openSrc(filepath.Join(srcPath, versionStr), config.SourceConfig, log, "", prometheus.NewRegistry())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't open source db %s: %w", versionStr, err)
+		}
+		dst, err := openDst(filepath.Join(dstPath, versionStr), config.DestConfig, log, "", prometheus.NewRegistry())
+		if err != nil {
+			_ = src.Close()
+			return nil, fmt.Errorf("couldn't open destination db %s: %w", versionStr, err)
+		}
+
+		result, err := migrate(src, dst, dbVersion, dbState, config.BatchSize, log, func() error {
+			return writeProgress(progressPath, state)
+		})
+		// Close errors are dropped in favor of reporting the migration error.
+		srcCloseErr := src.Close()
+		dstCloseErr := dst.Close()
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("failed to migrate db %s: %w", versionStr, err)
+		case srcCloseErr != nil:
+			return nil, srcCloseErr
+		case dstCloseErr != nil:
+			return nil, dstCloseErr
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// migrate copies [src] into [dst] starting after [state.LastKey] and then
+// verifies the contents of both databases. [checkpoint] is called whenever
+// [state] is updated.
+func migrate(
+	src database.Database,
+	dst database.Database,
+	dbVersion *version.Semantic,
+	state *dbProgress,
+	batchSize int,
+	log logging.Logger,
+	checkpoint func() error,
+) (Result, error) {
+	if !state.Complete {
+		log.Info("copying database",
+			zap.Stringer("version", dbVersion),
+			zap.Binary("resumeAfter", state.LastKey),
+		)
+		err := Copy(src, dst, state.LastKey, batchSize, func(lastKey []byte) error {
+			state.LastKey = lastKey
+			return checkpoint()
+		})
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	log.Info("verifying database",
+		zap.Stringer("version", dbVersion),
+	)
+	srcKeys, srcChecksum, err := Checksum(src)
+	if err != nil {
+		return Result{}, fmt.Errorf("couldn't checksum source: %w", err)
+	}
+	dstKeys, dstChecksum, err := Checksum(dst)
+	if err != nil {
+		return Result{}, fmt.Errorf("couldn't checksum destination: %w", err)
+	}
+	if srcKeys != dstKeys {
+		return Result{}, fmt.Errorf("%w: source has %d keys but destination has %d",
+			errKeyCountMismatch,
+			srcKeys,
+			dstKeys,
+		)
+	}
+	if srcChecksum != dstChecksum {
+		return Result{}, fmt.Errorf("%w: source has %s but destination has %s",
+			errChecksumMismatch,
+			srcChecksum,
+			dstChecksum,
+		)
+	}
+
+	if !state.Complete {
+		state.Complete = true
+		if err := checkpoint(); err != nil {
+			return Result{}, err
+		}
+	}
+
+	log.Info("migrated database",
+		zap.Stringer("version", dbVersion),
+		zap.Uint64("numKeys", srcKeys),
+		zap.Stringer("checksum", srcChecksum),
+	)
+	return Result{
+		Version:  dbVersion,
+		Keys:     srcKeys,
+		Checksum: srcChecksum,
+	}, nil
+}
+
+// Copy writes every key in [src] that is greater than [after] into [dst]. If
+// [after] is nil, every key is copied. After each batch of roughly
+// [batchSize] bytes is written, [onBatch] is called with the last key that
+// was written.
+func Copy(
+	src database.Iteratee,
+	dst database.Batcher,
+	after []byte,
+	batchSize int,
+	onBatch func(lastKey []byte) error,
+) error {
+	it := src.NewIteratorWithStart(after)
+	defer it.Release()
+
+	batch := dst.NewBatch()
+	var lastKey []byte
+	for it.Next() {
+		key := it.Key()
+		if after != nil && bytes.Equal(key, after) {
+			// The start key was already copied.
+			continue
+		}
+		if err := batch.Put(key, it.Value()); err != nil {
+			return err
+		}
+		lastKey = key
+
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+
+		if err := onBatch(lastKey); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return onBatch(lastKey)
+}
+
+// Checksum returns the number of keys in [db] along with a hash of every
+// key/value pair, in iteration order.
+func Checksum(db database.Iteratee) (uint64, ids.ID, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		numKeys   uint64
+		hasher    = sha256.New()
+		lenPrefix [binary.MaxVarintLen64]byte
+	)
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+
+		// Length prefixes ensure that distinct key/value boundaries can't
+		// produce the same stream of bytes.
+		n := binary.PutUvarint(lenPrefix[:], uint64(len(key)))
+		_, _ = hasher.Write(lenPrefix[:n])
+		_, _ = hasher.Write(key)
+		n = binary.PutUvarint(lenPrefix[:], uint64(len(value)))
+		_, _ = hasher.Write(lenPrefix[:n])
+		_, _ = hasher.Write(value)
+		numKeys++
+	}
+	if err := it.Error(); err != nil {
+		return 0, ids.Empty, err
+	}
+
+	checksum, err := ids.ToID(hasher.Sum(nil))
+	return numKeys, checksum, err
+}
+
+// versionedDatabases returns the versions of the databases stored in [path],
+// sorted from newest to oldest.
+func versionedDatabases(path string) ([]*version.Semantic, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*version.Semantic, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dbVersion, err := version.Parse(entry.Name())
+		if err != nil {
+			// Directories that don't match the expected version format aren't
+			// databases, so they are ignored.
+			continue
+		}
+		versions = append(versions, dbVersion)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w in %s", errNoDatabases, path)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) > 0
+	})
+	return versions, nil
+}
+
+func readProgress(path string) (*progress, error) {
+	state := &progress{
+		Databases: make(map[string]*dbProgress),
+	}
+	progressBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(progressBytes, state); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %w", path, err)
+	}
+	if state.Databases == nil {
+		state.Databases = make(map[string]*dbProgress)
+	}
+	return state, nil
+}
+
+func writeProgress(path string, state *progress) error {
+	progressBytes, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	// The progress is written to a temporary file first so that an
+	// interruption can't leave a partially written progress file behind.
+	tmpPath := path + ".tmp"
+	if err := perms.WriteFile(tmpPath, progressBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/leveldb"
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
+	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/version"
+)
+
+func populate(t *testing.T, db database.Database, numKeys int) {
+	require := require.New(t)
+
+	for _, prefix := range []string{"P", "X", "C"} {
+		prefixed := prefixdb.New([]byte(prefix), db)
+		for i := 0; i < numKeys; i++ {
+			key := []byte(fmt.Sprintf("key-%05d", i))
+			value := []byte(fmt.Sprintf("value-%s-%d", prefix, i))
+			require.NoError(prefixed.Put(key, value))
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	versions := []*version.Semantic{
+		version.Semantic1_0_0,
+		{Major: 1, Minor: 4, Patch: 5},
+	}
+	for _, dbVersion := range versions {
+		db, err := leveldb.New(filepath.Join(srcDir, dbVersion.String()), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		require.NoError(err)
+		populate(t, db, 100)
+		require.NoError(db.Close())
+	}
+
+	results, err := Run(Config{
+		SourcePath: srcDir,
+		SourceType: leveldb.Name,
+		DestPath:   dstDir,
+		DestType:   pebble.Name,
+		BatchSize:  128,
+	}, logging.NoLog{})
+	require.NoError(err)
+	require.Len(results, 2)
+
+	// The newest database should be migrated first.
+	require.Equal(versions[1], results[0].Version)
+	require.Equal(versions[0], results[1].Version)
+
+	for _, result := range results {
+		require.Equal(uint64(300), result.Keys)
+
+		db, err := pebble.New(filepath.Join(dstDir, result.Version.String()), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		require.NoError(err)
+
+		value, err := prefixdb.New([]byte("X"), db).Get([]byte("key-00042"))
+		require.NoError(err)
+		require.Equal([]byte("value-X-42"), value)
+
+		require.NoError(db.Close())
+	}
+
+	// Running the migration again should only verify the copied databases.
+	rerunResults, err := Run(Config{
+		SourcePath: srcDir,
+		SourceType: leveldb.Name,
+		DestPath:   dstDir,
+		DestType:   pebble.Name,
+	}, logging.NoLog{})
+	require.NoError(err)
+	require.Equal(results, rerunResults)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+
+	_, err := Run(Config{
+		SourcePath: dir,
+		SourceType: leveldb.Name,
+		DestPath:   dir,
+		DestType:   pebble.Name,
+	}, logging.NoLog{})
+	require.ErrorIs(err, errSameDirectory)
+
+	_, err = Run(Config{
+		SourcePath: dir,
+		SourceType: memdb.Name,
+		DestPath:   t.TempDir(),
+		DestType:   pebble.Name,
+	}, logging.NoLog{})
+	require.ErrorIs(err, errUnknownDBType)
+
+	_, err = Run(Config{
+		SourcePath: dir,
+		SourceType: leveldb.Name,
+		DestPath:   t.TempDir(),
+		DestType:   pebble.Name,
+	}, logging.NoLog{})
+	require.ErrorIs(err, errNoDatabases)
+}
+
+func TestCopyResume(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 50)
+
+	// Copy the first batch and then simulate an interruption.
+	errInterrupted := errors.New("interrupted")
+	dst := memdb.New()
+	var lastKey []byte
+	err := Copy(src, dst, nil, 64, func(key []byte) error {
+		lastKey = key
+		return errInterrupted
+	})
+	require.ErrorIs(err, errInterrupted)
+	require.NotNil(lastKey)
+
+	copiedKeys, _, err := Checksum(dst)
+	require.NoError(err)
+	require.Less(copiedKeys, uint64(150))
+
+	// Resume the copy after the last recorded key.
+	err = Copy(src, dst, lastKey, 64, func(key []byte) error {
+		lastKey = key
+		return nil
+	})
+	require.NoError(err)
+
+	srcKeys, srcChecksum, err := Checksum(src)
+	require.NoError(err)
+	dstKeys, dstChecksum, err := Checksum(dst)
+	require.NoError(err)
+	require.Equal(uint64(150), srcKeys)
+	require.Equal(srcKeys, dstKeys)
+	require.Equal(srcChecksum, dstChecksum)
+}
+
+func TestMigrateDetectsMismatch(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 10)
+
+	// The destination already contains a key that isn't in the source.
+	dst := memdb.New()
+	require.NoError(dst.Put([]byte("extra"), []byte("value")))
+
+	_, err := migrate(src, dst, version.Semantic1_0_0, &dbProgress{}, DefaultBatchSize, logging.NoLog{}, func() error {
+		return nil
+	})
+	require.ErrorIs(err, errKeyCountMismatch)
+
+	// The destination has the same number of keys but a different value.
+	dst = memdb.New()
+	state := &dbProgress{}
+	_, err = migrate(src, dst, version.Semantic1_0_0, state, DefaultBatchSize, logging.NoLog{}, func() error {
+		return nil
+	})
+	require.NoError(err)
+	require.True(state.Complete)
+
+	require.NoError(prefixdb.New([]byte("P"), dst).Put([]byte("key-00001"), []byte("modified")))
+	_, err = migrate(src, dst, version.Semantic1_0_0, state, DefaultBatchSize, logging.NoLog{}, func() error {
+		return nil
+	})
+	require.ErrorIs(err, errChecksumMismatch)
+}
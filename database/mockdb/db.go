@@ -34,6 +34,8 @@ type Database struct {
 	OnNewIteratorWithStart          func([]byte) database.Iterator
 	OnNewIteratorWithPrefix         func([]byte) database.Iterator
 	OnNewIteratorWithStartAndPrefix func([]byte, []byte) database.Iterator
+	OnNewIteratorWithRange          func([]byte, []byte) database.Iterator
+	OnNewReverseIteratorWithRange   func([]byte, []byte) database.Iterator
 	OnCompact                       func([]byte, []byte) error
 	OnClose                         func() error
 	OnHealthCheck                   func(context.Context) (interface{}, error)
@@ -107,6 +109,20 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return db.OnNewIteratorWithStartAndPrefix(start, prefix)
 }
 
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	if db.OnNewIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewIteratorWithRange(start, end)
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithRange(start, end)
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	if db.OnCompact == nil {
 		return errNoFunction
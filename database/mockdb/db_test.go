@@ -44,6 +44,12 @@ func TestDefaultError(t *testing.T) {
 	if iterator := db.NewIteratorWithStartAndPrefix([]byte{}, []byte{}); iterator != nil {
 		t.Fatal("should have errored")
 	}
+	if iterator := db.NewIteratorWithRange([]byte{}, []byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if iterator := db.NewReverseIteratorWithRange([]byte{}, []byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
 	if err := db.Compact([]byte{}, []byte{}); err == nil {
 		t.Fatal("should have errored")
 	}
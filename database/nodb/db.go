@@ -63,6 +63,16 @@ func (*Database) NewIteratorWithStartAndPrefix(_, _ []byte) database.Iterator {
 	return &Iterator{}
 }
 
+// NewIteratorWithRange returns a new empty iterator
+func (*Database) NewIteratorWithRange(_, _ []byte) database.Iterator {
+	return &Iterator{}
+}
+
+// NewReverseIteratorWithRange returns a new empty iterator
+func (*Database) NewReverseIteratorWithRange(_, _ []byte) database.Iterator {
+	return &Iterator{}
+}
+
 // Compact returns nil
 func (*Database) Compact(_, _ []byte) error {
 	return database.ErrClosed
@@ -0,0 +1,529 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+const (
+	// Name is the name of this database for database switches
+	Name = "pebble"
+
+	// DefaultCacheSize is the number of bytes to use for block caching in
+	// pebble.
+	DefaultCacheSize = 512 * units.MiB
+
+	// DefaultMemTableSize is the number of bytes to use for each memtable.
+	DefaultMemTableSize = 64 * units.MiB
+
+	// DefaultMaxOpenFiles is the number of files descriptors to cap pebble
+	// to use.
+	DefaultMaxOpenFiles = 4096
+
+	// DefaultBytesPerSync is the number of bytes of sstable data written
+	// between background syncs.
+	DefaultBytesPerSync = 512 * units.KiB
+
+	// DefaultMetricUpdateFrequency is the frequency to poll the pebble
+	// metrics.
+	DefaultMetricUpdateFrequency = 10 * time.Second
+
+	// pebbleByteOverhead is the number of bytes of constant overhead that
+	// should be added to a batch size per operation.
+	pebbleByteOverhead = 8
+)
+
+var (
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+
+	errInvalidOperation = errors.New("invalid batch operation")
+)
+
+// Database is a persistent key-value store backed by pebble. Apart from basic
+// data storage functionality it also supports batch writes and iterating over
+// the keyspace in binary-alphabetical order.
+type Database struct {
+	lock          sync.RWMutex
+	pebbleDB      *pebble.DB
+	closed        bool
+	openIterators set.Set[*iter]
+	openSnapshots set.Set[*snapshot]
+	writeOptions  *pebble.WriteOptions
+	// metrics is only initialized and used when [MetricUpdateFrequency] is > 0
+	// in the config
+	metrics *metrics
+	// closeCh is closed when Close() is called.
+	closeCh chan struct{}
+}
+
+type config struct {
+	// CacheSize is the number of bytes of the block cache shared by all the
+	// sstables.
+	//
+	// The default value is 512MiB.
+	CacheSize int64 `json:"cacheSize"`
+	// BytesPerSync is the number of bytes of sstable data written between
+	// background syncs.
+	//
+	// The default value is 512KiB.
+	BytesPerSync int `json:"bytesPerSync"`
+	// WALBytesPerSync is the number of bytes of WAL data written between
+	// background syncs. Zero disables background syncing of the WAL.
+	WALBytesPerSync int `json:"walBytesPerSync"`
+	// MemTableSize is the size of a single memtable. Writes are stalled once
+	// MemTableStopWritesThreshold memtables are queued for flushing.
+	//
+	// The default value is 64MiB.
+	MemTableSize int `json:"memTableSize"`
+	// MemTableStopWritesThreshold is the number of queued memtables after
+	// which writes are stopped.
+	//
+	// The default value is 8.
+	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"`
+	// MaxOpenFiles is the maximum number of open files that pebble may use.
+	//
+	// The default value is 4096.
+	MaxOpenFiles int `json:"maxOpenFiles"`
+	// MaxConcurrentCompactions is the maximum number of concurrent
+	// compactions.
+	//
+	// The default value is 1.
+	MaxConcurrentCompactions int `json:"maxConcurrentCompactions"`
+	// L0CompactionThreshold is the amount of L0 read-amplification necessary
+	// to trigger an L0 compaction.
+	//
+	// The default value is 4.
+	L0CompactionThreshold int `json:"l0CompactionThreshold"`
+	// L0StopWritesThreshold is the amount of L0 read-amplification at which
+	// writes are stopped.
+	//
+	// The default value is 12.
+	L0StopWritesThreshold int `json:"l0StopWritesThreshold"`
+	// Sync specifies whether writes should be synced to disk before
+	// returning.
+	//
+	// The default is false.
+	Sync bool `json:"sync"`
+	// MetricUpdateFrequency is the frequency to poll pebble metrics.
+	// If <= 0, pebble metrics aren't polled.
+	//
+	// The default value is 10s.
+	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+}
+
+// New returns a wrapped pebble database.
+func New(file string, configBytes []byte, log logging.Logger, namespace string, reg prometheus.Registerer) (database.Database, error) {
+	parsedConfig := config{
+		CacheSize:                   DefaultCacheSize,
+		BytesPerSync:                DefaultBytesPerSync,
+		MemTableSize:                DefaultMemTableSize,
+		MemTableStopWritesThreshold: 8,
+		MaxOpenFiles:                DefaultMaxOpenFiles,
+		MaxConcurrentCompactions:    1,
+		L0CompactionThreshold:       4,
+		L0StopWritesThreshold:       12,
+		MetricUpdateFrequency:       DefaultMetricUpdateFrequency,
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+
+	log.Info("creating pebble",
+		zap.Reflect("config", parsedConfig),
+	)
+
+	cache := pebble.NewCache(parsedConfig.CacheSize)
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:                       cache,
+		BytesPerSync:                parsedConfig.BytesPerSync,
+		WALBytesPerSync:             parsedConfig.WALBytesPerSync,
+		MemTableSize:                parsedConfig.MemTableSize,
+		MemTableStopWritesThreshold: parsedConfig.MemTableStopWritesThreshold,
+		MaxOpenFiles:                parsedConfig.MaxOpenFiles,
+		MaxConcurrentCompactions: func() int {
+			return parsedConfig.MaxConcurrentCompactions
+		},
+		L0CompactionThreshold: parsedConfig.L0CompactionThreshold,
+		L0StopWritesThreshold: parsedConfig.L0StopWritesThreshold,
+	}
+	opts.Experimental.ReadSamplingMultiplier = -1 // Disable seek compaction
+
+	var dbMetrics *metrics
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		var err error
+		dbMetrics, err = newMetrics(namespace, reg)
+		if err != nil {
+			return nil, err
+		}
+		opts.EventListener = dbMetrics.eventListener()
+	}
+
+	db, err := pebble.Open(file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedDB := &Database{
+		pebbleDB:      db,
+		openIterators: set.Set[*iter]{},
+		openSnapshots: set.Set[*snapshot]{},
+		writeOptions:  &pebble.WriteOptions{Sync: parsedConfig.Sync},
+		metrics:       dbMetrics,
+		closeCh:       make(chan struct{}),
+	}
+	if dbMetrics != nil {
+		go wrappedDB.pollMetrics(parsedConfig.MetricUpdateFrequency)
+	}
+	return wrappedDB, nil
+}
+
+// pollMetrics updates the metrics every [frequency] until the database is
+// closed.
+func (db *Database) pollMetrics(frequency time.Duration) {
+	t := time.NewTicker(frequency)
+	defer t.Stop()
+
+	for {
+		db.lock.RLock()
+		if !db.closed {
+			db.metrics.updateMetrics(db.pebbleDB)
+		}
+		db.lock.RUnlock()
+
+		select {
+		case <-t.C:
+		case <-db.closeCh:
+			return
+		}
+	}
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+	return has(db.pebbleDB, key)
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return get(db.pebbleDB, key)
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Set(key, value, db.writeOptions))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Delete(key, db.writeOptions))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		// Pebble panics when a batch is created after the database is closed,
+		// so an empty batch is returned instead. It reports
+		// [database.ErrClosed] once it's written.
+		return &batch{
+			db:    db,
+			batch: &pebble.Batch{},
+		}
+	}
+	return &batch{
+		db:    db,
+		batch: db.pebbleDB.NewBatch(),
+	}
+}
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.newIterator(db.pebbleDB, &pebble.IterOptions{}, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.newIterator(db.pebbleDB, startAndPrefixOptions(start, nil), false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(db.pebbleDB, startAndPrefixOptions(nil, prefix), false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(db.pebbleDB, startAndPrefixOptions(start, prefix), false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database keys in [start, end)
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(db.pebbleDB, rangeOptions(start, end), false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database keys in [start, end)
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(db.pebbleDB, rangeOptions(start, end), true)
+}
+
+// newIterator returns an iterator over [reader], which must be either the
+// underlying pebble database or one of its snapshots. If [reverse] is true,
+// the iterator moves from the last key to the first.
+func (db *Database) newIterator(reader iterReader, opts *pebble.IterOptions, reverse bool) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.newIteratorLocked(reader, opts, reverse)
+}
+
+// newIteratorLocked is newIterator but assumes the database lock is held.
+func (db *Database) newIteratorLocked(reader iterReader, opts *pebble.IterOptions, reverse bool) database.Iterator {
+	if db.closed {
+		return &iter{
+			db:     db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	it := &iter{
+		db:      db,
+		iter:    reader.NewIter(opts),
+		reverse: reverse,
+	}
+	db.openIterators.Add(it)
+	return it
+}
+
+// NewSnapshot returns a read-only view of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	s := &snapshot{
+		db:       db,
+		snapshot: db.pebbleDB.NewSnapshot(),
+	}
+	db.openSnapshots.Add(s)
+	return s, nil
+}
+
+// Compact the underlying DB for the given key range.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+// Therefore if both are nil then it will compact entire DB.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		// Pebble treats a nil [limit] as a key before all keys, so the largest
+		// key in the database is used to construct an exclusive upper bound
+		// that covers every key.
+		it := db.pebbleDB.NewIter(&pebble.IterOptions{})
+		if !it.Last() {
+			// The database is empty, so there is nothing to compact.
+			return updateError(it.Close())
+		}
+		lastKey := it.Key()
+		limit = make([]byte, len(lastKey)+1)
+		copy(limit, lastKey)
+		if err := it.Close(); err != nil {
+			return updateError(err)
+		}
+	}
+
+	if bytes.Compare(start, limit) >= 0 {
+		// The range is empty, so there is nothing to compact.
+		return nil
+	}
+	return updateError(db.pebbleDB.Compact(start, limit, true /*=parallelize*/))
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+	close(db.closeCh)
+
+	// Pebble refuses to close cleanly while iterators are still open, so any
+	// iterators that haven't been released are closed here. They will report
+	// [database.ErrClosed] on their next call to Next.
+	for it := range db.openIterators {
+		it.close()
+	}
+	db.openIterators.Clear()
+	for s := range db.openSnapshots {
+		s.release()
+	}
+	db.openSnapshots.Clear()
+	return updateError(db.pebbleDB.Close())
+}
+
+func (db *Database) HealthCheck(context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+// iterReader is the subset of the pebble database and snapshot methods
+// required to create iterators.
+type iterReader interface {
+	NewIter(*pebble.IterOptions) *pebble.Iterator
+}
+
+// getReader is the subset of the pebble database and snapshot methods
+// required to read values.
+type getReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+}
+
+func has(reader getReader, key []byte) (bool, error) {
+	_, closer, err := reader.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+func get(reader getReader, key []byte) ([]byte, error) {
+	data, closer, err := reader.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	// The returned slice is only valid until [closer] is closed, so it must be
+	// copied.
+	value := make([]byte, len(data))
+	copy(value, data)
+	return value, closer.Close()
+}
+
+// startAndPrefixOptions returns the iterator bounds of the keys that start
+// with [prefix] and are greater than or equal to [start].
+//
+// Pebble retains the bounds for the lifetime of the iterator, so they are
+// copied to allow the caller to modify [start] and [prefix].
+func startAndPrefixOptions(start, prefix []byte) *pebble.IterOptions {
+	lowerBound := prefix
+	if bytes.Compare(start, prefix) == 1 {
+		lowerBound = start
+	}
+	return &pebble.IterOptions{
+		LowerBound: utils.CopyBytes(lowerBound),
+		UpperBound: database.PrefixUpperBound(prefix),
+	}
+}
+
+// rangeOptions returns the iterator bounds of the keys in [start, end). An
+// empty [end] is treated as unbounded.
+//
+// Pebble retains the bounds for the lifetime of the iterator, so they are
+// copied to allow the caller to modify [start] and [end].
+func rangeOptions(start, end []byte) *pebble.IterOptions {
+	if len(end) == 0 {
+		return &pebble.IterOptions{
+			LowerBound: utils.CopyBytes(start),
+		}
+	}
+	if bytes.Compare(start, end) == 1 {
+		// The range is empty. Pebble requires the lower bound to not exceed
+		// the upper bound.
+		start = end
+	}
+	return &pebble.IterOptions{
+		LowerBound: utils.CopyBytes(start),
+		UpperBound: utils.CopyBytes(end),
+	}
+}
+
+func updateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pebble.ErrClosed):
+		return database.ErrClosed
+	case errors.Is(err, pebble.ErrNotFound):
+		return database.ErrNotFound
+	default:
+		return err
+	}
+}
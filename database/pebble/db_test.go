@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		folder := t.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		if err != nil {
+			t.Fatalf("pebble.New(%q, logging.NoLog{}) errored with %s", folder, err)
+		}
+
+		test(t, db)
+
+		// The database may have been closed by the test, so we don't care if it
+		// errors here.
+		_ = db.Close()
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		folder := t.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		test(t, db)
+
+		// The database may have been closed by the test, so we don't care if it
+		// errors here.
+		_ = db.Close()
+	}
+}
+
+func FuzzInterface(f *testing.F) {
+	for _, test := range database.FuzzTests {
+		folder := f.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		if err != nil {
+			require.NoError(f, err)
+		}
+
+		test(f, db)
+
+		// The database may have been closed by the test, so we don't care if it
+		// errors here.
+		_ = db.Close()
+	}
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			folder := b.TempDir()
+
+			db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			bench(b, db, "pebble", keys, values)
+
+			// The database may have been closed by the test, so we don't care if it
+			// errors here.
+			_ = db.Close()
+		}
+	}
+}
+
+func TestNewBatchAfterClose(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Delete([]byte("key")))
+	require.ErrorIs(batch.Write(), database.ErrClosed)
+
+	batch.Reset()
+	require.Zero(batch.Size())
+}
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	configBytes := []byte(fmt.Sprintf(`{"metricUpdateFrequency":%d}`, time.Millisecond))
+	db, err := New(t.TempDir(), configBytes, logging.NoLog{}, "db", registry)
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	require.NoError(db.Compact(nil, nil))
+
+	// Reports the value of each metric, or of its level 0 series.
+	gather := func() map[string]float64 {
+		metrics, err := registry.Gather()
+		require.NoError(err)
+
+		values := make(map[string]float64)
+		for _, metric := range metrics {
+			for _, m := range metric.GetMetric() {
+				if len(m.GetLabel()) != 0 && m.GetLabel()[0].GetValue() != "0" {
+					continue
+				}
+				switch {
+				case m.GetCounter() != nil:
+					values[metric.GetName()] = m.GetCounter().GetValue()
+				case m.GetGauge() != nil:
+					values[metric.GetName()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+		return values
+	}
+	require.Eventually(
+		func() bool {
+			values := gather()
+			return values["db_mem_comps"] > 0 && values["db_comps"] > 0
+		},
+		time.Second,
+		time.Millisecond,
+	)
+
+	values := gather()
+	require.Contains(values, "db_writes_delayed")
+	require.Contains(values, "db_block_cache_hits")
+	require.Contains(values, "db_block_cache_misses")
+	require.Contains(values, "db_table_count")
+
+	require.NoError(db.Close())
+}
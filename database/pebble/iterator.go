@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"github.com/cockroachdb/pebble"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/utils"
+)
+
+var _ database.Iterator = (*iter)(nil)
+
+type iter struct {
+	db   *Database
+	iter *pebble.Iterator
+
+	// true iff the iterator moves from the last key to the first
+	reverse bool
+	// true iff the underlying pebble iterator has been positioned
+	initialized bool
+	// true iff the underlying pebble iterator has been closed
+	closed bool
+
+	key, val []byte
+	err      error
+}
+
+func (it *iter) Next() bool {
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	switch {
+	case it.err != nil:
+		it.key = nil
+		it.val = nil
+		return false
+	case it.db.closed || it.closed:
+		// Short-circuit and set an error if the underlying database has been
+		// closed.
+		it.key = nil
+		it.val = nil
+		it.err = database.ErrClosed
+		return false
+	}
+
+	var hasNext bool
+	switch {
+	case it.initialized && it.reverse:
+		hasNext = it.iter.Prev()
+	case it.initialized:
+		hasNext = it.iter.Next()
+	case it.reverse:
+		it.initialized = true
+		hasNext = it.iter.Last()
+	default:
+		it.initialized = true
+		hasNext = it.iter.First()
+	}
+
+	if !hasNext {
+		it.key = nil
+		it.val = nil
+		return false
+	}
+
+	it.key = utils.CopyBytes(it.iter.Key())
+	it.val = utils.CopyBytes(it.iter.Value())
+	return true
+}
+
+func (it *iter) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	if it.closed {
+		return nil
+	}
+	return updateError(it.iter.Error())
+}
+
+func (it *iter) Key() []byte {
+	return it.key
+}
+
+func (it *iter) Value() []byte {
+	return it.val
+}
+
+func (it *iter) Release() {
+	it.db.lock.Lock()
+	defer it.db.lock.Unlock()
+
+	it.close()
+	it.db.openIterators.Remove(it)
+}
+
+// close closes the underlying pebble iterator. Assumes the database lock is
+// held.
+func (it *iter) close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	if err := it.iter.Close(); err != nil && it.err == nil {
+		it.err = updateError(err)
+	}
+}
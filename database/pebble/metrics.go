@@ -0,0 +1,254 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+var levelLabels = []string{"level"}
+
+type metrics struct {
+	// total number of writes that have been stalled due to compaction or
+	// flushing
+	writesDelayedCount prometheus.Counter
+	// total amount of time (in ns) that writes have been stalled due to
+	// compaction or flushing
+	writesDelayedDuration prometheus.Counter
+	// set to 1 if writes are currently being stalled
+	writeIsDelayed prometheus.Gauge
+
+	// stallLock guards [stallStart].
+	stallLock sync.Mutex
+	// time the current write stall began, or the zero time if writes aren't
+	// stalled
+	stallStart time.Time
+
+	// number of currently alive snapshots
+	aliveSnapshots prometheus.Gauge
+
+	// total number of bytes of cached data blocks
+	blockCacheSize prometheus.Gauge
+	// total number of block cache hits
+	blockCacheHits prometheus.Counter
+	// total number of block cache misses
+	blockCacheMisses prometheus.Counter
+
+	// number of sublevels in level 0, which is the read amplification of
+	// level 0
+	level0Sublevels prometheus.Gauge
+
+	// number of tables per level
+	levelTableCount *prometheus.GaugeVec
+	// size of each level
+	levelSize *prometheus.GaugeVec
+	// amount of bytes read while compacting each level
+	levelReads *prometheus.CounterVec
+	// amount of bytes written while compacting or flushing into each level
+	levelWrites *prometheus.CounterVec
+
+	// total number of memtable flushes performed
+	memCompactions prometheus.Counter
+	// total number of compactions performed
+	compactions prometheus.Counter
+	// estimated number of bytes that need to be compacted for the database to
+	// reach a stable state
+	compactionDebt prometheus.Gauge
+
+	priorStats *pebble.Metrics
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		writesDelayedCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "writes_delayed",
+			Help:      "number of cumulative write stalls due to compaction or flushing",
+		}),
+		writesDelayedDuration: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "writes_delayed_duration",
+			Help:      "amount of time (in ns) that writes have been stalled due to compaction or flushing",
+		}),
+		writeIsDelayed: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "write_delayed",
+			Help:      "1 if writes are currently being stalled due to compaction or flushing",
+		}),
+
+		aliveSnapshots: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_snapshots",
+			Help:      "number of currently alive snapshots",
+		}),
+
+		blockCacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "block_cache_size",
+			Help:      "total size of cached blocks",
+		}),
+		blockCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_hits",
+			Help:      "total number of block cache hits",
+		}),
+		blockCacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_misses",
+			Help:      "total number of block cache misses",
+		}),
+
+		level0Sublevels: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "level_0_sublevels",
+			Help:      "number of sublevels in level 0",
+		}),
+
+		levelTableCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "table_count",
+				Help:      "number of tables allocated by level",
+			},
+			levelLabels,
+		),
+		levelSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "amount of bytes allocated by level",
+			},
+			levelLabels,
+		),
+		levelReads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reads",
+				Help:      "amount of bytes read during compaction by level",
+			},
+			levelLabels,
+		),
+		levelWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "amount of bytes written during compaction and flushing by level",
+			},
+			levelLabels,
+		),
+
+		memCompactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mem_comps",
+			Help:      "total number of memtable flushes performed",
+		}),
+		compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comps",
+			Help:      "total number of compactions performed",
+		}),
+		compactionDebt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "compaction_debt",
+			Help:      "estimated number of bytes that need to be compacted",
+		}),
+
+		priorStats: &pebble.Metrics{},
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.writesDelayedCount),
+		reg.Register(m.writesDelayedDuration),
+		reg.Register(m.writeIsDelayed),
+
+		reg.Register(m.aliveSnapshots),
+
+		reg.Register(m.blockCacheSize),
+		reg.Register(m.blockCacheHits),
+		reg.Register(m.blockCacheMisses),
+
+		reg.Register(m.level0Sublevels),
+
+		reg.Register(m.levelTableCount),
+		reg.Register(m.levelSize),
+		reg.Register(m.levelReads),
+		reg.Register(m.levelWrites),
+
+		reg.Register(m.memCompactions),
+		reg.Register(m.compactions),
+		reg.Register(m.compactionDebt),
+	)
+	return m, errs.Err
+}
+
+// eventListener returns the pebble event listener that tracks write stalls,
+// which aren't reported by [pebble.DB.Metrics].
+func (m *metrics) eventListener() *pebble.EventListener {
+	return &pebble.EventListener{
+		WriteStallBegin: func(pebble.WriteStallBeginInfo) {
+			m.stallLock.Lock()
+			defer m.stallLock.Unlock()
+
+			m.stallStart = time.Now()
+			m.writesDelayedCount.Inc()
+			m.writeIsDelayed.Set(1)
+		},
+		WriteStallEnd: func() {
+			m.stallLock.Lock()
+			defer m.stallLock.Unlock()
+
+			if m.stallStart.IsZero() {
+				return
+			}
+			m.writesDelayedDuration.Add(float64(time.Since(m.stallStart)))
+			m.stallStart = time.Time{}
+			m.writeIsDelayed.Set(0)
+		},
+	}
+}
+
+// updateMetrics polls [pebbleDB] and reports the change in its metrics since
+// the last poll.
+func (m *metrics) updateMetrics(pebbleDB *pebble.DB) {
+	priorStats := m.priorStats
+	currentStats := pebbleDB.Metrics()
+
+	m.aliveSnapshots.Set(float64(currentStats.Snapshots.Count))
+
+	m.blockCacheSize.Set(float64(currentStats.BlockCache.Size))
+	m.blockCacheHits.Add(float64(currentStats.BlockCache.Hits - priorStats.BlockCache.Hits))
+	m.blockCacheMisses.Add(float64(currentStats.BlockCache.Misses - priorStats.BlockCache.Misses))
+
+	m.level0Sublevels.Set(float64(currentStats.Levels[0].Sublevels))
+
+	for level, levelStats := range currentStats.Levels {
+		priorLevelStats := priorStats.Levels[level]
+
+		levelStr := strconv.Itoa(level)
+		m.levelTableCount.WithLabelValues(levelStr).Set(float64(levelStats.NumFiles))
+		m.levelSize.WithLabelValues(levelStr).Set(float64(levelStats.Size))
+		m.levelReads.WithLabelValues(levelStr).Add(float64(levelStats.BytesRead - priorLevelStats.BytesRead))
+		m.levelWrites.WithLabelValues(levelStr).Add(float64(
+			levelStats.BytesCompacted + levelStats.BytesFlushed -
+				priorLevelStats.BytesCompacted - priorLevelStats.BytesFlushed,
+		))
+	}
+
+	m.memCompactions.Add(float64(currentStats.Flush.Count - priorStats.Flush.Count))
+	m.compactions.Add(float64(currentStats.Compact.Count - priorStats.Compact.Count))
+	m.compactionDebt.Set(float64(currentStats.Compact.EstimatedDebt))
+
+	// update the priorStats to update the counters correctly next time this
+	// method is called
+	m.priorStats = currentStats
+}
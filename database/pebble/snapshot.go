@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"github.com/cockroachdb/pebble"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+)
+
+var _ database.Snapshot = (*snapshot)(nil)
+
+// snapshot is a wrapper around a pebble snapshot to conform to the database
+// interfaces.
+type snapshot struct {
+	db       *Database
+	snapshot *pebble.Snapshot
+
+	// true iff the underlying pebble snapshot has been closed
+	released bool
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.released {
+		return false, database.ErrClosed
+	}
+	return has(s.snapshot, key)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.released {
+		return nil, database.ErrClosed
+	}
+	return get(s.snapshot, key)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.newIterator(&pebble.IterOptions{}, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.newIterator(startAndPrefixOptions(start, nil), false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.newIterator(startAndPrefixOptions(nil, prefix), false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.newIterator(startAndPrefixOptions(start, prefix), false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot keys in [start, end)
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return s.newIterator(rangeOptions(start, end), false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot keys in [start, end)
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return s.newIterator(rangeOptions(start, end), true)
+}
+
+func (s *snapshot) newIterator(opts *pebble.IterOptions, reverse bool) database.Iterator {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	if s.released {
+		return &iter{
+			db:     s.db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+	return s.db.newIteratorLocked(s.snapshot, opts, reverse)
+}
+
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.release()
+	s.db.openSnapshots.Remove(s)
+}
+
+// release closes the underlying pebble snapshot. Assumes the database lock is
+// held.
+func (s *snapshot) release() {
+	if s.released {
+		return
+	}
+	s.released = true
+	_ = s.snapshot.Close()
+}
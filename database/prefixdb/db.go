@@ -18,9 +18,10 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iterator)(nil)
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -168,6 +169,72 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// It is safe to modify [start] and [end] after this method returns.
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.db.NewIteratorWithRange, start, end)
+}
+
+// It is safe to modify [start] and [end] after this method returns.
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.db.NewReverseIteratorWithRange, start, end)
+}
+
+// newRangeIterator returns an iterator, created by [newIterator], over the
+// prefixed keys in [start, end). If [end] is empty, the iterator is bounded by
+// the end of this database's keyspace.
+// Assumes it is safe to modify the arguments to [newIterator] after it returns.
+func (db *Database) newRangeIterator(
+	newIterator func(start, end []byte) database.Iterator,
+	start []byte,
+	end []byte,
+) database.Iterator {
+	prefixedStart := db.prefix(start)
+	defer db.bufferPool.Put(prefixedStart)
+
+	var prefixedEnd []byte
+	if len(end) == 0 {
+		prefixedEnd = database.PrefixUpperBound(db.dbPrefix)
+	} else {
+		prefixedEnd = db.prefix(end)
+		defer db.bufferPool.Put(prefixedEnd)
+	}
+	return &iterator{
+		Iterator: newIterator(prefixedStart, prefixedEnd),
+		db:       db,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database.
+// The underlying database must support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db:       db,
+		snapshot: s,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -6,6 +6,8 @@ package prefixdb
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
 )
@@ -22,6 +24,23 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New([]byte("hello"), memdb.New()))
+		test(t, New([]byte("wor"), New([]byte("ld"), memdb.New())))
+		test(t, NewNested([]byte("wor"), New([]byte("ld"), memdb.New())))
+	}
+}
+
+func TestSnapshotNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	// Embedding the interface hides the memdb's snapshot support.
+	db := New([]byte("hello"), struct{ database.Database }{memdb.New()})
+	_, err := db.NewSnapshot()
+	require.ErrorIs(err, database.ErrSnapshotNotSupported)
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		test(f, New([]byte(""), memdb.New()))
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prefixdb
+
+import (
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/nodb"
+)
+
+var _ database.Snapshot = (*snapshot)(nil)
+
+// snapshot partitions a snapshot of the underlying database by prefixing all
+// keys with the database's prefix.
+type snapshot struct {
+	db       *Database
+	snapshot database.Snapshot
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed {
+		return false, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	has, err := s.snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed {
+		return nil, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	val, err := s.snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// It is safe to modify [start] and [end] after this method returns.
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.snapshot.NewIteratorWithRange, start, end)
+}
+
+// It is safe to modify [start] and [end] after this method returns.
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.snapshot.NewReverseIteratorWithRange, start, end)
+}
+
+func (s *snapshot) Release() {
+	s.snapshot.Release()
+}
@@ -29,9 +29,11 @@ const (
 )
 
 var (
-	_ database.Database = (*DatabaseClient)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*DatabaseClient)(nil)
+	_ database.Snapshotter = (*DatabaseClient)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iterator)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -124,6 +126,48 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	}
 }
 
+// NewIteratorWithRange returns a new iterator over the keys in [start, end)
+func (db *DatabaseClient) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(start, end, false)
+}
+
+// NewReverseIteratorWithRange returns a new reverse iterator over the keys in
+// [start, end)
+func (db *DatabaseClient) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(start, end, true)
+}
+
+func (db *DatabaseClient) newRangeIterator(start, end []byte, reverse bool) database.Iterator {
+	resp, err := db.client.NewIteratorWithRange(context.Background(), &rpcdbpb.NewIteratorWithRangeRequest{
+		Start:   start,
+		End:     end,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: db,
+		id: resp.Id,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the remote
+// database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbpb.NewSnapshotRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
@@ -336,3 +380,101 @@ func (it *iterator) Release() {
 		it.errs.Add(errCodeToError[resp.Err])
 	}
 }
+
+type snapshot struct {
+	db *DatabaseClient
+	id uint64
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbpb.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, errCodeToError[resp.Err]
+}
+
+// Get attempts to return the value that was mapped to the key that was provided
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbpb.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, errCodeToError[resp.Err]
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns a new iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithStartAndPrefix(context.Background(), &rpcdbpb.SnapshotNewIteratorWithStartAndPrefixRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// NewIteratorWithRange returns a new iterator over the snapshot keys in
+// [start, end)
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return s.newRangeIterator(start, end, false)
+}
+
+// NewReverseIteratorWithRange returns a new reverse iterator over the snapshot
+// keys in [start, end)
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return s.newRangeIterator(start, end, true)
+}
+
+func (s *snapshot) newRangeIterator(start, end []byte, reverse bool) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithRange(context.Background(), &rpcdbpb.SnapshotNewIteratorWithRangeRequest{
+		Id:      s.id,
+		Start:   start,
+		End:     end,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// Release frees any resources held by the snapshot
+func (s *snapshot) Release() {
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbpb.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
@@ -39,6 +39,12 @@ type DatabaseServer struct {
 	iteratorLock   sync.RWMutex
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	// snapshotLock protects [nextSnapshotID] and [snapshots] from concurrent
+	// modifications. Snapshots are safe for concurrent use.
+	snapshotLock   sync.RWMutex
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -47,6 +53,7 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batches:   make(map[int64]database.Batch),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -152,14 +159,31 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbpb.WriteBatchR
 // ID
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbpb.NewIteratorWithStartAndPrefixRequest) (*rpcdbpb.NewIteratorWithStartAndPrefixResponse, error) {
 	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	id := db.addIterator(it)
+	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
 
+// NewIteratorWithRange allocates an iterator over the keys in [start, end) and
+// returns the iterator ID
+func (db *DatabaseServer) NewIteratorWithRange(_ context.Context, req *rpcdbpb.NewIteratorWithRangeRequest) (*rpcdbpb.NewIteratorWithRangeResponse, error) {
+	var it database.Iterator
+	if req.Reverse {
+		it = db.db.NewReverseIteratorWithRange(req.Start, req.End)
+	} else {
+		it = db.db.NewIteratorWithRange(req.Start, req.End)
+	}
+	id := db.addIterator(it)
+	return &rpcdbpb.NewIteratorWithRangeResponse{Id: id}, nil
+}
+
+func (db *DatabaseServer) addIterator(it database.Iterator) uint64 {
 	db.iteratorLock.Lock()
 	defer db.iteratorLock.Unlock()
 
 	id := db.nextIteratorID
 	db.iterators[id] = it
 	db.nextIteratorID++
-	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+	return id
 }
 
 // IteratorNext attempts to call next on the requested iterator
@@ -214,3 +238,104 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbpb.Iterat
 	it.Release()
 	return &rpcdbpb.IteratorReleaseResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
 }
+
+// NewSnapshot allocates a snapshot of the managed database and returns the
+// snapshot ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *rpcdbpb.NewSnapshotRequest) (*rpcdbpb.NewSnapshotResponse, error) {
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return &rpcdbpb.NewSnapshotResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	db.snapshotLock.Lock()
+	defer db.snapshotLock.Unlock()
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+	db.nextSnapshotID++
+	return &rpcdbpb.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas delegates the Has call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbpb.SnapshotHasRequest) (*rpcdbpb.HasResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.HasResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	has, err := snapshot.Has(req.Key)
+	return &rpcdbpb.HasResponse{
+		Has: has,
+		Err: errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotGet delegates the Get call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbpb.SnapshotGetRequest) (*rpcdbpb.GetResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.GetResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	value, err := snapshot.Get(req.Key)
+	return &rpcdbpb.GetResponse{
+		Value: value,
+		Err:   errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotNewIteratorWithStartAndPrefix allocates an iterator over the
+// requested snapshot and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbpb.SnapshotNewIteratorWithStartAndPrefixRequest) (*rpcdbpb.SnapshotNewIteratorWithStartAndPrefixResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.SnapshotNewIteratorWithStartAndPrefixResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	it := snapshot.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	id := db.addIterator(it)
+	return &rpcdbpb.SnapshotNewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
+// SnapshotNewIteratorWithRange allocates an iterator over the keys of the
+// requested snapshot in [start, end) and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithRange(_ context.Context, req *rpcdbpb.SnapshotNewIteratorWithRangeRequest) (*rpcdbpb.SnapshotNewIteratorWithRangeResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbpb.SnapshotNewIteratorWithRangeResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	var it database.Iterator
+	if req.Reverse {
+		it = snapshot.NewReverseIteratorWithRange(req.Start, req.End)
+	} else {
+		it = snapshot.NewIteratorWithRange(req.Start, req.End)
+	}
+	id := db.addIterator(it)
+	return &rpcdbpb.SnapshotNewIteratorWithRangeResponse{Id: id}, nil
+}
+
+// SnapshotRelease releases the resources allocated to a snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbpb.SnapshotReleaseRequest) (*rpcdbpb.SnapshotReleaseResponse, error) {
+	db.snapshotLock.Lock()
+	snapshot, exists := db.snapshots[req.Id]
+	delete(db.snapshots, req.Id)
+	db.snapshotLock.Unlock()
+
+	if exists {
+		snapshot.Release()
+	}
+	return &rpcdbpb.SnapshotReleaseResponse{}, nil
+}
+
+// getSnapshot returns the requested snapshot. If the snapshot has already been
+// released, [database.ErrClosed] is returned.
+func (db *DatabaseServer) getSnapshot(id uint64) (database.Snapshot, error) {
+	db.snapshotLock.RLock()
+	defer db.snapshotLock.RUnlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+	return snapshot, nil
+}
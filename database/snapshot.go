@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+// Snapshot is a read-only, point-in-time view of a database. Writes made to
+// the database after the snapshot was created are not visible through the
+// snapshot.
+//
+// Once the snapshot has been released, or the database it was taken from has
+// been closed, reads will return [ErrClosed].
+//
+// A snapshot must be released after use. It is safe to use a snapshot
+// concurrently, including any iterators created from it.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed
+	// and can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot creates a read-only view of the current state of the
+	// database.
+	NewSnapshot() (Snapshot, error)
+}
+
+// NewSnapshot returns a snapshot of [db] if [db] supports snapshots. If it
+// doesn't, [ErrSnapshotNotSupported] is returned.
+func NewSnapshot(db KeyValueReader) (Snapshot, error) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
@@ -35,6 +35,9 @@ var Tests = []func(t *testing.T, db Database){
 	TestIteratorStart,
 	TestIteratorPrefix,
 	TestIteratorStartPrefix,
+	TestIteratorRange,
+	TestReverseIteratorRange,
+	TestReverseIteratorPrefix,
 	TestIteratorMemorySafety,
 	TestIteratorClosed,
 	TestIteratorError,
@@ -56,6 +59,15 @@ var FuzzTests = []func(*testing.F, Database){
 	FuzzKeyValue,
 }
 
+// SnapshotTests is a list of all database snapshot tests. They must only be
+// run against databases that implement [Snapshotter].
+var SnapshotTests = []func(t *testing.T, db Database){
+	TestSnapshot,
+	TestSnapshotIterator,
+	TestSnapshotRelease,
+	TestSnapshotClosed,
+}
+
 // TestSimpleKeyValue tests to make sure that simple Put + Get + Delete + Has
 // calls return the expected values.
 func TestSimpleKeyValue(t *testing.T, db Database) {
@@ -858,6 +870,157 @@ func TestIteratorStartPrefix(t *testing.T, db Database) {
 	}
 }
 
+// TestIteratorRange tests to make sure that the iterator only returns the keys
+// in the provided range.
+func TestIteratorRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{{0x00}, {0x01}, {0x01, 0x00}, {0x02}, {0x03}}
+	for _, key := range keys {
+		require.NoError(db.Put(key, append([]byte("value"), key...)))
+	}
+
+	tests := []struct {
+		start, end   []byte
+		expectedKeys [][]byte
+	}{
+		{
+			start:        nil,
+			end:          nil,
+			expectedKeys: keys,
+		},
+		{
+			start:        []byte{0x01},
+			end:          nil,
+			expectedKeys: keys[1:],
+		},
+		{
+			start:        nil,
+			end:          []byte{0x02},
+			expectedKeys: keys[:3],
+		},
+		{
+			start:        []byte{0x01},
+			end:          []byte{0x02},
+			expectedKeys: keys[1:3],
+		},
+		{
+			start:        []byte{0x00, 0x00},
+			end:          []byte{0x01, 0x00, 0x00},
+			expectedKeys: keys[1:3],
+		},
+		{
+			start:        []byte{0x02},
+			end:          []byte{0x02},
+			expectedKeys: nil,
+		},
+		{
+			start:        []byte{0x03},
+			end:          []byte{0x01},
+			expectedKeys: nil,
+		},
+	}
+	for _, test := range tests {
+		iterator := db.NewIteratorWithRange(test.start, test.end)
+		requireIteratorKeys(t, iterator, test.expectedKeys)
+		iterator.Release()
+	}
+}
+
+// TestReverseIteratorRange tests to make sure that the reverse iterator
+// returns the keys in the provided range in descending order.
+func TestReverseIteratorRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{{0x00}, {0x01}, {0x01, 0x00}, {0x02}, {0x03}}
+	for _, key := range keys {
+		require.NoError(db.Put(key, append([]byte("value"), key...)))
+	}
+
+	tests := []struct {
+		start, end   []byte
+		expectedKeys [][]byte
+	}{
+		{
+			start:        nil,
+			end:          nil,
+			expectedKeys: [][]byte{keys[4], keys[3], keys[2], keys[1], keys[0]},
+		},
+		{
+			start:        []byte{0x01},
+			end:          nil,
+			expectedKeys: [][]byte{keys[4], keys[3], keys[2], keys[1]},
+		},
+		{
+			start:        nil,
+			end:          []byte{0x02},
+			expectedKeys: [][]byte{keys[2], keys[1], keys[0]},
+		},
+		{
+			start:        []byte{0x01},
+			end:          []byte{0x02},
+			expectedKeys: [][]byte{keys[2], keys[1]},
+		},
+		{
+			start:        []byte{0x00, 0x00},
+			end:          []byte{0x01, 0x00, 0x00},
+			expectedKeys: [][]byte{keys[2], keys[1]},
+		},
+		{
+			start:        []byte{0x02},
+			end:          []byte{0x02},
+			expectedKeys: nil,
+		},
+		{
+			start:        []byte{0x03},
+			end:          []byte{0x01},
+			expectedKeys: nil,
+		},
+	}
+	for _, test := range tests {
+		iterator := db.NewReverseIteratorWithRange(test.start, test.end)
+		requireIteratorKeys(t, iterator, test.expectedKeys)
+		iterator.Release()
+	}
+}
+
+// TestReverseIteratorPrefix tests to make sure that the reverse iterator can
+// be restricted to the keys with a given prefix.
+func TestReverseIteratorPrefix(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{{0x01}, {0x01, 0x00}, {0x01, 0xFF}, {0x02}, {0xFF}, {0xFF, 0xFF}}
+	for _, key := range keys {
+		require.NoError(db.Put(key, append([]byte("value"), key...)))
+	}
+
+	prefix := []byte{0x01}
+	iterator := db.NewReverseIteratorWithRange(prefix, PrefixUpperBound(prefix))
+	requireIteratorKeys(t, iterator, [][]byte{keys[2], keys[1], keys[0]})
+	iterator.Release()
+
+	prefix = []byte{0xFF}
+	iterator = db.NewReverseIteratorWithRange(prefix, PrefixUpperBound(prefix))
+	requireIteratorKeys(t, iterator, [][]byte{keys[5], keys[4]})
+	iterator.Release()
+}
+
+// requireIteratorKeys exhausts [iterator] and requires that it returned
+// exactly [expectedKeys], in order, with the values written by the range
+// iterator tests.
+func requireIteratorKeys(t *testing.T, iterator Iterator, expectedKeys [][]byte) {
+	require := require.New(t)
+
+	keys := [][]byte(nil)
+	for iterator.Next() {
+		key := iterator.Key()
+		keys = append(keys, key)
+		require.Equal(append([]byte("value"), key...), iterator.Value())
+	}
+	require.NoError(iterator.Error())
+	require.Equal(expectedKeys, keys)
+}
+
 // TestIteratorMemorySafety tests to make sure that keys can values are able to
 // be modified from the returned iterator.
 func TestIteratorMemorySafety(t *testing.T, db Database) {
@@ -1001,6 +1164,42 @@ func TestIteratorClosed(t *testing.T, db Database) {
 			t.Fatalf("Expected %s on iterator.Error", ErrClosed)
 		}
 	}
+
+	{
+		iterator := db.NewIteratorWithRange(nil, nil)
+		if iterator == nil {
+			t.Fatalf("db.NewIteratorWithRange returned nil")
+		}
+		defer iterator.Release()
+
+		if iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+		} else if key := iterator.Key(); key != nil {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+		} else if value := iterator.Value(); value != nil {
+			t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+		} else if err := iterator.Error(); err != ErrClosed {
+			t.Fatalf("Expected %s on iterator.Error", ErrClosed)
+		}
+	}
+
+	{
+		iterator := db.NewReverseIteratorWithRange(nil, nil)
+		if iterator == nil {
+			t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+		}
+		defer iterator.Release()
+
+		if iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+		} else if key := iterator.Key(); key != nil {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+		} else if value := iterator.Value(); value != nil {
+			t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+		} else if err := iterator.Error(); err != ErrClosed {
+			t.Fatalf("Expected %s on iterator.Error", ErrClosed)
+		}
+	}
 }
 
 // TestIteratorError tests to make sure that an iterator on a database will report
@@ -1381,3 +1580,175 @@ func FuzzKeyValue(f *testing.F, db Database) {
 		require.ErrorIs(err, ErrNotFound)
 	})
 }
+
+// TestSnapshot tests to make sure that writes after a snapshot was taken are
+// not visible through the snapshot.
+func TestSnapshot(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Delete(key1))
+	require.NoError(db.Put(key2, value3))
+	require.NoError(db.Put(key3, value3))
+
+	has, err := snapshot.Has(key1)
+	require.NoError(err)
+	require.True(has)
+
+	value, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	value, err = snapshot.Get(key2)
+	require.NoError(err)
+	require.Equal(value2, value)
+
+	has, err = snapshot.Has(key3)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = snapshot.Get(key3)
+	require.ErrorIs(err, ErrNotFound)
+
+	// The database itself should reflect the new writes.
+	has, err = db.Has(key1)
+	require.NoError(err)
+	require.False(has)
+
+	value, err = db.Get(key2)
+	require.NoError(err)
+	require.Equal(value3, value)
+}
+
+// TestSnapshotIterator tests to make sure that iterators created from a
+// snapshot only observe the contents of the snapshot.
+func TestSnapshotIterator(t *testing.T, db Database) {
+	require := require.New(t)
+
+	require.NoError(db.Put([]byte("a1"), []byte("value-a1")))
+	require.NoError(db.Put([]byte("a2"), []byte("value-a2")))
+	require.NoError(db.Put([]byte("b1"), []byte("value-b1")))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put([]byte("a0"), []byte("value-a0")))
+	require.NoError(db.Delete([]byte("a2")))
+	require.NoError(db.Put([]byte("b1"), []byte("modified")))
+
+	tests := []struct {
+		it           Iterator
+		expectedKeys []string
+	}{
+		{
+			it:           snapshot.NewIterator(),
+			expectedKeys: []string{"a1", "a2", "b1"},
+		},
+		{
+			it:           snapshot.NewIteratorWithStart([]byte("a2")),
+			expectedKeys: []string{"a2", "b1"},
+		},
+		{
+			it:           snapshot.NewIteratorWithPrefix([]byte("a")),
+			expectedKeys: []string{"a1", "a2"},
+		},
+		{
+			it:           snapshot.NewIteratorWithStartAndPrefix([]byte("a2"), []byte("a")),
+			expectedKeys: []string{"a2"},
+		},
+		{
+			it:           snapshot.NewIteratorWithRange([]byte("a1"), []byte("b1")),
+			expectedKeys: []string{"a1", "a2"},
+		},
+		{
+			it:           snapshot.NewReverseIteratorWithRange(nil, nil),
+			expectedKeys: []string{"b1", "a2", "a1"},
+		},
+		{
+			it:           snapshot.NewReverseIteratorWithRange([]byte("a"), []byte("b")),
+			expectedKeys: []string{"a2", "a1"},
+		},
+	}
+	for _, test := range tests {
+		keys := []string(nil)
+		for test.it.Next() {
+			key := string(test.it.Key())
+			keys = append(keys, key)
+			require.Equal("value-"+key, string(test.it.Value()))
+		}
+		require.NoError(test.it.Error())
+		test.it.Release()
+
+		require.Equal(test.expectedKeys, keys)
+	}
+}
+
+// TestSnapshotRelease tests to make sure that a released snapshot can't be
+// read from.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+
+	snapshot.Release()
+	snapshot.Release()
+
+	_, err = snapshot.Has(key)
+	require.ErrorIs(err, ErrClosed)
+
+	_, err = snapshot.Get(key)
+	require.ErrorIs(err, ErrClosed)
+
+	// The database should be unaffected by the release.
+	has, err := db.Has(key)
+	require.NoError(err)
+	require.True(has)
+}
+
+// TestSnapshotClosed tests to make sure that snapshots can't be read from
+// after the database was closed.
+func TestSnapshotClosed(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Close())
+
+	_, err = snapshot.Get(key)
+	require.ErrorIs(err, ErrClosed)
+
+	it := snapshot.NewIterator()
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrClosed)
+	it.Release()
+
+	_, err = NewSnapshot(db)
+	require.ErrorIs(err, ErrClosed)
+}
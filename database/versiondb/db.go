@@ -18,10 +18,11 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ Commitable        = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ Commitable           = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iterator)(nil)
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -130,26 +131,71 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
+	return newIterator(
+		db,
+		db.mem,
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		startAndPrefixFilter(start, prefix),
+		false,
+	)
+}
+
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
+
+	return newIterator(
+		db,
+		db.mem,
+		db.db.NewIteratorWithRange(start, end),
+		rangeFilter(start, end),
+		false,
+	)
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
 
-	return &iterator{
-		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+	return newIterator(
+		db,
+		db.mem,
+		db.db.NewReverseIteratorWithRange(start, end),
+		rangeFilter(start, end),
+		true,
+	)
+}
+
+// NewSnapshot returns a read-only view of the current state of the database,
+// including any uncommitted changes. The underlying database must support
+// snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
 	}
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Values are never modified in place, so a shallow copy of the map is
+	// sufficient to isolate the snapshot from future writes.
+	return &snapshot{
+		db:       db,
+		mem:      maps.Clone(db.mem),
+		snapshot: s,
+	}, nil
 }
 
 func (db *Database) Compact(start, limit []byte) error {
@@ -347,6 +393,66 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// newIterator returns an iterator that merges the keys in [mem] that satisfy
+// [include] with the keys returned by [it]. [it] must return the keys of the
+// underlying database that satisfy [include], in descending order if
+// [reverse] is true and in ascending order otherwise. Assumes the lock
+// protecting [mem] is held.
+func newIterator(
+	db *Database,
+	mem map[string]valueDelete,
+	it database.Iterator,
+	include func(key string) bool,
+	reverse bool,
+) database.Iterator {
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if include(key) {
+			keys = append(keys, key)
+		}
+	}
+	// Keys need to be in the same order as [it]
+	if reverse {
+		slices.SortFunc(keys, func(a, b string) int {
+			return strings.Compare(b, a)
+		})
+	} else {
+		slices.Sort(keys)
+	}
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+
+	return &iterator{
+		db:       db,
+		Iterator: it,
+		keys:     keys,
+		values:   values,
+		reverse:  reverse,
+	}
+}
+
+// startAndPrefixFilter returns a filter that includes the keys that start
+// with [prefix] and are greater than or equal to [start].
+func startAndPrefixFilter(start, prefix []byte) func(string) bool {
+	startString := string(start)
+	prefixString := string(prefix)
+	return func(key string) bool {
+		return strings.HasPrefix(key, prefixString) && key >= startString
+	}
+}
+
+// rangeFilter returns a filter that includes the keys in [start, end). An
+// empty [end] is treated as unbounded.
+func rangeFilter(start, end []byte) func(string) bool {
+	startString := string(start)
+	endString := string(end)
+	return func(key string) bool {
+		return key >= startString && (len(endString) == 0 || key < endString)
+	}
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -359,6 +465,8 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
+	// reverse is true iff the keys are iterated in descending order
+	reverse                bool
 	initialized, exhausted bool
 }
 
@@ -412,7 +520,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys[0] = ""
 				it.keys = it.keys[1:]
 				it.values[0].value = nil
@@ -423,7 +531,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
@@ -445,6 +553,14 @@ func (it *iterator) Next() bool {
 	}
 }
 
+// before returns true if [a] should be iterated over before [b]
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
+}
+
 func (it *iterator) Error() error {
 	if it.err != nil {
 		return it.err
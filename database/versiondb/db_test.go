@@ -7,6 +7,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
 )
@@ -25,6 +27,49 @@ func FuzzInterface(f *testing.F) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		test(t, New(baseDB))
+	}
+}
+
+func TestSnapshotUncommitted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	require.NoError(baseDB.Put([]byte("committed"), []byte("value")))
+	require.NoError(db.Put([]byte("uncommitted"), []byte("value")))
+	require.NoError(db.Delete([]byte("committed")))
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	// Changes made after the snapshot was taken shouldn't be visible.
+	require.NoError(db.Put([]byte("committed"), []byte("new value")))
+	require.NoError(db.Commit())
+
+	has, err := snapshot.Has([]byte("committed"))
+	require.NoError(err)
+	require.False(has)
+
+	value, err := snapshot.Get([]byte("uncommitted"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+
+	require.True(it.Next())
+	require.Equal([]byte("uncommitted"), it.Key())
+	require.Equal([]byte("value"), it.Value())
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
+
 func TestIterate(t *testing.T) {
 	baseDB := memdb.New()
 	db := New(baseDB)
@@ -186,6 +231,42 @@ func TestIterate(t *testing.T) {
 	}
 }
 
+func TestReverseIterateRange(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	// Interleave committed and uncommitted keys, including an uncommitted
+	// deletion and an uncommitted overwrite of committed keys.
+	require.NoError(baseDB.Put([]byte("a"), []byte("db-a")))
+	require.NoError(baseDB.Put([]byte("c"), []byte("db-c")))
+	require.NoError(baseDB.Put([]byte("e"), []byte("db-e")))
+	require.NoError(baseDB.Put([]byte("g"), []byte("db-g")))
+	require.NoError(db.Put([]byte("b"), []byte("mem-b")))
+	require.NoError(db.Put([]byte("e"), []byte("mem-e")))
+	require.NoError(db.Put([]byte("f"), []byte("mem-f")))
+	require.NoError(db.Delete([]byte("c")))
+
+	it := db.NewReverseIteratorWithRange([]byte("b"), []byte("g"))
+	defer it.Release()
+
+	expected := []struct {
+		key, value string
+	}{
+		{"f", "mem-f"},
+		{"e", "mem-e"},
+		{"b", "mem-b"},
+	}
+	for _, kv := range expected {
+		require.True(it.Next())
+		require.Equal([]byte(kv.key), it.Key())
+		require.Equal([]byte(kv.value), it.Value())
+	}
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
+
 func TestCommit(t *testing.T) {
 	baseDB := memdb.New()
 	db := New(baseDB)
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/database/nodb"
+	"github.com/lasthyphen/dijetsnodego/utils"
+)
+
+var _ database.Snapshot = (*snapshot)(nil)
+
+// snapshot combines a copy of the uncommitted changes of a versioned database
+// with a snapshot of its underlying database.
+type snapshot struct {
+	db *Database
+
+	lock sync.RWMutex
+	// mem is set to nil once the snapshot has been released
+	mem      map[string]valueDelete
+	snapshot database.Snapshot
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	return s.snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.db,
+		s.mem,
+		s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		startAndPrefixFilter(start, prefix),
+		false,
+	)
+}
+
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.db,
+		s.mem,
+		s.snapshot.NewIteratorWithRange(start, end),
+		rangeFilter(start, end),
+		false,
+	)
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.db,
+		s.mem,
+		s.snapshot.NewReverseIteratorWithRange(start, end),
+		rangeFilter(start, end),
+		true,
+	)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+	s.mem = nil
+	s.snapshot.Release()
+}
@@ -46,6 +46,7 @@ var (
 			AddPrimaryNetworkDelegatorFee: 0,
 			AddSubnetValidatorFee:         units.MilliDjtx,
 			AddSubnetDelegatorFee:         units.MilliDjtx,
+			DynamicFeeConfig:              dynamicFeeConfig,
 		},
 		StakingConfig: StakingConfig{
 			UptimeRequirement: .8, // 80%
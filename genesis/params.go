@@ -7,9 +7,29 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 )
 
+// dynamicFeeConfig is the pricing of P-chain transactions once dynamic fees
+// are activated. It's the same on every network.
+var dynamicFeeConfig = fees.Config{
+	MinPrices: fees.Dimensions{
+		fees.Bandwidth: units.MicroDjtx,
+		fees.DBRead:    50 * units.MicroDjtx,
+		fees.DBWrite:   100 * units.MicroDjtx,
+		fees.Compute:   100 * units.MicroDjtx,
+	},
+	TargetUnits: fees.Dimensions{
+		fees.Bandwidth: 64 * units.KiB,
+		fees.DBRead:    250,
+		fees.DBWrite:   500,
+		fees.Compute:   250,
+	},
+	ChangeDenominator: 8,
+}
+
 type StakingConfig struct {
 	// Staking uptime requirements
 	UptimeRequirement float64 `json:"uptimeRequirement"`
@@ -52,6 +72,8 @@ type TxFeeConfig struct {
 	AddSubnetValidatorFee uint64 `json:"addSubnetValidatorFee"`
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64 `json:"addSubnetDelegatorFee"`
+	// Pricing of P-chain transactions once dynamic fees are activated
+	DynamicFeeConfig fees.Config `json:"dynamicFeeConfig"`
 }
 
 type Params struct {
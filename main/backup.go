@@ -0,0 +1,165 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/lasthyphen/dijetsnodego/api/admin"
+	"github.com/lasthyphen/dijetsnodego/database/backup"
+	"github.com/lasthyphen/dijetsnodego/database/leveldb"
+	"github.com/lasthyphen/dijetsnodego/database/migrate"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+const (
+	createBackupCommand  = "create-backup"
+	restoreBackupCommand = "restore-backup"
+
+	uriKey          = "uri"
+	archivePathKey  = "archive"
+	dbDirKey        = "db-dir"
+	dbTypeKey       = "db-type"
+	dbConfigFileKey = "db-config-file"
+	chainDataDirKey = "chain-data-dir"
+)
+
+var (
+	errMissingArchivePath = errors.New("--archive must be specified")
+	errMissingRestoreDir  = errors.New("--db-dir must be specified")
+)
+
+// createBackup asks a running node, through its admin API, to write a backup
+// archive of its database and chain data.
+func createBackup(args []string) int {
+	fs := pflag.NewFlagSet(createBackupCommand, pflag.ContinueOnError)
+	fs.String(uriKey, "http://127.0.0.1:9650", "URI of the node's API server. The admin API must be enabled")
+	fs.String(archivePathKey, "", "Path, on the node's filesystem, to write the archive to")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+
+	uri, err := fs.GetString(uriKey)
+	if err != nil {
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+	archivePath, err := fs.GetString(archivePathKey)
+	if err != nil {
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+	if archivePath == "" {
+		fmt.Println(errMissingArchivePath)
+		return 1
+	}
+
+	manifest, err := admin.NewClient(uri).CreateBackup(context.Background(), archivePath)
+	if err != nil {
+		fmt.Printf("backup failed: %s\n", err)
+		return 1
+	}
+	return printManifest(manifest)
+}
+
+// restoreBackup restores a backup archive into the directories of an offline
+// node.
+func restoreBackup(args []string) int {
+	fs := pflag.NewFlagSet(restoreBackupCommand, pflag.ContinueOnError)
+	fs.String(archivePathKey, "", "Path of the archive to restore")
+	fs.String(dbDirKey, "", "Database directory to restore into. The versioned database is restored into <db-dir>/<network>/<version>")
+	fs.String(dbTypeKey, leveldb.Name, fmt.Sprintf("Database type to restore into. Should be one of {%s, %s}", leveldb.Name, pebble.Name))
+	fs.String(dbConfigFileKey, "", "Path to the database config file")
+	fs.String(chainDataDirKey, "", "Directory to restore the chain data into. If empty, the chain data is not restored")
+	fs.Int(batchSizeKey, migrate.DefaultBatchSize, "Number of bytes to buffer before writing to the database")
+	fs.String(logLevelKey, logging.Info.String(), "The log level")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+
+	config, log, err := getRestoreBackupConfig(fs)
+	if err != nil {
+		fmt.Printf("couldn't load restore config: %s\n", err)
+		return 1
+	}
+
+	manifest, err := backup.Restore(config, log)
+	if err != nil {
+		fmt.Printf("restore failed: %s\n", err)
+		return 1
+	}
+	return printManifest(manifest)
+}
+
+func getRestoreBackupConfig(fs *pflag.FlagSet) (backup.RestoreConfig, logging.Logger, error) {
+	var (
+		config = backup.RestoreConfig{}
+		err    error
+	)
+	if config.ArchivePath, err = fs.GetString(archivePathKey); err != nil {
+		return config, nil, err
+	}
+	if config.DBDir, err = fs.GetString(dbDirKey); err != nil {
+		return config, nil, err
+	}
+	if config.DBType, err = fs.GetString(dbTypeKey); err != nil {
+		return config, nil, err
+	}
+	if config.ChainDataDir, err = fs.GetString(chainDataDirKey); err != nil {
+		return config, nil, err
+	}
+	if config.BatchSize, err = fs.GetInt(batchSizeKey); err != nil {
+		return config, nil, err
+	}
+	if config.ArchivePath == "" {
+		return config, nil, errMissingArchivePath
+	}
+	if config.DBDir == "" {
+		return config, nil, errMissingRestoreDir
+	}
+	if config.DBConfig, err = readOptionalFile(fs, dbConfigFileKey); err != nil {
+		return config, nil, err
+	}
+
+	logLevelStr, err := fs.GetString(logLevelKey)
+	if err != nil {
+		return config, nil, err
+	}
+	logLevel, err := logging.ToLevel(logLevelStr)
+	if err != nil {
+		return config, nil, err
+	}
+	log := logging.NewLogger(
+		restoreBackupCommand,
+		logging.NewWrappedCore(logLevel, os.Stdout, logging.Plain.ConsoleEncoder()),
+	)
+	return config, log, nil
+}
+
+func printManifest(manifest *backup.Manifest) int {
+	manifestBytes, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		fmt.Printf("couldn't marshal manifest: %s\n", err)
+		return 1
+	}
+	fmt.Println(string(manifestBytes))
+	return 0
+}
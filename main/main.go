@@ -16,6 +16,17 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case migrateDBCommand:
+			os.Exit(migrateDB(os.Args[2:]))
+		case createBackupCommand:
+			os.Exit(createBackup(os.Args[2:]))
+		case restoreBackupCommand:
+			os.Exit(restoreBackup(os.Args[2:]))
+		}
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
@@ -44,6 +55,7 @@ func main() {
 		fmt.Printf("couldn't load node config: %s\n", err)
 		os.Exit(1)
 	}
+	nodeConfig.ConfigReloader = config.NewConfigReloader(v, nodeConfig, os.Args[1:])
 
 	runner.Run(runnerConfig, nodeConfig)
 }
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/lasthyphen/dijetsnodego/database/leveldb"
+	"github.com/lasthyphen/dijetsnodego/database/migrate"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+const (
+	migrateDBCommand = "migrate-db"
+
+	sourceDBDirKey        = "source-db-dir"
+	sourceDBTypeKey       = "source-db-type"
+	sourceDBConfigFileKey = "source-db-config-file"
+	destDBDirKey          = "dest-db-dir"
+	destDBTypeKey         = "dest-db-type"
+	destDBConfigFileKey   = "dest-db-config-file"
+	batchSizeKey          = "batch-size"
+	logLevelKey           = "log-level"
+)
+
+var errMissingDBDir = errors.New("both --source-db-dir and --dest-db-dir must be specified")
+
+// migrateDB copies the versioned databases of an offline node from one
+// database backend to another. The provided directories are expected to
+// contain the database version directories, e.g. "<db-dir>/mainnet" for leveldb
+// or "<db-dir>/mainnet/pebble" for pebble.
+func migrateDB(args []string) int {
+	fs := pflag.NewFlagSet(migrateDBCommand, pflag.ContinueOnError)
+	fs.String(sourceDBDirKey, "", "Directory containing the versioned databases to copy from")
+	fs.String(sourceDBTypeKey, leveldb.Name, fmt.Sprintf("Database type of the source. Should be one of {%s, %s}", leveldb.Name, pebble.Name))
+	fs.String(sourceDBConfigFileKey, "", "Path to the source database config file")
+	fs.String(destDBDirKey, "", "Directory to copy the versioned databases into")
+	fs.String(destDBTypeKey, pebble.Name, fmt.Sprintf("Database type of the destination. Should be one of {%s, %s}", leveldb.Name, pebble.Name))
+	fs.String(destDBConfigFileKey, "", "Path to the destination database config file")
+	fs.Int(batchSizeKey, migrate.DefaultBatchSize, "Number of bytes to buffer before writing to the destination. Progress is recorded after every batch")
+	fs.String(logLevelKey, logging.Info.String(), "The log level")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+
+	config, log, err := getMigrateDBConfig(fs)
+	if err != nil {
+		fmt.Printf("couldn't load migration config: %s\n", err)
+		return 1
+	}
+
+	results, err := migrate.Run(config, log)
+	if err != nil {
+		fmt.Printf("migration failed: %s\n", err)
+		return 1
+	}
+
+	for _, result := range results {
+		fmt.Printf("%s: %d keys, checksum %s\n", result.Version, result.Keys, result.Checksum)
+	}
+	return 0
+}
+
+func getMigrateDBConfig(fs *pflag.FlagSet) (migrate.Config, logging.Logger, error) {
+	var (
+		config = migrate.Config{}
+		err    error
+	)
+	if config.SourcePath, err = fs.GetString(sourceDBDirKey); err != nil {
+		return config, nil, err
+	}
+	if config.SourceType, err = fs.GetString(sourceDBTypeKey); err != nil {
+		return config, nil, err
+	}
+	if config.DestPath, err = fs.GetString(destDBDirKey); err != nil {
+		return config, nil, err
+	}
+	if config.DestType, err = fs.GetString(destDBTypeKey); err != nil {
+		return config, nil, err
+	}
+	if config.BatchSize, err = fs.GetInt(batchSizeKey); err != nil {
+		return config, nil, err
+	}
+	if config.SourcePath == "" || config.DestPath == "" {
+		return config, nil, errMissingDBDir
+	}
+	if config.SourceConfig, err = readOptionalFile(fs, sourceDBConfigFileKey); err != nil {
+		return config, nil, err
+	}
+	if config.DestConfig, err = readOptionalFile(fs, destDBConfigFileKey); err != nil {
+		return config, nil, err
+	}
+
+	logLevelStr, err := fs.GetString(logLevelKey)
+	if err != nil {
+		return config, nil, err
+	}
+	logLevel, err := logging.ToLevel(logLevelStr)
+	if err != nil {
+		return config, nil, err
+	}
+	log := logging.NewLogger(
+		migrateDBCommand,
+		logging.NewWrappedCore(logLevel, os.Stdout, logging.Plain.ConsoleEncoder()),
+	)
+	return config, log, nil
+}
+
+func readOptionalFile(fs *pflag.FlagSet, key string) ([]byte, error) {
+	path, err := fs.GetString(key)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
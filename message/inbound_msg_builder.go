@@ -8,6 +8,8 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/proto/pb/p2p"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 )
 
@@ -296,3 +298,33 @@ func encodeIDs(ids []ids.ID, result [][]byte) {
 		result[i] = copy[:]
 	}
 }
+
+func encodeCompressionTypes(compressionTypes []compression.Type) []p2p.CompressionType {
+	result := make([]p2p.CompressionType, 0, len(compressionTypes))
+	for _, compressionType := range compressionTypes {
+		switch compressionType {
+		case compression.TypeGzip:
+			result = append(result, p2p.CompressionType_COMPRESSION_TYPE_GZIP)
+		case compression.TypeZstd:
+			result = append(result, p2p.CompressionType_COMPRESSION_TYPE_ZSTD)
+		}
+	}
+	return result
+}
+
+// DecodeCompressionTypes returns the compression types a peer reported
+// supporting in its Version message. Gzip is always included because every
+// peer supports it, even if it predates the field.
+func DecodeCompressionTypes(compressionTypes []p2p.CompressionType) set.Set[compression.Type] {
+	result := set.Set[compression.Type]{}
+	result.Add(compression.TypeGzip)
+	for _, compressionType := range compressionTypes {
+		switch compressionType {
+		case p2p.CompressionType_COMPRESSION_TYPE_GZIP:
+			result.Add(compression.TypeGzip)
+		case p2p.CompressionType_COMPRESSION_TYPE_ZSTD:
+			result.Add(compression.TypeZstd)
+		}
+	}
+	return result
+}
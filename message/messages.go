@@ -4,7 +4,9 @@
 package message
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,6 +27,8 @@ import (
 var (
 	_ InboundMessage  = (*inboundMessage)(nil)
 	_ OutboundMessage = (*outboundMessage)(nil)
+
+	errUnknownCompressionType = errors.New("unknown compression type")
 )
 
 // InboundMessage represents a set of fields for an inbound message
@@ -94,6 +98,9 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// CompressionType returns the compression algorithm that this message was
+	// compressed with
+	CompressionType() compression.Type
 }
 
 type outboundMessage struct {
@@ -101,6 +108,14 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	compressionType       compression.Type
+
+	// recompressedLock guards [recompressed].
+	recompressedLock sync.Mutex
+	// recompressed caches this message compressed with other compression
+	// types, so that a message sent to many peers is only recompressed once
+	// per compression type.
+	recompressed map[compression.Type]*outboundMessage
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -119,14 +134,78 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
-type msgBuilder struct {
-	gzipCompressor compression.Compressor
+func (m *outboundMessage) CompressionType() compression.Type {
+	return m.compressionType
+}
+
+// compressionTypes are the compression algorithms messages may be compressed
+// with.
+var compressionTypes = []compression.Type{
+	compression.TypeGzip,
+	compression.TypeZstd,
+}
+
+// compressionMetrics tracks the cost and effectiveness of a compression
+// algorithm for each message op.
+type compressionMetrics struct {
+	compressTime   map[Op]metric.Averager
+	compressRatio  map[Op]metric.Averager
+	decompressTime map[Op]metric.Averager
+}
 
-	compressTimeMetrics   map[Op]metric.Averager
-	decompressTimeMetrics map[Op]metric.Averager
+func newCompressionMetrics(
+	namespace string,
+	compressionType compression.Type,
+	metrics prometheus.Registerer,
+	errs *wrappers.Errs,
+) *compressionMetrics {
+	// The gzip series keep the names they had before other compression
+	// algorithms were supported, so existing dashboards keep working.
+	prefix := ""
+	if compressionType != compression.TypeGzip {
+		prefix = fmt.Sprintf("%s_", compressionType)
+	}
+
+	m := &compressionMetrics{
+		compressTime:   make(map[Op]metric.Averager, len(ExternalOps)),
+		compressRatio:  make(map[Op]metric.Averager, len(ExternalOps)),
+		decompressTime: make(map[Op]metric.Averager, len(ExternalOps)),
+	}
+	for _, op := range ExternalOps {
+		m.compressTime[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_%scompress_time", op, prefix),
+			fmt.Sprintf("time (in ns) to compress %s messages with %s", op, compressionType),
+			metrics,
+			errs,
+		)
+		m.compressRatio[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_%scompression_ratio", op, prefix),
+			fmt.Sprintf("size of %s messages compressed with %s relative to their uncompressed size", op, compressionType),
+			metrics,
+			errs,
+		)
+		m.decompressTime[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_%sdecompress_time", op, prefix),
+			fmt.Sprintf("time (in ns) to decompress %s messages with %s", op, compressionType),
+			metrics,
+			errs,
+		)
+	}
+	return m
+}
 
-	maxMessageTimeout time.Duration
+type msgBuilder struct {
+	compressors        map[compression.Type]compression.Compressor
+	compressionMetrics map[compression.Type]*compressionMetrics
+
+	// Maximum number of bytes a compressed message may decompress into.
+	// Decompression is aborted as soon as this limit is exceeded so that a
+	// small malicious payload can't force a large allocation.
+	maxDecompressedSize int64
+	maxMessageTimeout   time.Duration
 }
 
 func newMsgBuilder(
@@ -134,33 +213,31 @@ func newMsgBuilder(
 	metrics prometheus.Registerer,
 	maxMessageTimeout time.Duration,
 ) (*msgBuilder, error) {
-	cpr, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
+	gzipCompressor, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
 	if err != nil {
 		return nil, err
 	}
 
 	mb := &msgBuilder{
-		gzipCompressor: cpr,
-
-		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		compressors: map[compression.Type]compression.Compressor{
+			compression.TypeGzip: gzipCompressor,
+			compression.TypeZstd: zstdCompressor,
+		},
+		compressionMetrics: make(map[compression.Type]*compressionMetrics, len(compressionTypes)),
 
-		maxMessageTimeout: maxMessageTimeout,
+		maxDecompressedSize: constants.DefaultMaxMessageSize,
+		maxMessageTimeout:   maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		mb.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages", op),
-			metrics,
-			&errs,
-		)
-		mb.decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+	for _, compressionType := range compressionTypes {
+		mb.compressionMetrics[compressionType] = newCompressionMetrics(
 			namespace,
-			fmt.Sprintf("%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages", op),
+			compressionType,
 			metrics,
 			&errs,
 		)
@@ -170,14 +247,14 @@ func newMsgBuilder(
 
 func (mb *msgBuilder) marshal(
 	uncompressedMsg *p2ppb.Message,
-	gzipCompress bool,
+	compressionType compression.Type,
 ) ([]byte, int, time.Duration, error) {
 	uncompressedMsgBytes, err := proto.Marshal(uncompressedMsg)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	if !gzipCompress {
+	if compressionType == compression.TypeNone {
 		return uncompressedMsgBytes, 0, 0, nil
 	}
 
@@ -187,16 +264,27 @@ func (mb *msgBuilder) marshal(
 	//
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
+	compressor, ok := mb.compressors[compressionType]
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("%w: %s", errUnknownCompressionType, compressionType)
+	}
+
 	startTime := time.Now()
-	compressedBytes, err := mb.gzipCompressor.Compress(uncompressedMsgBytes)
+	compressedBytes, err := compressor.Compress(uncompressedMsgBytes)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	compressedMsg := p2ppb.Message{
-		Message: &p2ppb.Message_CompressedGzip{
+	var compressedMsg p2ppb.Message
+	switch compressionType {
+	case compression.TypeGzip:
+		compressedMsg.Message = &p2ppb.Message_CompressedGzip{
 			CompressedGzip: compressedBytes,
-		},
+		}
+	case compression.TypeZstd:
+		compressedMsg.Message = &p2ppb.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
+		}
 	}
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
 	if err != nil {
@@ -208,35 +296,46 @@ func (mb *msgBuilder) marshal(
 	return compressedMsgBytes, bytesSaved, compressTook, nil
 }
 
-func (mb *msgBuilder) unmarshal(b []byte) (*p2ppb.Message, bool, int, time.Duration, error) {
+func (mb *msgBuilder) unmarshal(b []byte) (*p2ppb.Message, compression.Type, int, time.Duration, error) {
 	m := new(p2ppb.Message)
 	if err := proto.Unmarshal(b, m); err != nil {
-		return nil, false, 0, 0, err
+		return nil, compression.TypeNone, 0, 0, err
 	}
 
-	compressed := m.GetCompressedGzip()
+	var (
+		compressionType compression.Type
+		compressed      []byte
+	)
+	switch msg := m.GetMessage().(type) {
+	case *p2ppb.Message_CompressedGzip:
+		compressionType = compression.TypeGzip
+		compressed = msg.CompressedGzip
+	case *p2ppb.Message_CompressedZstd:
+		compressionType = compression.TypeZstd
+		compressed = msg.CompressedZstd
+	}
 	if len(compressed) == 0 {
 		// The message wasn't compressed
-		return m, false, 0, 0, nil
+		return m, compression.TypeNone, 0, 0, nil
 	}
 
 	startTime := time.Now()
-	decompressed, err := mb.gzipCompressor.Decompress(compressed)
+	decompressed, err := mb.compressors[compressionType].DecompressWithLimit(compressed, mb.maxDecompressedSize)
 	if err != nil {
-		return nil, true, 0, 0, err
+		return nil, compressionType, 0, 0, err
 	}
 
 	if err := proto.Unmarshal(decompressed, m); err != nil {
-		return nil, true, 0, 0, err
+		return nil, compressionType, 0, 0, err
 	}
 	decompressTook := time.Since(startTime)
 
 	bytesSavedCompression := len(decompressed) - len(compressed)
-	return m, true, bytesSavedCompression, decompressTook, nil
+	return m, compressionType, bytesSavedCompression, decompressTook, nil
 }
 
-func (mb *msgBuilder) createOutbound(m *p2ppb.Message, gzipCompress bool, bypassThrottling bool) (*outboundMessage, error) {
-	b, saved, compressTook, err := mb.marshal(m, gzipCompress)
+func (mb *msgBuilder) createOutbound(m *p2ppb.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
+	b, saved, compressTook, err := mb.marshal(m, compressionType)
 	if err != nil {
 		return nil, err
 	}
@@ -246,8 +345,12 @@ func (mb *msgBuilder) createOutbound(m *p2ppb.Message, gzipCompress bool, bypass
 		return nil, err
 	}
 
-	if gzipCompress {
-		mb.compressTimeMetrics[op].Observe(float64(compressTook))
+	if compressionType != compression.TypeNone {
+		metrics := mb.compressionMetrics[compressionType]
+		metrics.compressTime[op].Observe(float64(compressTook))
+		if uncompressedLen := len(b) + saved; uncompressedLen > 0 {
+			metrics.compressRatio[op].Observe(float64(len(b)) / float64(uncompressedLen))
+		}
 	}
 
 	return &outboundMessage{
@@ -255,15 +358,56 @@ func (mb *msgBuilder) createOutbound(m *p2ppb.Message, gzipCompress bool, bypass
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
+		compressionType:       compressionType,
 	}, nil
 }
 
+// recompress returns [msg] compressed with [compressionType]. If [msg] wasn't
+// compressed, or was already compressed with [compressionType], [msg] is
+// returned. The result is cached on [msg], so recompressing the same message
+// again returns the same message.
+func (mb *msgBuilder) recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	msgCompressionType := msg.CompressionType()
+	if msgCompressionType == compression.TypeNone || msgCompressionType == compressionType {
+		return msg, nil
+	}
+
+	outMsg, ok := msg.(*outboundMessage)
+	if !ok {
+		return mb.createRecompressed(msg, compressionType)
+	}
+
+	outMsg.recompressedLock.Lock()
+	defer outMsg.recompressedLock.Unlock()
+
+	if recompressedMsg, ok := outMsg.recompressed[compressionType]; ok {
+		return recompressedMsg, nil
+	}
+	recompressedMsg, err := mb.createRecompressed(msg, compressionType)
+	if err != nil {
+		return nil, err
+	}
+	if outMsg.recompressed == nil {
+		outMsg.recompressed = make(map[compression.Type]*outboundMessage)
+	}
+	outMsg.recompressed[compressionType] = recompressedMsg
+	return recompressedMsg, nil
+}
+
+func (mb *msgBuilder) createRecompressed(msg OutboundMessage, compressionType compression.Type) (*outboundMessage, error) {
+	m, _, _, _, err := mb.unmarshal(msg.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return mb.createOutbound(m, compressionType, msg.BypassThrottling())
+}
+
 func (mb *msgBuilder) parseInbound(
 	bytes []byte,
 	nodeID ids.NodeID,
 	onFinishedHandling func(),
 ) (*inboundMessage, error) {
-	m, wasCompressed, bytesSavedCompression, decompressTook, err := mb.unmarshal(bytes)
+	m, compressionType, bytesSavedCompression, decompressTook, err := mb.unmarshal(bytes)
 	if err != nil {
 		return nil, err
 	}
@@ -278,8 +422,8 @@ func (mb *msgBuilder) parseInbound(
 		return nil, err
 	}
 
-	if wasCompressed {
-		mb.decompressTimeMetrics[op].Observe(float64(decompressTook))
+	if compressionType != compression.TypeNone {
+		mb.compressionMetrics[compressionType].decompressTime[op].Observe(float64(decompressTook))
 	}
 
 	expiration := mockable.MaxTime
@@ -17,6 +17,8 @@ import (
 
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/staking"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
 
 	p2ppb "github.com/lasthyphen/dijetsnodego/proto/pb/p2p"
 )
@@ -52,7 +54,7 @@ func TestMessage(t *testing.T) {
 		desc             string
 		op               Op
 		msg              *p2ppb.Message
-		gzipCompress     bool
+		compressionType  compression.Type
 		bypassThrottling bool
 		bytesSaved       bool // if true, outbound message saved bytes must be non-zero
 	}{
@@ -64,7 +66,7 @@ func TestMessage(t *testing.T) {
 					Ping: &p2ppb.Ping{},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -78,7 +80,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -98,7 +100,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -119,7 +121,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -141,7 +143,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -163,7 +165,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -179,7 +181,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: false,
 			bytesSaved:       false,
 		},
@@ -195,7 +197,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -211,7 +213,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -227,7 +229,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -244,7 +246,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -261,7 +263,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -277,7 +279,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -293,7 +295,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -309,7 +311,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -325,7 +327,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -342,7 +344,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -358,7 +360,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -375,7 +377,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -391,7 +393,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -407,7 +409,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -424,7 +426,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -440,7 +442,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -456,7 +458,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -473,7 +475,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -490,7 +492,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -507,7 +509,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -523,7 +525,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -540,7 +542,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -557,7 +559,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -573,7 +575,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -589,7 +591,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -604,7 +606,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -619,7 +621,38 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "ancestors message with zstd compression",
+			op:   AncestorsOp,
+			msg: &p2ppb.Message{
+				Message: &p2ppb.Message_Ancestors_{
+					Ancestors_: &p2ppb.Ancestors{
+						ChainId:    testID[:],
+						RequestId:  12345,
+						Containers: compressibleContainers,
+					},
+				},
+			},
+			compressionType:  compression.TypeZstd,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "app_gossip message with zstd compression",
+			op:   AppGossipOp,
+			msg: &p2ppb.Message{
+				Message: &p2ppb.Message_AppGossip{
+					AppGossip: &p2ppb.AppGossip{
+						ChainId:  testID[:],
+						AppBytes: compressibleContainers[0],
+					},
+				},
+			},
+			compressionType:  compression.TypeZstd,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -627,7 +660,7 @@ func TestMessage(t *testing.T) {
 
 	for _, tv := range tests {
 		require.True(t.Run(tv.desc, func(t2 *testing.T) {
-			encodedMsg, err := mb.createOutbound(tv.msg, tv.gzipCompress, tv.bypassThrottling)
+			encodedMsg, err := mb.createOutbound(tv.msg, tv.compressionType, tv.bypassThrottling)
 			require.NoError(err)
 
 			require.Equal(tv.bypassThrottling, encodedMsg.BypassThrottling())
@@ -635,6 +668,7 @@ func TestMessage(t *testing.T) {
 
 			bytesSaved := encodedMsg.BytesSavedCompression()
 			require.Equal(tv.bytesSaved, bytesSaved > 0)
+			require.Equal(tv.compressionType, encodedMsg.CompressionType())
 
 			parsedMsg, err := mb.parseInbound(encodedMsg.Bytes(), ids.EmptyNodeID, func() {})
 			require.NoError(err)
@@ -690,3 +724,126 @@ func TestNilInboundMessage(t *testing.T) {
 	require.True(ok)
 	require.NotNil(pingMsg)
 }
+
+func TestRecompress(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	msg := &p2ppb.Message{
+		Message: &p2ppb.Message_Put{
+			Put: &p2ppb.Put{
+				ChainId:   chainID[:],
+				RequestId: 12345,
+				Container: bytes.Repeat([]byte{'a'}, 1024),
+			},
+		},
+	}
+	zstdMsg, err := mb.createOutbound(msg, compression.TypeZstd, true)
+	require.NoError(err)
+
+	gzipMsg, err := mb.recompress(zstdMsg, compression.TypeGzip)
+	require.NoError(err)
+	require.Equal(compression.TypeGzip, gzipMsg.CompressionType())
+	require.Equal(PutOp, gzipMsg.Op())
+	require.True(gzipMsg.BypassThrottling())
+
+	parsedMsg, err := mb.parseInbound(gzipMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(PutOp, parsedMsg.Op())
+	require.Equal(msg.GetPut().Container, parsedMsg.Message().(*p2ppb.Put).Container)
+
+	// Recompressing the same message again reuses the first result.
+	cachedMsg, err := mb.recompress(zstdMsg, compression.TypeGzip)
+	require.NoError(err)
+	require.Same(gzipMsg, cachedMsg)
+
+	// Messages already compressed with the requested type are unchanged.
+	sameMsg, err := mb.recompress(gzipMsg, compression.TypeGzip)
+	require.NoError(err)
+	require.Equal(gzipMsg, sameMsg)
+
+	// Uncompressed messages are never compressed.
+	uncompressedMsg, err := mb.createOutbound(msg, compression.TypeNone, false)
+	require.NoError(err)
+	sameMsg, err = mb.recompress(uncompressedMsg, compression.TypeGzip)
+	require.NoError(err)
+	require.Equal(uncompressedMsg, sameMsg)
+}
+
+func TestCompressionMetricNames(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	_, err := newMsgBuilder("test", registry, 5*time.Second)
+	require.NoError(err)
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	names := make(map[string]struct{}, len(metrics))
+	for _, metric := range metrics {
+		names[metric.GetName()] = struct{}{}
+	}
+
+	// The gzip series keep their original names and the zstd series are
+	// registered next to them.
+	for _, name := range []string{
+		"test_put_compress_time_count",
+		"test_put_decompress_time_count",
+		"test_put_compression_ratio_count",
+		"test_put_zstd_compress_time_count",
+		"test_put_zstd_decompress_time_count",
+		"test_put_zstd_compression_ratio_count",
+	} {
+		require.Contains(names, name)
+	}
+}
+
+func TestParseInboundDecompressionLimit(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	msg := &p2ppb.Message{
+		Message: &p2ppb.Message_Put{
+			Put: &p2ppb.Put{
+				ChainId:   chainID[:],
+				RequestId: 12345,
+				Container: make([]byte, 64*units.KiB),
+			},
+		},
+	}
+
+	for _, compressionType := range compressionTypes {
+		outboundMsg, err := mb.createOutbound(msg, compressionType, false)
+		require.NoError(err)
+
+		mb.maxDecompressedSize = 64 * units.KiB
+		_, err = mb.parseInbound(outboundMsg.Bytes(), ids.EmptyNodeID, func() {})
+		require.ErrorIs(err, compression.ErrDecompressedMsgTooLarge)
+
+		mb.maxDecompressedSize = 128 * units.KiB
+		parsedMsg, err := mb.parseInbound(outboundMsg.Bytes(), ids.EmptyNodeID, func() {})
+		require.NoError(err)
+		require.Equal(PutOp, parsedMsg.Op())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	compression "github.com/lasthyphen/dijetsnodego/utils/compression"
 )
 
 // MockOutboundMessage is a mock of OutboundMessage interface.
@@ -78,6 +79,20 @@ func (mr *MockOutboundMessageMockRecorder) BytesSavedCompression() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesSavedCompression", reflect.TypeOf((*MockOutboundMessage)(nil).BytesSavedCompression))
 }
 
+// CompressionType mocks base method.
+func (m *MockOutboundMessage) CompressionType() compression.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompressionType")
+	ret0, _ := ret[0].(compression.Type)
+	return ret0
+}
+
+// CompressionType indicates an expected call of CompressionType.
+func (mr *MockOutboundMessageMockRecorder) CompressionType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompressionType", reflect.TypeOf((*MockOutboundMessage)(nil).CompressionType))
+}
+
 // Op mocks base method.
 func (m *MockOutboundMessage) Op() Op {
 	m.ctrl.T.Helper()
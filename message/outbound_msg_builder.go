@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
 
 	p2ppb "github.com/lasthyphen/dijetsnodego/proto/pb/p2p"
@@ -25,6 +26,8 @@ type OutboundMsgBuilder interface {
 		myVersion string,
 		myVersionTime uint64,
 		sig []byte,
+		ipv6 ips.IPPort,
+		ipv6Sig []byte,
 		trackedSubnets []ids.ID,
 	) (OutboundMessage, error)
 
@@ -155,20 +158,28 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	// Recompress returns [msg] compressed with [compressionType] rather than
+	// the compression type it was built with. Uncompressed messages are
+	// returned unchanged.
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilder struct {
-	compress bool // set to "true" if compression is enabled
+	compressionType compression.Type
 
 	builder *msgBuilder
 }
 
 // Use "message.NewCreator" to import this function
 // since we do not expose "msgBuilder" yet
-func newOutboundBuilder(enableCompression bool, builder *msgBuilder) OutboundMsgBuilder {
+func newOutboundBuilder(compressionType compression.Type, builder *msgBuilder) OutboundMsgBuilder {
 	return &outMsgBuilder{
-		compress: enableCompression,
-		builder:  builder,
+		compressionType: compressionType,
+		builder:         builder,
 	}
 }
 
@@ -179,7 +190,7 @@ func (b *outMsgBuilder) Ping() (OutboundMessage, error) {
 				Ping: &p2ppb.Ping{},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -197,7 +208,7 @@ func (b *outMsgBuilder) Pong(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -209,6 +220,8 @@ func (b *outMsgBuilder) Version(
 	myVersion string,
 	myVersionTime uint64,
 	sig []byte,
+	ipv6 ips.IPPort,
+	ipv6Sig []byte,
 	trackedSubnets []ids.ID,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
@@ -217,18 +230,22 @@ func (b *outMsgBuilder) Version(
 		&p2ppb.Message{
 			Message: &p2ppb.Message_Version{
 				Version: &p2ppb.Version{
-					NetworkId:      networkID,
-					MyTime:         myTime,
-					IpAddr:         ip.IP.To16(),
-					IpPort:         uint32(ip.Port),
-					MyVersion:      myVersion,
-					MyVersionTime:  myVersionTime,
-					Sig:            sig,
-					TrackedSubnets: subnetIDBytes,
+					NetworkId:                 networkID,
+					MyTime:                    myTime,
+					IpAddr:                    ip.IP.To16(),
+					IpPort:                    uint32(ip.Port),
+					MyVersion:                 myVersion,
+					MyVersionTime:             myVersionTime,
+					Sig:                       sig,
+					TrackedSubnets:            subnetIDBytes,
+					SupportedCompressionTypes: encodeCompressionTypes(compressionTypes),
+					Ipv6Addr:                  ipv6.IP.To16(),
+					Ipv6Port:                  uint32(ipv6.Port),
+					Ipv6Sig:                   ipv6Sig,
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		true,
 	)
 }
@@ -253,7 +270,7 @@ func (b *outMsgBuilder) PeerList(peers []ips.ClaimedIPPort, bypassThrottling boo
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		bypassThrottling,
 	)
 }
@@ -270,7 +287,7 @@ func (b *outMsgBuilder) PeerListAck(txIDs []ids.ID) (OutboundMessage, error) {
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -290,7 +307,7 @@ func (b *outMsgBuilder) GetStateSummaryFrontier(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -310,7 +327,7 @@ func (b *outMsgBuilder) StateSummaryFrontier(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -332,7 +349,7 @@ func (b *outMsgBuilder) GetAcceptedStateSummary(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -354,7 +371,7 @@ func (b *outMsgBuilder) AcceptedStateSummary(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -374,7 +391,7 @@ func (b *outMsgBuilder) GetAcceptedFrontier(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -396,7 +413,7 @@ func (b *outMsgBuilder) AcceptedFrontier(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -420,7 +437,7 @@ func (b *outMsgBuilder) GetAccepted(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -442,7 +459,7 @@ func (b *outMsgBuilder) Accepted(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -464,7 +481,7 @@ func (b *outMsgBuilder) GetAncestors(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -484,7 +501,7 @@ func (b *outMsgBuilder) Ancestors(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -506,7 +523,7 @@ func (b *outMsgBuilder) Get(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -526,7 +543,7 @@ func (b *outMsgBuilder) Put(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -548,7 +565,7 @@ func (b *outMsgBuilder) PushQuery(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -570,7 +587,7 @@ func (b *outMsgBuilder) PullQuery(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -592,7 +609,7 @@ func (b *outMsgBuilder) Chits(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -614,7 +631,7 @@ func (b *outMsgBuilder) AppRequest(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -630,7 +647,7 @@ func (b *outMsgBuilder) AppResponse(chainID ids.ID, requestID uint32, msg []byte
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -645,7 +662,14 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
+
+func (b *outMsgBuilder) Recompress(
+	msg OutboundMessage,
+	compressionType compression.Type,
+) (OutboundMessage, error) {
+	return b.builder.recompress(msg, compressionType)
+}
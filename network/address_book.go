@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+// addressBookRefreshFreq is how often the IPs of connected peers are
+// re-persisted, so that peers we stay connected to don't become stale.
+const addressBookRefreshFreq = time.Hour
+
+// ipv6KeySuffix is appended to the nodeID in the key of IPv6 entries, so that
+// both IPs of dual-stack peers are persisted. IPv4 entries are keyed by the
+// nodeID alone.
+const ipv6KeySuffix byte = 6
+
+var (
+	errNodeIDMismatch  = errors.New("certificate doesn't match nodeID")
+	errIPVersionKey    = errors.New("IP version doesn't match key")
+	errInvalidEntryKey = errors.New("invalid address book entry key")
+)
+
+// addressBook persists the signed IPs of peers, so that they can be reconnected
+// to after a restart without relying on bootstrappers or gossip.
+//
+// Every entry records the last time the IP was learned or confirmed. Entries
+// that haven't been refreshed within [maxAge] are pruned when loaded.
+type addressBook struct {
+	db     database.Database
+	maxAge time.Duration
+
+	lock sync.Mutex
+	// persisted tracks the entries in [db], keyed by their database key, so
+	// that IPs that are gossiped again without changing aren't rewritten.
+	persisted map[string]addressBookEntry
+}
+
+// addressBookEntry is the part of a persisted entry that determines whether
+// it needs to be rewritten.
+type addressBookEntry struct {
+	ip        ips.IPPort
+	timestamp uint64
+	lastSeen  time.Time
+}
+
+func newAddressBook(db database.Database, maxAge time.Duration) *addressBook {
+	return &addressBook{
+		db:        db,
+		maxAge:    maxAge,
+		persisted: make(map[string]addressBookEntry),
+	}
+}
+
+// put records that [ip] was seen at [lastSeen], replacing any previous entry
+// for the same node and IP version. If the same signed IP was already recorded
+// within [addressBookRefreshFreq] of [lastSeen], nothing is written.
+func (a *addressBook) put(ip ips.ClaimedIPPort, lastSeen time.Time) error {
+	nodeID := ids.NodeIDFromCert(ip.Cert)
+	key := addressBookKey(nodeID, ip.IPPort)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if entry, ok := a.persisted[string(key)]; ok &&
+		entry.ip.Equal(ip.IPPort) &&
+		entry.timestamp == ip.Timestamp &&
+		lastSeen.Sub(entry.lastSeen) < addressBookRefreshFreq {
+		return nil
+	}
+
+	p := wrappers.Packer{
+		MaxSize: wrappers.LongLen + wrappers.IntLen + len(ip.Cert.Raw) +
+			wrappers.IPLen + wrappers.LongLen + wrappers.IntLen + len(ip.Signature),
+	}
+	p.PackLong(uint64(lastSeen.Unix()))
+	p.PackBytes(ip.Cert.Raw)
+	ips.PackIP(&p, ip.IPPort)
+	p.PackLong(ip.Timestamp)
+	p.PackBytes(ip.Signature)
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := a.db.Put(key, p.Bytes); err != nil {
+		return err
+	}
+	a.persisted[string(key)] = addressBookEntry{
+		ip:        ip.IPPort,
+		timestamp: ip.Timestamp,
+		lastSeen:  lastSeen,
+	}
+	return nil
+}
+
+// load returns the IPs that were seen within [maxAge] of [now]. Stale and
+// malformed entries are removed.
+func (a *addressBook) load(now time.Time) ([]ips.ClaimedIPPort, error) {
+	var (
+		claimedIPs []ips.ClaimedIPPort
+		toDelete   [][]byte
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	it := a.db.NewIterator()
+	defer it.Release()
+
+	a.persisted = make(map[string]addressBookEntry)
+	for it.Next() {
+		key := it.Key()
+		lastSeen, ip, err := parseAddressBookEntry(key, it.Value())
+		if err != nil || now.Sub(lastSeen) > a.maxAge {
+			toDelete = append(toDelete, key)
+			continue
+		}
+		claimedIPs = append(claimedIPs, ip)
+		a.persisted[string(key)] = addressBookEntry{
+			ip:        ip.IPPort,
+			timestamp: ip.Timestamp,
+			lastSeen:  lastSeen,
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	batch := a.db.NewBatch()
+	for _, key := range toDelete {
+		if err := batch.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+	return claimedIPs, batch.Write()
+}
+
+func addressBookKey(nodeID ids.NodeID, ip ips.IPPort) []byte {
+	if !ip.IsIPv6() {
+		return nodeID[:]
+	}
+	key := make([]byte, 0, len(nodeID)+1)
+	key = append(key, nodeID[:]...)
+	return append(key, ipv6KeySuffix)
+}
+
+func parseAddressBookEntry(key, value []byte) (time.Time, ips.ClaimedIPPort, error) {
+	isIPv6 := false
+	if len(key) == len(ids.NodeID{})+1 {
+		if key[len(key)-1] != ipv6KeySuffix {
+			return time.Time{}, ips.ClaimedIPPort{}, errInvalidEntryKey
+		}
+		isIPv6 = true
+		key = key[:len(key)-1]
+	}
+	nodeID, err := ids.ToNodeID(key)
+	if err != nil {
+		return time.Time{}, ips.ClaimedIPPort{}, err
+	}
+
+	p := wrappers.Packer{Bytes: value}
+	lastSeen := time.Unix(int64(p.UnpackLong()), 0)
+	certBytes := p.UnpackBytes()
+	ipPort := ips.UnpackIP(&p)
+	timestamp := p.UnpackLong()
+	signature := p.UnpackBytes()
+	if p.Err != nil {
+		return time.Time{}, ips.ClaimedIPPort{}, p.Err
+	}
+
+	if ipPort.IsIPv6() != isIPv6 {
+		return time.Time{}, ips.ClaimedIPPort{}, errIPVersionKey
+	}
+
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return time.Time{}, ips.ClaimedIPPort{}, err
+	}
+	if certNodeID := ids.NodeIDFromCert(cert); certNodeID != nodeID {
+		return time.Time{}, ips.ClaimedIPPort{}, fmt.Errorf("%w: expected %s but got %s",
+			errNodeIDMismatch,
+			nodeID,
+			certNodeID,
+		)
+	}
+	return lastSeen, ips.ClaimedIPPort{
+		Cert:      cert,
+		IPPort:    ipPort,
+		Timestamp: timestamp,
+		Signature: signature,
+	}, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
+)
+
+func TestAddressBookPutLoad(t *testing.T) {
+	require := require.New(t)
+
+	nodeID, tlsCert, _ := getTLS(t, 0)
+	ip := ips.ClaimedIPPort{
+		Cert: tlsCert.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+		Signature: []byte{1, 2, 3},
+	}
+
+	db := memdb.New()
+	book := newAddressBook(db, time.Hour)
+	now := time.Unix(1_000_000, 0)
+	require.NoError(book.put(ip, now))
+
+	loadedIPs, err := book.load(now.Add(time.Hour))
+	require.NoError(err)
+	require.Len(loadedIPs, 1)
+	loadedIP := loadedIPs[0]
+	require.Equal(ip.Cert.Raw, loadedIP.Cert.Raw)
+	require.True(ip.IPPort.Equal(loadedIP.IPPort))
+	require.Equal(ip.Timestamp, loadedIP.Timestamp)
+	require.Equal(ip.Signature, loadedIP.Signature)
+
+	has, err := db.Has(nodeID[:])
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestAddressBookPrunesStaleIPs(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0, tlsCert0, _ := getTLS(t, 0)
+	nodeID1, tlsCert1, _ := getTLS(t, 1)
+
+	db := memdb.New()
+	book := newAddressBook(db, time.Hour)
+	now := time.Unix(1_000_000, 0)
+	require.NoError(book.put(ips.ClaimedIPPort{
+		Cert: tlsCert0.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+	}, now.Add(-2*time.Hour)))
+	require.NoError(book.put(ips.ClaimedIPPort{
+		Cert: tlsCert1.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 124),
+			Port: 10000,
+		},
+	}, now))
+
+	loadedIPs, err := book.load(now)
+	require.NoError(err)
+	require.Len(loadedIPs, 1)
+	require.Equal(tlsCert1.Leaf.Raw, loadedIPs[0].Cert.Raw)
+
+	has, err := db.Has(nodeID0[:])
+	require.NoError(err)
+	require.False(has)
+
+	has, err = db.Has(nodeID1[:])
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestAddressBookPrunesMalformedEntries(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0, tlsCert0, _ := getTLS(t, 0)
+	nodeID1, _, _ := getTLS(t, 1)
+
+	db := memdb.New()
+	book := newAddressBook(db, time.Hour)
+	now := time.Unix(1_000_000, 0)
+	require.NoError(book.put(ips.ClaimedIPPort{
+		Cert: tlsCert0.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+	}, now))
+
+	// Store [nodeID0]'s entry under [nodeID1].
+	value, err := db.Get(nodeID0[:])
+	require.NoError(err)
+	require.NoError(db.Put(nodeID1[:], value))
+
+	// Store an entry that can't be parsed.
+	require.NoError(db.Put([]byte{1, 2, 3}, []byte{4, 5, 6}))
+
+	loadedIPs, err := book.load(now)
+	require.NoError(err)
+	require.Len(loadedIPs, 1)
+
+	has, err := db.Has(nodeID1[:])
+	require.NoError(err)
+	require.False(has)
+
+	has, err = db.Has([]byte{1, 2, 3})
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestAddressBookPutLoadDualStack(t *testing.T) {
+	require := require.New(t)
+
+	nodeID, tlsCert, _ := getTLS(t, 0)
+	ipv4 := ips.ClaimedIPPort{
+		Cert: tlsCert.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+		Signature: []byte{1, 2, 3},
+	}
+	ipv6 := ips.ClaimedIPPort{
+		Cert: tlsCert.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.ParseIP("2001:db8::1"),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+		Signature: []byte{4, 5, 6},
+	}
+
+	db := memdb.New()
+	book := newAddressBook(db, time.Hour)
+	now := time.Unix(1_000_000, 0)
+	require.NoError(book.put(ipv4, now))
+	require.NoError(book.put(ipv6, now))
+
+	// Both IPs of a dual-stack node are persisted.
+	loadedIPs, err := book.load(now)
+	require.NoError(err)
+	require.Len(loadedIPs, 2)
+
+	numIPv6 := 0
+	for _, loadedIP := range loadedIPs {
+		require.Equal(tlsCert.Leaf.Raw, loadedIP.Cert.Raw)
+		if loadedIP.IPPort.IsIPv6() {
+			numIPv6++
+			require.True(ipv6.IPPort.Equal(loadedIP.IPPort))
+			require.Equal(ipv6.Signature, loadedIP.Signature)
+		} else {
+			require.True(ipv4.IPPort.Equal(loadedIP.IPPort))
+			require.Equal(ipv4.Signature, loadedIP.Signature)
+		}
+	}
+	require.Equal(1, numIPv6)
+
+	has, err := db.Has(nodeID[:])
+	require.NoError(err)
+	require.True(has)
+
+	// An IPv6 entry stored under an IPv4 key is pruned.
+	value, err := db.Get(addressBookKey(nodeID, ipv6.IPPort))
+	require.NoError(err)
+	require.NoError(db.Put(nodeID[:], value))
+
+	loadedIPs, err = book.load(now)
+	require.NoError(err)
+	require.Len(loadedIPs, 1)
+	require.True(loadedIPs[0].IPPort.IsIPv6())
+}
+
+func TestAddressBookSkipsUnchangedIPs(t *testing.T) {
+	require := require.New(t)
+
+	nodeID, tlsCert, _ := getTLS(t, 0)
+	ip := ips.ClaimedIPPort{
+		Cert: tlsCert.Leaf,
+		IPPort: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+		Signature: []byte{1, 2, 3},
+	}
+
+	db := memdb.New()
+	book := newAddressBook(db, 24*time.Hour)
+	now := time.Unix(1_000_000, 0)
+	require.NoError(book.put(ip, now))
+
+	// Remove the entry behind the address book's back, so that writes can be
+	// observed.
+	require.NoError(db.Delete(nodeID[:]))
+
+	// The same signed IP isn't rewritten until it needs to be refreshed.
+	require.NoError(book.put(ip, now.Add(addressBookRefreshFreq-time.Second)))
+	has, err := db.Has(nodeID[:])
+	require.NoError(err)
+	require.False(has)
+
+	require.NoError(book.put(ip, now.Add(addressBookRefreshFreq)))
+	has, err = db.Has(nodeID[:])
+	require.NoError(err)
+	require.True(has)
+
+	// A new signed IP is written immediately.
+	require.NoError(db.Delete(nodeID[:]))
+	ip.Timestamp++
+	require.NoError(book.put(ip, now.Add(addressBookRefreshFreq)))
+	has, err = db.Has(nodeID[:])
+	require.NoError(err)
+	require.True(has)
+
+	// Loading the entries from disk doesn't cause them to be rewritten.
+	_, err = book.load(now.Add(addressBookRefreshFreq))
+	require.NoError(err)
+	require.NoError(db.Delete(nodeID[:]))
+	require.NoError(book.put(ip, now.Add(addressBookRefreshFreq)))
+	has, err = db.Has(nodeID[:])
+	require.NoError(err)
+	require.False(has)
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/database"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+// Ban is a node that this node refuses to connect to until [Until].
+type Ban struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Until  time.Time  `json:"until"`
+}
+
+// banList tracks the nodes that were banned by the operator. If [db] is
+// non-nil, bans are persisted so that they survive restarts.
+type banList struct {
+	db database.Database
+
+	lock sync.RWMutex
+	bans map[ids.NodeID]time.Time
+}
+
+// newBanList returns a ban list that contains the unexpired bans persisted in
+// [db]. Expired and malformed bans are removed from [db].
+func newBanList(db database.Database, now time.Time) (*banList, error) {
+	b := &banList{
+		db:   db,
+		bans: make(map[ids.NodeID]time.Time),
+	}
+	if db == nil {
+		return b, nil
+	}
+
+	var toDelete [][]byte
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		nodeID, until, err := parseBan(key, it.Value())
+		if err != nil || !now.Before(until) {
+			toDelete = append(toDelete, key)
+			continue
+		}
+		b.bans[nodeID] = until
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	batch := db.NewBatch()
+	for _, key := range toDelete {
+		if err := batch.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+	return b, batch.Write()
+}
+
+// ban refuses connections to [nodeID] until [until], replacing any previous
+// ban of [nodeID].
+func (b *banList) ban(nodeID ids.NodeID, until time.Time) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.db != nil {
+		p := wrappers.Packer{MaxSize: wrappers.LongLen}
+		p.PackLong(uint64(until.Unix()))
+		if err := b.db.Put(nodeID[:], p.Bytes); err != nil {
+			return err
+		}
+	}
+	b.bans[nodeID] = until
+	return nil
+}
+
+// isBanned returns true if [nodeID] is banned at [now].
+func (b *banList) isBanned(nodeID ids.NodeID, now time.Time) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	until, ok := b.bans[nodeID]
+	return ok && now.Before(until)
+}
+
+// list returns the bans that haven't expired by [now].
+func (b *banList) list(now time.Time) []Ban {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	bans := make([]Ban, 0, len(b.bans))
+	for nodeID, until := range b.bans {
+		if now.Before(until) {
+			bans = append(bans, Ban{
+				NodeID: nodeID,
+				Until:  until,
+			})
+		}
+	}
+	return bans
+}
+
+func parseBan(key, value []byte) (ids.NodeID, time.Time, error) {
+	nodeID, err := ids.ToNodeID(key)
+	if err != nil {
+		return ids.EmptyNodeID, time.Time{}, err
+	}
+
+	p := wrappers.Packer{Bytes: value}
+	until := time.Unix(int64(p.UnpackLong()), 0)
+	return nodeID, until, p.Err
+}
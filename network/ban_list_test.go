@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestBanListPersistsBans(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	now := time.Unix(1_000_000, 0)
+	bans, err := newBanList(db, now)
+	require.NoError(err)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	require.NoError(bans.ban(nodeID0, now.Add(time.Hour)))
+	require.NoError(bans.ban(nodeID1, now.Add(2*time.Hour)))
+
+	require.True(bans.isBanned(nodeID0, now))
+	require.False(bans.isBanned(nodeID0, now.Add(time.Hour)))
+	require.False(bans.isBanned(ids.GenerateTestNodeID(), now))
+	require.Len(bans.list(now), 2)
+
+	// After restarting, only the unexpired ban is loaded and the expired one
+	// is removed.
+	later := now.Add(time.Hour)
+	bans, err = newBanList(db, later)
+	require.NoError(err)
+	require.Equal(
+		[]Ban{{
+			NodeID: nodeID1,
+			Until:  now.Add(2 * time.Hour),
+		}},
+		bans.list(later),
+	)
+
+	has, err := db.Has(nodeID0[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestBanListWithoutDB(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	bans, err := newBanList(nil, now)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(bans.ban(nodeID, now.Add(time.Hour)))
+	require.True(bans.isBanned(nodeID, now))
+}
@@ -8,17 +8,31 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/network/dialer"
 	"github.com/lasthyphen/dijetsnodego/network/peer"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/snow/uptime"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 )
 
+const (
+	// TCPTransport connects to peers with TLS over TCP.
+	TCPTransport = "tcp"
+	// QUICTransport connects to peers with QUIC. Messages of different lanes
+	// are sent on independent streams, so large bootstrapping messages don't
+	// delay consensus messages. Peers that don't accept QUIC connections are
+	// dialed over TCP, and TCP connections are still accepted, so nodes using
+	// either transport can connect to each other.
+	QUICTransport = "quic"
+)
+
 // HealthConfig describes parameters for network layer health checks.
 type HealthConfig struct {
 	// MinConnectedPeers is the minimum number of peers that the network should
@@ -99,16 +113,37 @@ type ThrottlerConfig struct {
 	MaxInboundConnsPerSec             float64                                      `json:"maxInboundConnsPerSec"`
 }
 
+type ReputationConfig struct {
+	// PenaltyHalflife is the amount of time after which the penalty of a peer
+	// is halved. Should be > 0.
+	PenaltyHalflife time.Duration `json:"penaltyHalflife"`
+
+	// SampleThreshold is the penalty at which peers are only sampled for
+	// gossip if there aren't enough peers with a lower penalty. If 0, peers
+	// are sampled regardless of their penalty.
+	SampleThreshold float64 `json:"sampleThreshold"`
+
+	// DisconnectThreshold is the penalty at which this node disconnects from
+	// a peer and refuses its connections. If 0, peers are never disconnected
+	// because of their penalty.
+	DisconnectThreshold float64 `json:"disconnectThreshold"`
+}
+
 type Config struct {
 	HealthConfig         `json:"healthConfig"`
 	PeerListGossipConfig `json:"peerListGossipConfig"`
 	TimeoutConfig        `json:"timeoutConfigs"`
 	DelayConfig          `json:"delayConfig"`
-	ThrottlerConfig      ThrottlerConfig `json:"throttlerConfig"`
+	ThrottlerConfig      ThrottlerConfig  `json:"throttlerConfig"`
+	ReputationConfig     ReputationConfig `json:"reputationConfig"`
 
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
+	// Transport is the protocol used to connect to peers. Either
+	// [TCPTransport] or [QUICTransport].
+	Transport string `json:"transport"`
+
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
 	Namespace          string            `json:"namespace"`
@@ -119,9 +154,14 @@ type Config struct {
 	PingFrequency      time.Duration     `json:"pingFrequency"`
 	AllowPrivateIPs    bool              `json:"allowPrivateIPs"`
 
-	// CompressionEnabled will compress available outbound messages when set to
-	// true.
-	CompressionEnabled bool `json:"compressionEnabled"`
+	// MyIPv6Port is the IPv6 IP of dual-stack nodes, which is advertised in
+	// addition to [MyIPPort]. Nil if this node only has one IP.
+	MyIPv6Port ips.DynamicIPPort `json:"myIPv6,omitempty"`
+
+	// CompressionType is the compression algorithm used to compress available
+	// outbound messages. Messages are recompressed with gzip for peers that
+	// don't support the configured type.
+	CompressionType compression.Type `json:"compressionType"`
 
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
@@ -149,6 +189,18 @@ type Config struct {
 	// the network negatively.
 	RequireValidatorToConnect bool `json:"requireValidatorToConnect"`
 
+	// AllowedNodeIDs, if non-empty, are the only nodes this node will connect
+	// to. These nodes are connected to even if they aren't validators. This
+	// allows running a validator that is only reachable through its sentries.
+	AllowedNodeIDs set.Set[ids.NodeID] `json:"allowedNodeIDs"`
+
+	// DeniedNodeIDs are nodes this node will never connect to.
+	DeniedNodeIDs set.Set[ids.NodeID] `json:"deniedNodeIDs"`
+
+	// PrivateNodeIDs are nodes whose IPs will never be gossiped to peers. A
+	// sentry should mark the validator it protects as private.
+	PrivateNodeIDs set.Set[ids.NodeID] `json:"privateNodeIDs"`
+
 	// MaximumInboundMessageTimeout is the maximum deadline duration in a
 	// message. Messages sent by clients setting values higher than this value
 	// will be reset to this value.
@@ -175,4 +227,45 @@ type Config struct {
 
 	// Tracks which validators have been sent to which peers
 	GossipTracker peer.GossipTracker `json:"-"`
+
+	// Registers failures of peers that send messages that decompress to more
+	// than the maximum message size.
+	Benchlist benchlist.Manager `json:"-"`
+
+	// Scores the behavior of peers. Peers with a high penalty are sampled
+	// less and are eventually disconnected.
+	Reputation peer.Reputation `json:"-"`
+
+	// Rate-limits the messages received for the chains of a subnet to the
+	// subnet's bandwidth budget.
+	SubnetThrottler throttling.SubnetBandwidthThrottler `json:"-"`
+
+	// OutboundPriorityQueueEnabled queues outbound messages to each peer in
+	// separate lanes by message type, rather than in a single FIFO, so that
+	// large messages don't delay latency-critical consensus messages.
+	OutboundPriorityQueueEnabled bool `json:"outboundPriorityQueueEnabled"`
+
+	// AddressBookDB persists the signed IPs of peers across restarts. If nil,
+	// peer IPs aren't persisted.
+	AddressBookDB database.Database `json:"-"`
+
+	// AddressBookMaxAge is the maximum amount of time a persisted peer IP is
+	// kept without being learned again or confirmed by a connection.
+	AddressBookMaxAge time.Duration `json:"addressBookMaxAge"`
+
+	// BansDB persists the nodes banned by the operator across restarts. If
+	// nil, bans are only kept in memory.
+	BansDB database.Database `json:"-"`
+
+	// CaptureDir is the directory every message exchanged with peers is
+	// captured to. If empty, messages aren't captured.
+	CaptureDir string `json:"captureDir"`
+
+	// CaptureMaxFileSize is the size, in megabytes, a capture file can grow to
+	// before it is rotated.
+	CaptureMaxFileSize int `json:"captureMaxFileSize"`
+
+	// CaptureMaxFiles is the maximum number of rotated capture files that are
+	// kept. If 0, all rotated capture files are kept.
+	CaptureMaxFiles int `json:"captureMaxFiles"`
 }
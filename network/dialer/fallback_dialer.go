@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dialer
+
+import (
+	"context"
+	"net"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/cache"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+// fallbackCacheSize is the number of peers that are remembered to only accept
+// connections from the fallback dialer.
+const fallbackCacheSize = 1024
+
+var _ Dialer = (*fallbackDialer)(nil)
+
+type fallbackDialer struct {
+	primary  Dialer
+	fallback Dialer
+	log      logging.Logger
+
+	// Peers that were only reachable by [fallback]. They are dialed with
+	// [fallback] directly until they are evicted.
+	fallbackOnly cache.Cacher[string, struct{}]
+}
+
+// NewFallbackDialer returns a new Dialer that dials with [primary] and, if that
+// fails, with [fallback]. This allows a node to prefer a transport that not
+// all of its peers support, such as QUIC, while still reaching every peer.
+func NewFallbackDialer(primary, fallback Dialer, log logging.Logger) Dialer {
+	return &fallbackDialer{
+		primary:      primary,
+		fallback:     fallback,
+		log:          log,
+		fallbackOnly: cache.NewLRU[string, struct{}](fallbackCacheSize),
+	}
+}
+
+func (d *fallbackDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	key := ip.String()
+	if _, ok := d.fallbackOnly.Get(key); ok {
+		return d.fallback.Dial(ctx, ip)
+	}
+
+	conn, err := d.primary.Dial(ctx, ip)
+	if err == nil || ctx.Err() != nil {
+		return conn, err
+	}
+
+	d.log.Verbo("dialing with fallback",
+		zap.Stringer("ip", ip),
+		zap.Error(err),
+	)
+	conn, err = d.fallback.Dial(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+	d.fallbackOnly.Put(key, struct{}{})
+	return conn, nil
+}
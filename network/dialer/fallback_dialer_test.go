@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dialer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+var errDialFailed = errors.New("dial failed")
+
+type testDialer struct {
+	err   error
+	dials int
+}
+
+func (d *testDialer) Dial(context.Context, ips.IPPort) (net.Conn, error) {
+	d.dials++
+	if d.err != nil {
+		return nil, d.err
+	}
+	client, server := net.Pipe()
+	_ = server.Close()
+	return client, nil
+}
+
+func TestFallbackDialer(t *testing.T) {
+	require := require.New(t)
+
+	ip := ips.IPPort{
+		IP:   net.IPv4(127, 0, 0, 1),
+		Port: 9651,
+	}
+
+	primary := &testDialer{}
+	fallback := &testDialer{}
+	d := NewFallbackDialer(primary, fallback, logging.NoLog{})
+
+	// A peer that accepts the primary transport is never dialed with the
+	// fallback.
+	_, err := d.Dial(context.Background(), ip)
+	require.NoError(err)
+	require.Equal(1, primary.dials)
+	require.Zero(fallback.dials)
+
+	// A peer that only accepts the fallback transport is dialed with both.
+	primary.err = errDialFailed
+	_, err = d.Dial(context.Background(), ip)
+	require.NoError(err)
+	require.Equal(2, primary.dials)
+	require.Equal(1, fallback.dials)
+
+	// Afterwards, it is only dialed with the fallback.
+	_, err = d.Dial(context.Background(), ip)
+	require.NoError(err)
+	require.Equal(2, primary.dials)
+	require.Equal(2, fallback.dials)
+}
+
+func TestFallbackDialerBothFail(t *testing.T) {
+	require := require.New(t)
+
+	ip := ips.IPPort{
+		IP:   net.IPv4(127, 0, 0, 1),
+		Port: 9651,
+	}
+
+	primary := &testDialer{err: errDialFailed}
+	fallback := &testDialer{err: errDialFailed}
+	d := NewFallbackDialer(primary, fallback, logging.NoLog{})
+
+	_, err := d.Dial(context.Background(), ip)
+	require.ErrorIs(err, errDialFailed)
+
+	// The peer isn't remembered, so the primary is tried again.
+	_, err = d.Dial(context.Background(), ip)
+	require.ErrorIs(err, errDialFailed)
+	require.Equal(2, primary.dials)
+	require.Equal(2, fallback.dials)
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dialer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/network/quic"
+	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+var _ Dialer = (*quicDialer)(nil)
+
+type quicDialer struct {
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	log       logging.Logger
+	throttler throttling.DialThrottler
+}
+
+// NewQUICDialer returns a new Dialer that establishes QUIC connections. The
+// TLS handshake is performed with [tlsConfig] while dialing, so the returned
+// connections are already authenticated.
+// [dialerConfig.connectionTimeout] gives the timeout when dialing an IP.
+// [dialerConfig.throttleRps] gives the max number of outgoing connection attempts/second.
+// If [dialerConfig.throttleRps] == 0, outgoing connections aren't rate-limited.
+func NewQUICDialer(tlsConfig *tls.Config, dialerConfig Config, log logging.Logger) Dialer {
+	log.Debug(
+		"creating dialer",
+		zap.String("network", "quic"),
+		zap.Uint32("throttleRPS", dialerConfig.ThrottleRps),
+		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
+	)
+	return &quicDialer{
+		tlsConfig: tlsConfig,
+		timeout:   dialerConfig.ConnectionTimeout,
+		log:       log,
+		throttler: newDialThrottler(dialerConfig),
+	}
+}
+
+func (d *quicDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	if err := d.throttler.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	d.log.Verbo("dialing",
+		zap.Stringer("ip", ip),
+	)
+	conn, err := quic.Dial(ctx, ip.String(), d.tlsConfig, d.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+	}
+	return conn, nil
+}
@@ -24,10 +24,12 @@ import (
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/network/dialer"
 	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/network/quic"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/sender"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
@@ -53,6 +55,10 @@ var (
 	errNotValidator             = errors.New("node is not a validator")
 	errNotWhiteListed           = errors.New("subnet is not whitelisted")
 	errSubnetNotExist           = errors.New("subnet does not exist")
+	errNodeBanned               = errors.New("node is banned")
+	errNodeNotAllowed           = errors.New("node is not allowed")
+	errNotConnected             = errors.New("not connected to node")
+	errInvalidBanDuration       = errors.New("ban duration must be positive")
 )
 
 // Network defines the functionality of the networking library.
@@ -84,6 +90,21 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
+	// AddPeer attempts to connect to [nodeID] at [ip], as with ManuallyTrack.
+	// Returns an error if [nodeID] is banned.
+	AddPeer(nodeID ids.NodeID, ip ips.IPPort) error
+
+	// DisconnectPeer closes the connection to [nodeID] and stops manually
+	// tracking it. Validators are reconnected to, unless they are banned.
+	DisconnectPeer(nodeID ids.NodeID) error
+
+	// Ban disconnects from [nodeID] and refuses connections to it for
+	// [duration].
+	Ban(nodeID ids.NodeID, duration time.Duration) error
+
+	// Bans returns the nodes that are currently banned.
+	Bans() []Ban
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
@@ -92,6 +113,14 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// UpdateConfig applies the settings of [config] that can change while the
+	// network is running: the health thresholds, the number of peers and
+	// validator IPs of each peer list gossip, the inbound and outbound
+	// message throttler limits, the inbound connection upgrade cooldown and
+	// the subnets added to the tracked subnets. The other settings are
+	// ignored.
+	UpdateConfig(config *Config)
 }
 
 type UptimeResult struct {
@@ -117,6 +146,10 @@ type network struct {
 
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
+	// Lanes of the priority outbound message queues. Nil if outbound messages
+	// are queued in a single FIFO per peer.
+	lanes map[peer.Lane]*peer.LaneConfig
+
 	// Limits the number of connection attempts based on IP.
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
 	// Listens for and accepts new inbound connections
@@ -127,6 +160,9 @@ type network struct {
 	serverUpgrader peer.Upgrader
 	// Does TLS handshakes for outbound connections
 	clientUpgrader peer.Upgrader
+	// Upgrades QUIC connections, inbound or outbound, which are authenticated
+	// while they are established
+	quicUpgrader peer.Upgrader
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -137,6 +173,16 @@ type network struct {
 
 	sendFailRateCalculator math.Averager
 
+	// Persists the signed IPs of peers across restarts. Nil if peer IPs
+	// aren't persisted.
+	addressBook *addressBook
+
+	// Nodes that this node refuses to connect to.
+	bans *banList
+
+	// Guards the settings of [config] that can be changed by [UpdateConfig].
+	configLock sync.RWMutex
+
 	// Tracks which peers know about which peers
 	gossipTracker peer.GossipTracker
 	peersLock     sync.RWMutex
@@ -206,6 +252,14 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing outbound message throttler failed with: %w", err)
 	}
 
+	var lanes map[peer.Lane]*peer.LaneConfig
+	if config.OutboundPriorityQueueEnabled {
+		lanes, err = newLanes(log, config, metricsRegisterer, primaryNetworkValidators)
+		if err != nil {
+			return nil, fmt.Errorf("initializing outbound message queue lanes failed with: %w", err)
+		}
+	}
+
 	peerMetrics, err := peer.NewMetrics(log, config.Namespace, metricsRegisterer)
 	if err != nil {
 		return nil, fmt.Errorf("initializing peer metrics failed with: %w", err)
@@ -224,20 +278,34 @@ func NewNetwork(
 
 		Log:                  log,
 		InboundMsgThrottler:  inboundMsgThrottler,
+		SubnetThrottler:      config.SubnetThrottler,
 		Network:              nil, // This is set below.
 		Router:               router,
 		VersionCompatibility: version.GetCompatibility(config.NetworkID),
-		MySubnets:            config.WhitelistedSubnets,
+		MySubnets:            utils.NewAtomicInterface(config.WhitelistedSubnets),
 		Beacons:              config.Beacons,
 		NetworkID:            config.NetworkID,
 		PingFrequency:        config.PingFrequency,
 		PongTimeout:          config.PingPongTimeout,
 		MaxClockDifference:   config.MaxClockDifference,
 		ResourceTracker:      config.ResourceTracker,
+		Benchlist:            config.Benchlist,
+		Reputation:           config.Reputation,
 		GossipTracker:        config.GossipTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
 	}
+	if config.MyIPv6Port != nil {
+		peerConfig.IPSigner = peer.NewDualStackIPSigner(config.MyIPPort, config.MyIPv6Port, config.TLSKey)
+	}
+	if config.CaptureDir != "" {
+		peerConfig.Recorder = peer.NewFileRecorder(log, config.CaptureDir, config.CaptureMaxFileSize, config.CaptureMaxFiles)
+	}
+
+	bans, err := newBanList(config.BansDB, peerConfig.Clock.Time())
+	if err != nil {
+		return nil, fmt.Errorf("initializing ban list failed with: %w", err)
+	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
@@ -245,12 +313,14 @@ func NewNetwork(
 		peerConfig:           peerConfig,
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
+		lanes:                lanes,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
 		dialer:                      dialer,
 		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig),
 		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig),
+		quicUpgrader:                peer.NewAuthenticatedUpgrader(),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -261,16 +331,49 @@ func NewNetwork(
 			time.Now(),
 		)),
 
+		bans:            bans,
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
 		gossipTracker:   config.GossipTracker,
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
 		router:          router,
 	}
+	if config.AddressBookDB != nil {
+		n.addressBook = newAddressBook(config.AddressBookDB, config.AddressBookMaxAge)
+	}
 	n.peerConfig.Network = n
 	return n, nil
 }
 
+// newLanes returns the lanes of the priority outbound message queues. Each lane
+// is given its own portion of the outbound throttler's byte allocations.
+func newLanes(
+	log logging.Logger,
+	config *Config,
+	metricsRegisterer prometheus.Registerer,
+	vdrs validators.Set,
+) (map[peer.Lane]*peer.LaneConfig, error) {
+	lanes := make(map[peer.Lane]*peer.LaneConfig, len(peer.Lanes))
+	errs := wrappers.Errs{}
+	for _, lane := range peer.Lanes {
+		throttler, err := throttling.NewSybilOutboundMsgThrottler(
+			log,
+			fmt.Sprintf("%s_%s_lane", config.Namespace, lane),
+			metricsRegisterer,
+			vdrs,
+			lane.ThrottlerConfig(config.ThrottlerConfig.OutboundMsgThrottlerConfig),
+		)
+		if err != nil {
+			return nil, err
+		}
+		lanes[lane] = &peer.LaneConfig{
+			Throttler: throttler,
+			Metrics:   peer.NewLaneMetrics(lane, config.Namespace, metricsRegisterer, &errs),
+		}
+	}
+	return lanes, errs.Err
+}
+
 func (n *network) Send(msg message.OutboundMessage, nodeIDs set.Set[ids.NodeID], subnetID ids.ID, validatorOnly bool) set.Set[ids.NodeID] {
 	peers := n.getPeers(nodeIDs, subnetID, validatorOnly)
 	n.peerConfig.Metrics.MultipleSendsFailed(
@@ -302,8 +405,12 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 
 	sendFailRate := n.sendFailRateCalculator.Read()
 
+	n.configLock.RLock()
+	healthConfig := n.config.HealthConfig
+	n.configLock.RUnlock()
+
 	// Make sure we're connected to at least the minimum number of peers
-	isConnected := connectedTo >= int(n.config.HealthConfig.MinConnectedPeers)
+	isConnected := connectedTo >= int(healthConfig.MinConnectedPeers)
 	healthy := isConnected
 	details := map[string]interface{}{
 		ConnectedPeersKey: connectedTo,
@@ -314,7 +421,7 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 
 	lastMsgReceivedAt := time.Unix(atomic.LoadInt64(&n.peerConfig.LastReceived), 0)
 	timeSinceLastMsgReceived := now.Sub(lastMsgReceivedAt)
-	wasMsgReceivedRecently := timeSinceLastMsgReceived <= n.config.HealthConfig.MaxTimeSinceMsgReceived
+	wasMsgReceivedRecently := timeSinceLastMsgReceived <= healthConfig.MaxTimeSinceMsgReceived
 	healthy = healthy && wasMsgReceivedRecently
 	details[TimeSinceLastMsgReceivedKey] = timeSinceLastMsgReceived.String()
 	n.metrics.timeSinceLastMsgReceived.Set(float64(timeSinceLastMsgReceived))
@@ -322,13 +429,13 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	// Make sure we've sent an outgoing message within the threshold
 	lastMsgSentAt := time.Unix(atomic.LoadInt64(&n.peerConfig.LastSent), 0)
 	timeSinceLastMsgSent := now.Sub(lastMsgSentAt)
-	wasMsgSentRecently := timeSinceLastMsgSent <= n.config.HealthConfig.MaxTimeSinceMsgSent
+	wasMsgSentRecently := timeSinceLastMsgSent <= healthConfig.MaxTimeSinceMsgSent
 	healthy = healthy && wasMsgSentRecently
 	details[TimeSinceLastMsgSentKey] = timeSinceLastMsgSent.String()
 	n.metrics.timeSinceLastMsgSent.Set(float64(timeSinceLastMsgSent))
 
 	// Make sure the message send failed rate isn't too high
-	isMsgFailRate := sendFailRate <= n.config.HealthConfig.MaxSendFailRate
+	isMsgFailRate := sendFailRate <= healthConfig.MaxSendFailRate
 	healthy = healthy && isMsgFailRate
 	details[SendFailRateKey] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
@@ -337,16 +444,16 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	if !healthy {
 		var errorReasons []string
 		if !isConnected {
-			errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", n.config.HealthConfig.MinConnectedPeers, connectedTo))
+			errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", healthConfig.MinConnectedPeers, connectedTo))
 		}
 		if !wasMsgReceivedRecently {
-			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, n.config.HealthConfig.MaxTimeSinceMsgReceived))
+			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, healthConfig.MaxTimeSinceMsgReceived))
 		}
 		if !wasMsgSentRecently {
-			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, n.config.HealthConfig.MaxTimeSinceMsgSent))
+			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, healthConfig.MaxTimeSinceMsgSent))
 		}
 		if !isMsgFailRate {
-			errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, n.config.HealthConfig.MaxSendFailRate))
+			errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, healthConfig.MaxSendFailRate))
 		}
 
 		return details, fmt.Errorf("network layer is unhealthy reason: %s", strings.Join(errorReasons, ", "))
@@ -378,6 +485,7 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	n.peersLock.Unlock()
 
 	n.metrics.markConnected(peer)
+	n.persistIP(peer)
 
 	peerVersion := peer.Version()
 	n.router.Connected(nodeID, peerVersion, constants.PrimaryNetworkID)
@@ -389,14 +497,42 @@ func (n *network) Connected(nodeID ids.NodeID) {
 // AllowConnection returns true if this node should have a connection to the
 // provided nodeID. If the node is attempting to connect to the minimum number
 // of peers, then it should only connect if this node is a validator, or the
-// peer is a validator/beacon.
+// peer is a validator/beacon. Banned, denied, and, if an allowlist is
+// configured, unlisted nodes are never allowed. Neither are nodes that
+// misbehaved too much.
+//
+// Peers periodically check that their connection is still allowed, so peers
+// that keep misbehaving are eventually disconnected.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
+	if !n.isAllowed(nodeID) || !n.isReputable(nodeID) {
+		return false
+	}
 	return !n.config.RequireValidatorToConnect ||
 		validators.Contains(n.config.Validators, constants.PrimaryNetworkID, n.config.MyNodeID) ||
 		n.WantsConnection(nodeID)
 }
 
-func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
+func (n *network) Track(peerID ids.NodeID, claimedIPPort ips.ClaimedIPPort) bool {
+	if !n.track(peerID, claimedIPPort) {
+		return false
+	}
+
+	if n.addressBook != nil {
+		if err := n.addressBook.put(claimedIPPort, n.peerConfig.Clock.Time()); err != nil {
+			n.peerConfig.Log.Warn("failed to persist peer IP",
+				zap.Stringer("nodeID", ids.NodeIDFromCert(claimedIPPort.Cert)),
+				zap.Error(err),
+			)
+		}
+	}
+	return true
+}
+
+// track attempts to connect to the peer at [claimedIPPort] if the IP is valid
+// and the peer is desired. Returns true if the IP is now being tracked.
+// [peerID] is penalized if it gossiped an invalid IP. It's empty if the IP
+// wasn't gossiped.
+func (n *network) track(peerID ids.NodeID, claimedIPPort ips.ClaimedIPPort) bool {
 	nodeID := ids.NodeIDFromCert(claimedIPPort.Cert)
 
 	// Verify that we do want to attempt to make a connection to this peer
@@ -421,6 +557,9 @@ func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
 			zap.Stringer("nodeID", nodeID),
 			zap.Error(err),
 		)
+		if peerID != ids.EmptyNodeID {
+			n.config.Reputation.Penalize(peerID, peer.InvalidSignatureOffense)
+		}
 		return false
 	}
 
@@ -434,25 +573,28 @@ func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
 		return false
 	}
 
+	unsignedIP := &peer.UnsignedIP{
+		IP:        claimedIPPort.IPPort,
+		Timestamp: claimedIPPort.Timestamp,
+	}
 	tracked, isTracked := n.trackedIPs[nodeID]
 	switch {
+	case isTracked && tracked.isAlternateIP(unsignedIP):
+		// Dual-stack peers claim an IP of each version at the same time. The
+		// dialing goroutine will also attempt to connect to this IP.
+		tracked.setAlternateIP(unsignedIP)
+		return true
 	case isTracked:
 		if tracked.ip.Timestamp >= claimedIPPort.Timestamp {
 			return false
 		}
 		// Stop tracking the old IP and instead start tracking new one.
-		tracked := tracked.trackNewIP(&peer.UnsignedIP{
-			IP:        claimedIPPort.IPPort,
-			Timestamp: claimedIPPort.Timestamp,
-		})
+		tracked := tracked.trackNewIP(unsignedIP)
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 		return true
 	case n.wantsConnection(nodeID):
-		tracked := newTrackedIP(&peer.UnsignedIP{
-			IP:        claimedIPPort.IPPort,
-			Timestamp: claimedIPPort.Timestamp,
-		})
+		tracked := newTrackedIP(unsignedIP)
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 		return true
@@ -499,15 +641,24 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 		return nil, err
 	}
 
+	n.configLock.RLock()
+	numValidatorIPs := int(n.config.PeerListNumValidatorIPs)
+	n.configLock.RUnlock()
+
 	// Calculate the unknown information we need to send to this peer.
-	validatorIPs := make([]ips.ClaimedIPPort, 0, int(n.config.PeerListNumValidatorIPs))
-	for i := 0; i < len(unknownValidators) && len(validatorIPs) < int(n.config.PeerListNumValidatorIPs); i++ {
+	validatorIPs := make([]ips.ClaimedIPPort, 0, numValidatorIPs)
+	for i := 0; i < len(unknownValidators) && len(validatorIPs) < numValidatorIPs; i++ {
 		drawn, err := s.Next()
 		if err != nil {
 			return nil, err
 		}
 
 		validator := unknownValidators[drawn]
+		if n.config.PrivateNodeIDs.Contains(validator.NodeID) {
+			// The IPs of private nodes must never be gossiped.
+			continue
+		}
+
 		n.peersLock.RLock()
 		p, ok := n.connectedPeers.GetByID(validator.NodeID)
 		n.peersLock.RUnlock()
@@ -529,6 +680,20 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 				TxID:      validator.TxID,
 			},
 		)
+		// Both IPs of dual-stack validators are gossiped. Because they are
+		// signed with the same timestamp, nodes that don't support dual-stack
+		// peers keep tracking the first one.
+		if peerIPv6 := p.IPv6(); peerIPv6 != nil {
+			validatorIPs = append(validatorIPs,
+				ips.ClaimedIPPort{
+					Cert:      p.Cert(),
+					IPPort:    peerIPv6.IP.IP,
+					Timestamp: peerIPv6.IP.Timestamp,
+					Signature: peerIPv6.Signature,
+					TxID:      validator.TxID,
+				},
+			)
+		}
 	}
 
 	return validatorIPs, nil
@@ -537,8 +702,8 @@ func (n *network) Peers(peerID ids.NodeID) ([]ips.ClaimedIPPort, error) {
 // Dispatch starts accepting connections from other nodes attempting to connect
 // to this node.
 func (n *network) Dispatch() error {
+	n.loadAddressBook()
 	go n.runTimers() // Periodically perform operations
-	go n.inboundConnUpgradeThrottler.Dispatch()
 	errs := wrappers.Errs{}
 	for { // Continuously accept new connections
 		if n.onCloseCtx.Err() != nil {
@@ -588,7 +753,6 @@ func (n *network) Dispatch() error {
 			}
 		}()
 	}
-	n.inboundConnUpgradeThrottler.Stop()
 	n.StartClose()
 
 	n.peersLock.RLock()
@@ -599,6 +763,9 @@ func (n *network) Dispatch() error {
 	for _, peer := range append(connecting, connected...) {
 		errs.Add(peer.AwaitClosed(context.TODO()))
 	}
+	if n.peerConfig.Recorder != nil {
+		errs.Add(n.peerConfig.Recorder.Close())
+	}
 	return errs.Err
 }
 
@@ -610,7 +777,11 @@ func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 }
 
 func (n *network) wantsConnection(nodeID ids.NodeID) bool {
-	return validators.Contains(n.config.Validators, constants.PrimaryNetworkID, nodeID) ||
+	if !n.isAllowed(nodeID) || !n.isReputable(nodeID) {
+		return false
+	}
+	return n.config.AllowedNodeIDs.Contains(nodeID) ||
+		validators.Contains(n.config.Validators, constants.PrimaryNetworkID, nodeID) ||
 		n.manuallyTrackedIDs.Contains(nodeID)
 }
 
@@ -639,6 +810,95 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	}
 }
 
+func (n *network) AddPeer(nodeID ids.NodeID, ip ips.IPPort) error {
+	if n.isBanned(nodeID) {
+		return fmt.Errorf("%w: %s", errNodeBanned, nodeID)
+	}
+	if !n.isAllowed(nodeID) {
+		return fmt.Errorf("%w: %s", errNodeNotAllowed, nodeID)
+	}
+	n.ManuallyTrack(nodeID, ip)
+	return nil
+}
+
+func (n *network) DisconnectPeer(nodeID ids.NodeID) error {
+	n.peersLock.Lock()
+	n.manuallyTrackedIDs.Remove(nodeID)
+	peer, connected := n.connectedPeers.GetByID(nodeID)
+	if !connected {
+		peer, connected = n.connectingPeers.GetByID(nodeID)
+	}
+	n.peersLock.Unlock()
+
+	if !connected {
+		return fmt.Errorf("%w: %s", errNotConnected, nodeID)
+	}
+	peer.StartClose()
+	return nil
+}
+
+func (n *network) Ban(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return errInvalidBanDuration
+	}
+
+	until := n.peerConfig.Clock.Time().Add(duration)
+	if err := n.bans.ban(nodeID, until); err != nil {
+		return err
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	n.manuallyTrackedIDs.Remove(nodeID)
+	if tracked, ok := n.trackedIPs[nodeID]; ok {
+		tracked.stopTracking()
+		delete(n.trackedIPs, nodeID)
+	}
+	if peer, ok := n.connectingPeers.GetByID(nodeID); ok {
+		peer.StartClose()
+	}
+	if peer, ok := n.connectedPeers.GetByID(nodeID); ok {
+		peer.StartClose()
+	}
+	return nil
+}
+
+func (n *network) Bans() []Ban {
+	return n.bans.list(n.peerConfig.Clock.Time())
+}
+
+func (n *network) isBanned(nodeID ids.NodeID) bool {
+	return n.bans.isBanned(nodeID, n.peerConfig.Clock.Time())
+}
+
+// isAllowed returns false if connections with [nodeID] are forbidden by the
+// operator, either because [nodeID] is banned or denied, or because an
+// allowlist is configured and doesn't contain [nodeID].
+func (n *network) isAllowed(nodeID ids.NodeID) bool {
+	if n.config.DeniedNodeIDs.Contains(nodeID) || n.isBanned(nodeID) {
+		return false
+	}
+	return n.config.AllowedNodeIDs.Len() == 0 || n.config.AllowedNodeIDs.Contains(nodeID)
+}
+
+// isReputable returns false if [nodeID] misbehaved so much that this node
+// shouldn't be connected to it. Nodes in the allowlist are always reputable, as
+// the operator chose to connect to them.
+func (n *network) isReputable(nodeID ids.NodeID) bool {
+	threshold := n.config.ReputationConfig.DisconnectThreshold
+	return threshold <= 0 ||
+		n.config.AllowedNodeIDs.Contains(nodeID) ||
+		n.config.Reputation.Penalty(nodeID) < threshold
+}
+
+// isWellBehaved returns true if [nodeID] should be preferred when sampling
+// peers.
+func (n *network) isWellBehaved(nodeID ids.NodeID) bool {
+	threshold := n.config.ReputationConfig.SampleThreshold
+	return threshold <= 0 || n.config.Reputation.Penalty(nodeID) < threshold
+}
+
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
 //
 // - [nodeIDs] the IDs of the peers that should be returned if they are
@@ -694,29 +954,45 @@ func (n *network) samplePeers(
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
-	return n.connectedPeers.Sample(
-		numValidatorsToSample+numNonValidatorsToSample+numPeersToSample,
-		func(p peer.Peer) bool {
-			// Only return peers that are tracking [subnetID]
-			trackedSubnets := p.TrackedSubnets()
-			if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
-				return false
-			}
+	sample := func(wellBehaved bool) []peer.Peer {
+		return n.connectedPeers.Sample(
+			numValidatorsToSample+numNonValidatorsToSample+numPeersToSample,
+			func(p peer.Peer) bool {
+				// Only return peers that are tracking [subnetID]
+				trackedSubnets := p.TrackedSubnets()
+				if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
+					return false
+				}
 
-			if numPeersToSample > 0 {
-				numPeersToSample--
-				return true
-			}
+				if n.isWellBehaved(p.ID()) != wellBehaved {
+					return false
+				}
 
-			if validators.Contains(n.config.Validators, subnetID, p.ID()) {
-				numValidatorsToSample--
-				return numValidatorsToSample >= 0
-			}
+				if numPeersToSample > 0 {
+					numPeersToSample--
+					return true
+				}
 
-			numNonValidatorsToSample--
-			return numNonValidatorsToSample >= 0
-		},
-	)
+				if validators.Contains(n.config.Validators, subnetID, p.ID()) {
+					numValidatorsToSample--
+					return numValidatorsToSample >= 0
+				}
+
+				numNonValidatorsToSample--
+				return numNonValidatorsToSample >= 0
+			},
+		)
+	}
+
+	// Peers that misbehaved are only sampled if there aren't enough
+	// well-behaved peers.
+	peers := sample(true)
+	if n.config.ReputationConfig.SampleThreshold <= 0 {
+		return peers
+	}
+	numValidatorsToSample = math.Max(numValidatorsToSample, 0)
+	numNonValidatorsToSample = math.Max(numNonValidatorsToSample, 0)
+	return append(peers, sample(false)...)
 }
 
 // send the message to the provided peers.
@@ -754,7 +1030,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 	tracked, ok := n.trackedIPs[nodeID]
 	if ok {
 		if n.wantsConnection(nodeID) {
-			tracked := tracked.trackNewIP(tracked.ip)
+			tracked := tracked.retrack()
 			n.trackedIPs[nodeID] = tracked
 			n.dial(n.onCloseCtx, nodeID, tracked)
 		} else {
@@ -777,6 +1053,9 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	// The peer that is disconnecting from us finished the handshake
 	if n.wantsConnection(nodeID) {
 		tracked := newTrackedIP(&peer.IP().IP)
+		if peerIPv6 := peer.IPv6(); peerIPv6 != nil {
+			tracked.setAlternateIP(&peerIPv6.IP)
+		}
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 	} else {
@@ -787,6 +1066,15 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 }
 
 func (n *network) shouldTrack(nodeID ids.NodeID, ip ips.ClaimedIPPort) bool {
+	if !n.isAllowed(nodeID) {
+		n.peerConfig.Log.Verbo(
+			"not connecting to suggested peer",
+			zap.String("reason", "peer is not allowed"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return false
+	}
+
 	if !n.config.AllowPrivateIPs && ip.IPPort.IP.IsPrivate() {
 		n.peerConfig.Log.Verbo(
 			"not connecting to suggested peer",
@@ -810,7 +1098,10 @@ func (n *network) shouldTrack(nodeID ids.NodeID, ip ips.ClaimedIPPort) bool {
 
 	tracked, isTracked := n.trackedIPs[nodeID]
 	if isTracked {
-		return tracked.ip.Timestamp < ip.Timestamp
+		return tracked.ip.Timestamp < ip.Timestamp || tracked.isAlternateIP(&peer.UnsignedIP{
+			IP:        ip.IPPort,
+			Timestamp: ip.Timestamp,
+		})
 	}
 	return n.wantsConnection(nodeID)
 }
@@ -887,32 +1178,45 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				n.config.MaxReconnectDelay,
 			)
 
-			conn, err := n.dialer.Dial(ctx, ip.ip.IP)
-			if err != nil {
-				n.peerConfig.Log.Verbo(
-					"failed to reach peer, attempting again",
-					zap.Stringer("peerIP", ip.ip.IP),
-					zap.Duration("delay", ip.delay),
-				)
-				continue
-			}
+			// Dual-stack peers can be reached at either of their IPs. The
+			// IP of the version this node prefers is attempted first.
+			for _, peerIP := range ip.dialOrder(n.prefersIPv6()) {
+				conn, err := n.dialer.Dial(ctx, peerIP)
+				if err != nil {
+					n.peerConfig.Log.Verbo(
+						"failed to reach peer, attempting again",
+						zap.Stringer("peerIP", peerIP),
+						zap.Duration("delay", ip.getDelay()),
+					)
+					continue
+				}
 
-			err = n.upgrade(conn, n.clientUpgrader)
-			if err != nil {
-				n.peerConfig.Log.Verbo(
-					"failed to upgrade, attempting again",
-					zap.Stringer("peerIP", ip.ip.IP),
-					zap.Duration("delay", ip.delay),
-				)
-				continue
+				err = n.upgrade(conn, n.clientUpgrader)
+				if err != nil {
+					n.peerConfig.Log.Verbo(
+						"failed to upgrade, attempting again",
+						zap.Stringer("peerIP", peerIP),
+						zap.Duration("delay", ip.getDelay()),
+					)
+					continue
+				}
+				return
 			}
-			return
 		}
 	}()
 }
 
+// prefersIPv6 returns true if this node should attempt to connect to the IPv6
+// IPs of dual-stack peers before their IPv4 IPs. That is the case if this node
+// has an IPv6 IP.
+func (n *network) prefersIPv6() bool {
+	return n.config.MyIPv6Port != nil || n.config.MyIPPort.IPPort().IsIPv6()
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
-// outbound connection, with the provided [upgrader].
+// outbound connection, with the provided [upgrader]. QUIC connections are
+// always upgraded with [n.quicUpgrader], as their TLS handshake was already
+// performed.
 //
 // If the connection is successfully upgraded, [nil] will be returned.
 //
@@ -920,6 +1224,10 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
 func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+	if _, ok := conn.(*quic.Conn); ok {
+		upgrader = n.quicUpgrader
+	}
+
 	upgradeTimeout := n.peerConfig.Clock.Time().Add(n.config.ReadHandshakeTimeout)
 	if err := conn.SetReadDeadline(upgradeTimeout); err != nil {
 		_ = conn.Close()
@@ -1004,17 +1312,28 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 	// peer.Start requires there is only ever one peer instance running with the
 	// same [peerConfig.InboundMsgThrottler]. This is guaranteed by the above
 	// de-duplications for [connectingPeers] and [connectedPeers].
+	var messageQueue peer.MessageQueue
+	if n.lanes != nil {
+		messageQueue = peer.NewPriorityMessageQueue(
+			n.peerConfig.Metrics,
+			nodeID,
+			n.peerConfig.Log,
+			n.lanes,
+		)
+	} else {
+		messageQueue = peer.NewThrottledMessageQueue(
+			n.peerConfig.Metrics,
+			nodeID,
+			n.peerConfig.Log,
+			n.outboundMsgThrottler,
+		)
+	}
 	peer := peer.Start(
 		n.peerConfig,
 		tlsConn,
 		cert,
 		nodeID,
-		peer.NewThrottledMessageQueue(
-			n.peerConfig.Metrics,
-			nodeID,
-			n.peerConfig.Log,
-			n.outboundMsgThrottler,
-		),
+		messageQueue,
 	)
 	n.connectingPeers.Add(peer)
 	return nil
@@ -1034,6 +1353,10 @@ func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
 
+		// Refresh the persisted IPs of our peers so that we can reconnect to
+		// them after restarting.
+		n.persistConnectedIPs()
+
 		if err := n.listener.Close(); err != nil {
 			n.peerConfig.Log.Debug("closing the network listener",
 				zap.Error(err),
@@ -1064,7 +1387,11 @@ func (n *network) StartClose() {
 }
 
 func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
-	if subnetID != constants.PrimaryNetworkID && !n.config.WhitelistedSubnets.Contains(subnetID) {
+	n.configLock.RLock()
+	whitelistedSubnets := n.config.WhitelistedSubnets
+	n.configLock.RUnlock()
+
+	if subnetID != constants.PrimaryNetworkID && !whitelistedSubnets.Contains(subnetID) {
 		return UptimeResult{}, errNotWhiteListed
 	}
 
@@ -1118,12 +1445,90 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 	}, nil
 }
 
+func (n *network) UpdateConfig(config *Config) {
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+
+	// The send fail rate averager can't change its halflife.
+	n.config.HealthConfig.MinConnectedPeers = config.HealthConfig.MinConnectedPeers
+	n.config.HealthConfig.MaxTimeSinceMsgReceived = config.HealthConfig.MaxTimeSinceMsgReceived
+	n.config.HealthConfig.MaxTimeSinceMsgSent = config.HealthConfig.MaxTimeSinceMsgSent
+	n.config.HealthConfig.MaxSendFailRate = config.HealthConfig.MaxSendFailRate
+
+	// The peer lists are gossiped on a ticker, so their frequency can't change.
+	n.config.PeerListNumValidatorIPs = config.PeerListNumValidatorIPs
+	n.config.PeerListValidatorGossipSize = config.PeerListValidatorGossipSize
+	n.config.PeerListNonValidatorGossipSize = config.PeerListNonValidatorGossipSize
+	n.config.PeerListPeersGossipSize = config.PeerListPeersGossipSize
+
+	n.config.ThrottlerConfig.InboundMsgThrottlerConfig.MsgByteThrottlerConfig = config.ThrottlerConfig.InboundMsgThrottlerConfig.MsgByteThrottlerConfig
+	n.config.ThrottlerConfig.InboundMsgThrottlerConfig.MaxProcessingMsgsPerNode = config.ThrottlerConfig.InboundMsgThrottlerConfig.MaxProcessingMsgsPerNode
+	n.config.ThrottlerConfig.InboundMsgThrottlerConfig.BandwidthThrottlerConfig = config.ThrottlerConfig.InboundMsgThrottlerConfig.BandwidthThrottlerConfig
+	n.peerConfig.InboundMsgThrottler.UpdateConfig(n.config.ThrottlerConfig.InboundMsgThrottlerConfig)
+
+	n.config.ThrottlerConfig.OutboundMsgThrottlerConfig = config.ThrottlerConfig.OutboundMsgThrottlerConfig
+	n.outboundMsgThrottler.SetConfig(n.config.ThrottlerConfig.OutboundMsgThrottlerConfig)
+	for lane, laneConfig := range n.lanes {
+		laneConfig.Throttler.SetConfig(lane.ThrottlerConfig(n.config.ThrottlerConfig.OutboundMsgThrottlerConfig))
+	}
+
+	// The rate of inbound connections is limited by the listener, so it can't
+	// change.
+	n.config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig = config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig
+	n.inboundConnUpgradeThrottler.SetConfig(n.config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig)
+
+	n.trackSubnets(config.WhitelistedSubnets)
+}
+
+// trackSubnets adds the subnets of [whitelistedSubnets] that aren't tracked yet
+// to the tracked subnets. Subnets can't stop being tracked.
+// Peers only learn about the subnets this node tracks in the handshake, so the
+// connected validators of the new subnets are disconnected to handshake again.
+// Other peers learn about the new subnets the next time they connect.
+// Assumes [n.configLock] is held.
+func (n *network) trackSubnets(whitelistedSubnets set.Set[ids.ID]) {
+	newSubnets := set.NewSet[ids.ID](whitelistedSubnets.Len())
+	newSubnets.Union(whitelistedSubnets)
+	newSubnets.Difference(n.config.WhitelistedSubnets)
+	if newSubnets.Len() == 0 {
+		return
+	}
+
+	// The tracked subnets are shared with the peers, so they are replaced
+	// rather than modified.
+	mySubnets := set.NewSet[ids.ID](n.config.WhitelistedSubnets.Len() + newSubnets.Len())
+	mySubnets.Union(n.config.WhitelistedSubnets)
+	mySubnets.Union(newSubnets)
+	n.config.WhitelistedSubnets = mySubnets
+	n.peerConfig.MySubnets.SetValue(mySubnets)
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		peer, _ := n.connectedPeers.GetByIndex(i)
+		nodeID := peer.ID()
+		for subnetID := range newSubnets {
+			if validators.Contains(n.config.Validators, subnetID, nodeID) {
+				n.peerConfig.Log.Debug("disconnecting from peer to share the new tracked subnets",
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("subnetID", subnetID),
+				)
+				peer.StartClose()
+				break
+			}
+		}
+	}
+}
+
 func (n *network) runTimers() {
 	gossipPeerlists := time.NewTicker(n.config.PeerListGossipFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	refreshAddressBook := time.NewTicker(addressBookRefreshFreq)
 	defer func() {
 		gossipPeerlists.Stop()
 		updateUptimes.Stop()
+		refreshAddressBook.Stop()
 	}()
 
 	for {
@@ -1132,6 +1537,8 @@ func (n *network) runTimers() {
 			return
 		case <-gossipPeerlists.C:
 			n.gossipPeerLists()
+		case <-refreshAddressBook.C:
+			n.persistConnectedIPs()
 		case <-updateUptimes.C:
 			primaryUptime, err := n.NodeUptime(constants.PrimaryNetworkID)
 			if err != nil {
@@ -1142,7 +1549,11 @@ func (n *network) runTimers() {
 			n.metrics.nodeUptimeWeightedAverage.Set(primaryUptime.WeightedAveragePercentage)
 			n.metrics.nodeUptimeRewardingStake.Set(primaryUptime.RewardingStakePercentage)
 
-			for subnetID := range n.config.WhitelistedSubnets {
+			n.configLock.RLock()
+			whitelistedSubnets := n.config.WhitelistedSubnets
+			n.configLock.RUnlock()
+
+			for subnetID := range whitelistedSubnets {
 				result, err := n.NodeUptime(subnetID)
 				if err != nil {
 					n.peerConfig.Log.Debug("failed to get subnet uptime",
@@ -1160,15 +1571,90 @@ func (n *network) runTimers() {
 
 // gossipPeerLists gossips validators to peers in the network
 func (n *network) gossipPeerLists() {
+	n.configLock.RLock()
+	gossipConfig := n.config.PeerListGossipConfig
+	n.configLock.RUnlock()
+
 	peers := n.samplePeers(
 		constants.PrimaryNetworkID,
 		false,
-		int(n.config.PeerListValidatorGossipSize),
-		int(n.config.PeerListNonValidatorGossipSize),
-		int(n.config.PeerListPeersGossipSize),
+		int(gossipConfig.PeerListValidatorGossipSize),
+		int(gossipConfig.PeerListNonValidatorGossipSize),
+		int(gossipConfig.PeerListPeersGossipSize),
 	)
 
 	for _, p := range peers {
 		p.StartSendPeerList()
 	}
 }
+
+// loadAddressBook starts tracking the peer IPs that were persisted before the
+// last shutdown.
+func (n *network) loadAddressBook() {
+	if n.addressBook == nil {
+		return
+	}
+
+	claimedIPs, err := n.addressBook.load(n.peerConfig.Clock.Time())
+	if err != nil {
+		n.peerConfig.Log.Warn("failed to load persisted peer IPs",
+			zap.Error(err),
+		)
+		return
+	}
+
+	numTracked := 0
+	for _, claimedIP := range claimedIPs {
+		// The IPs are re-verified, and only tracked if the peer is still
+		// desired.
+		if n.track(ids.EmptyNodeID, claimedIP) {
+			numTracked++
+		}
+	}
+	n.peerConfig.Log.Info("loaded persisted peer IPs",
+		zap.Int("numLoaded", len(claimedIPs)),
+		zap.Int("numTracked", numTracked),
+	)
+}
+
+// persistConnectedIPs refreshes the persisted IPs of all the connected peers
+// that we want to stay connected to.
+func (n *network) persistConnectedIPs() {
+	if n.addressBook == nil {
+		return
+	}
+
+	n.peersLock.RLock()
+	peers := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	for _, p := range peers {
+		n.persistIP(p)
+	}
+}
+
+// persistIP records the signed IP of [p] if we want to reconnect to [p] after
+// restarting.
+func (n *network) persistIP(p peer.Peer) {
+	if n.addressBook == nil || !n.WantsConnection(p.ID()) {
+		return
+	}
+
+	for _, signedIP := range []*peer.SignedIP{p.IP(), p.IPv6()} {
+		if signedIP == nil || signedIP.IP.IP.IsZero() {
+			continue
+		}
+		claimedIPPort := ips.ClaimedIPPort{
+			Cert:      p.Cert(),
+			IPPort:    signedIP.IP.IP,
+			Timestamp: signedIP.IP.Timestamp,
+			Signature: signedIP.Signature,
+		}
+		if err := n.addressBook.put(claimedIPPort, n.peerConfig.Clock.Time()); err != nil {
+			n.peerConfig.Log.Warn("failed to persist peer IP",
+				zap.Stringer("nodeID", p.ID()),
+				zap.Error(err),
+			)
+		}
+	}
+}
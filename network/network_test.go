@@ -15,15 +15,19 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/network/dialer"
 	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/network/quic"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/snow/uptime"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
@@ -108,7 +112,7 @@ var (
 		PingFrequency:      constants.DefaultPingFrequency,
 		AllowPrivateIPs:    true,
 
-		CompressionEnabled: true,
+		CompressionType: compression.TypeGzip,
 
 		UptimeCalculator:  uptime.NewManager(uptime.NewTestState()),
 		UptimeMetricFreq:  30 * time.Second,
@@ -118,6 +122,9 @@ var (
 
 		MaximumInboundMessageTimeout: 30 * time.Second,
 		ResourceTracker:              newDefaultResourceTracker(),
+		Benchlist:                    benchlist.NewNoBenchlist(),
+		Reputation:                   peer.NewNoReputation(),
+		SubnetThrottler:              throttling.NewNoSubnetBandwidthThrottler(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
 	}
@@ -183,7 +190,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -192,9 +199,41 @@ func newMessageCreator(t *testing.T) message.Creator {
 }
 
 func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler) ([]ids.NodeID, []Network, *sync.WaitGroup) {
-	require := require.New(t)
+	return newConfiguredFullyConnectedTestNetwork(t, handlers, func(int, *Config) {})
+}
+
+// newConfiguredFullyConnectedTestNetwork is newFullyConnectedTestNetwork, but
+// calls [configure] with the config of every network before it is created.
+func newConfiguredFullyConnectedTestNetwork(
+	t *testing.T,
+	handlers []router.InboundHandler,
+	configure func(i int, config *Config),
+) ([]ids.NodeID, []Network, *sync.WaitGroup) {
+	testDialer, testListeners, nodeIDs, configs := newTestNetwork(t, len(handlers))
 
-	dialer, listeners, nodeIDs, configs := newTestNetwork(t, len(handlers))
+	listeners := make([]net.Listener, len(testListeners))
+	dialers := make([]dialer.Dialer, len(testListeners))
+	for i, listener := range testListeners {
+		listeners[i] = listener
+		dialers[i] = testDialer
+	}
+	networks, wg := startFullyConnectedTestNetwork(t, handlers, listeners, dialers, nodeIDs, configs, configure)
+	return nodeIDs, networks, wg
+}
+
+// startFullyConnectedTestNetwork creates and dispatches a network for every
+// config, using the provided listeners and dialers, and waits until they are
+// all connected to each other.
+func startFullyConnectedTestNetwork(
+	t *testing.T,
+	handlers []router.InboundHandler,
+	listeners []net.Listener,
+	dialers []dialer.Dialer,
+	nodeIDs []ids.NodeID,
+	configs []*Config,
+	configure func(i int, config *Config),
+) ([]Network, *sync.WaitGroup) {
+	require := require.New(t)
 
 	var (
 		networks = make([]Network, len(configs))
@@ -236,6 +275,7 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 		config.GossipTracker = g
 		config.Beacons = beacons
 		config.Validators = vdrs
+		configure(i, config)
 
 		var connected set.Set[ids.NodeID]
 		net, err := NewNetwork(
@@ -244,7 +284,7 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 			registry,
 			log,
 			listeners[i],
-			dialer,
+			dialers[i],
 			&testHandler{
 				InboundHandler: handlers[i],
 				ConnectedF: func(nodeID ids.NodeID, _ *version.Application, _ ids.ID) {
@@ -298,7 +338,7 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 		<-onAllConnected
 	}
 
-	return nodeIDs, networks, &wg
+	return networks, &wg
 }
 
 func TestNewNetwork(t *testing.T) {
@@ -348,17 +388,189 @@ func TestSend(t *testing.T) {
 	wg.Wait()
 }
 
+func TestQUICTransport(t *testing.T) {
+	require := require.New(t)
+
+	const numNodes = 2
+	var (
+		listeners = make([]net.Listener, numNodes)
+		dialers   = make([]dialer.Dialer, numNodes)
+		nodeIDs   = make([]ids.NodeID, numNodes)
+		configs   = make([]*Config, numNodes)
+	)
+	for i := 0; i < numNodes; i++ {
+		nodeID, tlsCert, tlsConfig := getTLS(t, i)
+
+		listener, err := quic.Listen("127.0.0.1:0", tlsConfig, time.Second)
+		require.NoError(err)
+		ip, err := ips.ToIPPort(listener.Addr().String())
+		require.NoError(err)
+
+		config := defaultConfig
+		config.TLSConfig = tlsConfig
+		config.Transport = QUICTransport
+		config.MyNodeID = nodeID
+		config.MyIPPort = ips.NewDynamicIPPort(ip.IP, ip.Port)
+		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
+
+		listeners[i] = listener
+		dialers[i] = dialer.NewQUICDialer(tlsConfig, defaultDialerConfig, logging.NoLog{})
+		nodeIDs[i] = nodeID
+		configs[i] = &config
+	}
+
+	received := make(chan message.InboundMessage, 2)
+	networks, wg := startFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+			router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
+				received <- msg
+			}),
+		},
+		listeners,
+		dialers,
+		nodeIDs,
+		configs,
+		func(int, *Config) {},
+	)
+
+	// The NodeIDs of the peers are derived from their staking certificates.
+	for i, net := range networks {
+		peers := net.PeerInfo(nil)
+		require.Len(peers, 1)
+		require.Equal(nodeIDs[1-i], peers[0].ID)
+	}
+
+	mc := newMessageCreator(t)
+	ancestorsMsg, err := mc.Ancestors(ids.Empty, 1, [][]byte{make([]byte, units.MiB)})
+	require.NoError(err)
+	getMsg, err := mc.Get(ids.Empty, 2, time.Second, ids.Empty)
+	require.NoError(err)
+
+	toSend := set.Set[ids.NodeID]{}
+	toSend.Add(nodeIDs[1])
+	for _, msg := range []message.OutboundMessage{ancestorsMsg, getMsg} {
+		sentTo := networks[0].Send(msg, toSend, constants.PrimaryNetworkID, false)
+		require.EqualValues(toSend, sentTo)
+	}
+
+	receivedOps := set.Set[message.Op]{}
+	for i := 0; i < 2; i++ {
+		receivedOps.Add((<-received).Op())
+	}
+	require.True(receivedOps.Contains(message.AncestorsOp))
+	require.True(receivedOps.Contains(message.GetOp))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestQUICFallsBackToTCP(t *testing.T) {
+	require := require.New(t)
+
+	const numNodes = 2
+	var (
+		listeners = make([]net.Listener, numNodes)
+		dialers   = make([]dialer.Dialer, numNodes)
+		nodeIDs   = make([]ids.NodeID, numNodes)
+		configs   = make([]*Config, numNodes)
+	)
+	for i := 0; i < numNodes; i++ {
+		nodeID, tlsCert, tlsConfig := getTLS(t, i)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(err)
+		ip, err := ips.ToIPPort(listener.Addr().String())
+		require.NoError(err)
+
+		tcpDialer := dialer.NewDialer("tcp", defaultDialerConfig, logging.NoLog{})
+
+		config := defaultConfig
+		config.TLSConfig = tlsConfig
+		config.MyNodeID = nodeID
+		config.MyIPPort = ips.NewDynamicIPPort(ip.IP, ip.Port)
+		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
+
+		// Only the first node uses QUIC, so the nodes must connect over TCP.
+		if i == 0 {
+			quicListener, err := quic.Listen(listener.Addr().String(), tlsConfig, time.Second)
+			require.NoError(err)
+
+			config.Transport = QUICTransport
+			listeners[i] = quic.NewFallbackListener(quicListener, listener)
+			dialers[i] = dialer.NewFallbackDialer(
+				dialer.NewQUICDialer(tlsConfig, defaultDialerConfig, logging.NoLog{}),
+				tcpDialer,
+				logging.NoLog{},
+			)
+		} else {
+			listeners[i] = listener
+			dialers[i] = tcpDialer
+		}
+		nodeIDs[i] = nodeID
+		configs[i] = &config
+	}
+
+	received := make(chan message.InboundMessage, 1)
+	networks, wg := startFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+			router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
+				received <- msg
+			}),
+		},
+		listeners,
+		dialers,
+		nodeIDs,
+		configs,
+		func(int, *Config) {},
+	)
+
+	mc := newMessageCreator(t)
+	getMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	toSend := set.Set[ids.NodeID]{}
+	toSend.Add(nodeIDs[1])
+	sentTo := networks[0].Send(getMsg, toSend, constants.PrimaryNetworkID, false)
+	require.EqualValues(toSend, sentTo)
+	require.Equal(message.GetOp, (<-received).Op())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestTrackVerifiesSignatures(t *testing.T) {
 	require := require.New(t)
 
-	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+	reputation, err := peer.NewReputation(time.Hour, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	_, networks, wg := newConfiguredFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{nil},
+		func(_ int, config *Config) {
+			config.Reputation = reputation
+		},
+	)
 
 	network := networks[0].(*network)
 	nodeID, tlsCert, _ := getTLS(t, 1)
-	err := validators.Add(network.config.Validators, constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1)
+	err = validators.Add(network.config.Validators, constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1)
 	require.NoError(err)
 
-	useful := network.Track(ips.ClaimedIPPort{
+	gossiperID := ids.GenerateTestNodeID()
+	useful := network.Track(gossiperID, ips.ClaimedIPPort{
 		Cert: tlsCert.Leaf,
 		IPPort: ips.IPPort{
 			IP:   net.IPv4(123, 132, 123, 123),
@@ -369,6 +581,8 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	})
 	// The signature is wrong so this peer tracking info isn't useful.
 	require.False(useful)
+	// The peer that gossiped the invalid signature is penalized.
+	require.InDelta(peer.InvalidSignatureOffense.Weight(), reputation.Penalty(gossiperID), 0.01)
 
 	network.peersLock.RLock()
 	require.Empty(network.trackedIPs)
@@ -379,3 +593,511 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestTrackPersistsIP(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newConfiguredFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{nil},
+		func(_ int, config *Config) {
+			config.AddressBookDB = memdb.New()
+			config.AddressBookMaxAge = time.Hour
+		},
+	)
+
+	network := networks[0].(*network)
+
+	nodeID, tlsCert, _ := getTLS(t, 1)
+	err := validators.Add(network.config.Validators, constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1)
+	require.NoError(err)
+
+	unsignedIP := peer.UnsignedIP{
+		IP: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+	}
+	signedIP, err := unsignedIP.Sign(tlsCert.PrivateKey.(crypto.Signer))
+	require.NoError(err)
+
+	useful := network.Track(ids.GenerateTestNodeID(), ips.ClaimedIPPort{
+		Cert:      tlsCert.Leaf,
+		IPPort:    signedIP.IP.IP,
+		Timestamp: signedIP.IP.Timestamp,
+		Signature: signedIP.Signature,
+	})
+	require.True(useful)
+
+	persistedIPs, err := network.addressBook.load(time.Now())
+	require.NoError(err)
+	require.Len(persistedIPs, 1)
+	require.Equal(tlsCert.Leaf.Raw, persistedIPs[0].Cert.Raw)
+	require.Equal(signedIP.Signature, persistedIPs[0].Signature)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestReputationAffectsSamplingAndConnections(t *testing.T) {
+	require := require.New(t)
+
+	reputation, err := peer.NewReputation(time.Hour, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	nodeIDs, networks, wg := newConfiguredFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{nil, nil, nil},
+		func(i int, config *Config) {
+			if i != 0 {
+				return
+			}
+			config.Reputation = reputation
+			config.ReputationConfig = ReputationConfig{
+				PenaltyHalflife:     time.Hour,
+				SampleThreshold:     1,
+				DisconnectThreshold: 20,
+			}
+		},
+	)
+
+	network := networks[0].(*network)
+	penalizedID := nodeIDs[1]
+	wellBehavedID := nodeIDs[2]
+	reputation.Penalize(penalizedID, peer.InvalidSignatureOffense)
+
+	// The well-behaved peer is always preferred.
+	for i := 0; i < 10; i++ {
+		peers := network.samplePeers(constants.PrimaryNetworkID, false, 0, 0, 1)
+		require.Len(peers, 1)
+		require.Equal(wellBehavedID, peers[0].ID())
+	}
+
+	// The penalized peer is still sampled if there aren't enough well-behaved
+	// peers.
+	peers := network.samplePeers(constants.PrimaryNetworkID, false, 0, 0, 2)
+	require.Len(peers, 2)
+	require.Equal(wellBehavedID, peers[0].ID())
+	require.Equal(penalizedID, peers[1].ID())
+	require.True(network.AllowConnection(penalizedID))
+
+	// Peers that keep misbehaving aren't allowed to stay connected.
+	reputation.Penalize(penalizedID, peer.IncompatibleVersionOffense)
+	reputation.Penalize(penalizedID, peer.IncompatibleVersionOffense)
+	require.False(network.AllowConnection(penalizedID))
+	require.False(network.WantsConnection(penalizedID))
+	require.True(network.AllowConnection(wellBehavedID))
+	require.True(network.WantsConnection(wellBehavedID))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestUpdateConfig(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+	network := networks[0].(*network)
+
+	// The peer validates a subnet that isn't tracked yet.
+	subnetID := ids.GenerateTestID()
+	subnetVdrs := validators.NewSet()
+	require.NoError(subnetVdrs.Add(nodeIDs[1], nil, ids.GenerateTestID(), 1))
+	require.True(network.config.Validators.Add(subnetID, subnetVdrs))
+
+	network.peersLock.RLock()
+	oldPeer, ok := network.connectedPeers.GetByID(nodeIDs[1])
+	network.peersLock.RUnlock()
+	require.True(ok)
+
+	config := *network.config
+	config.HealthConfig.MinConnectedPeers = 2
+	config.HealthConfig.SendFailRateHalflife = time.Hour
+	config.PeerListValidatorGossipSize = 5
+	config.PeerListGossipFreq = time.Hour
+	config.ThrottlerConfig.InboundMsgThrottlerConfig.MaxProcessingMsgsPerNode = 5
+	config.ThrottlerConfig.InboundMsgThrottlerConfig.AtLargeAllocSize = 5
+	config.ThrottlerConfig.OutboundMsgThrottlerConfig.AtLargeAllocSize = 5
+	config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig.UpgradeCooldown = time.Hour
+	config.WhitelistedSubnets = set.Set[ids.ID]{subnetID: {}}
+	network.UpdateConfig(&config)
+
+	_, err := network.HealthCheck(context.Background())
+	require.ErrorContains(err, "not connected to a minimum of 2 peer(s) only 1")
+	require.EqualValues(5, network.config.PeerListValidatorGossipSize)
+	require.EqualValues(5, network.config.ThrottlerConfig.InboundMsgThrottlerConfig.MaxProcessingMsgsPerNode)
+	require.EqualValues(5, network.config.ThrottlerConfig.InboundMsgThrottlerConfig.AtLargeAllocSize)
+	require.EqualValues(5, network.config.ThrottlerConfig.OutboundMsgThrottlerConfig.AtLargeAllocSize)
+	require.Equal(time.Hour, network.config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig.UpgradeCooldown)
+
+	// The new subnet is tracked, and the peer validating it handshakes again
+	// to learn about it.
+	require.Equal(set.Set[ids.ID]{subnetID: {}}, network.config.WhitelistedSubnets)
+	require.Equal(set.Set[ids.ID]{subnetID: {}}, network.peerConfig.MySubnets.GetValue())
+	require.Eventually(
+		func() bool {
+			network.peersLock.RLock()
+			defer network.peersLock.RUnlock()
+
+			newPeer, ok := network.connectedPeers.GetByID(nodeIDs[1])
+			return ok && newPeer != oldPeer
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+
+	// Settings that can't change while the network is running are ignored.
+	require.Equal(defaultHealthConfig.SendFailRateHalflife, network.config.HealthConfig.SendFailRateHalflife)
+	require.Equal(defaultPeerListGossipConfig.PeerListGossipFreq, network.config.PeerListGossipFreq)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestBanDisconnectsAndRejectsNode(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	network := networks[0]
+	bannedNodeID := nodeIDs[1]
+	require.ErrorIs(network.Ban(bannedNodeID, 0), errInvalidBanDuration)
+	require.NoError(network.Ban(bannedNodeID, time.Hour))
+
+	bans := network.Bans()
+	require.Len(bans, 1)
+	require.Equal(bannedNodeID, bans[0].NodeID)
+
+	require.False(network.AllowConnection(bannedNodeID))
+	require.False(network.WantsConnection(bannedNodeID))
+	err := network.AddPeer(bannedNodeID, ips.IPPort{
+		IP:   net.IPv4(123, 132, 123, 123),
+		Port: 10000,
+	})
+	require.ErrorIs(err, errNodeBanned)
+
+	require.Eventually(
+		func() bool {
+			return len(network.PeerInfo(nil)) == 0
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestAllowedAndDeniedNodeIDs(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 3)
+
+	primaryVdrs := validators.NewSet()
+	for _, nodeID := range nodeIDs {
+		require.NoError(primaryVdrs.Add(nodeID, nil, ids.GenerateTestID(), 1))
+	}
+	vdrs := validators.NewManager()
+	_ = vdrs.Add(constants.PrimaryNetworkID, primaryVdrs)
+
+	sentryID := ids.GenerateTestNodeID()
+	config := configs[0]
+	config.Validators = vdrs
+	config.Beacons = validators.NewSet()
+	config.AllowedNodeIDs = set.Set[ids.NodeID]{}
+	config.AllowedNodeIDs.Add(sentryID, nodeIDs[1])
+	config.DeniedNodeIDs = set.Set[ids.NodeID]{}
+	config.DeniedNodeIDs.Add(nodeIDs[1])
+
+	network, err := NewNetwork(
+		config,
+		newMessageCreator(t),
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		listeners[0],
+		dialer,
+		&testHandler{},
+	)
+	require.NoError(err)
+
+	// Allowed nodes are connected to even if they aren't validators.
+	require.True(network.AllowConnection(sentryID))
+	require.True(network.WantsConnection(sentryID))
+
+	// Denied nodes are never connected to, even if they are allowed.
+	require.False(network.AllowConnection(nodeIDs[1]))
+	require.False(network.WantsConnection(nodeIDs[1]))
+
+	// Validators that aren't allowed are never connected to.
+	require.False(network.AllowConnection(nodeIDs[2]))
+	require.False(network.WantsConnection(nodeIDs[2]))
+	err = network.AddPeer(nodeIDs[2], ips.IPPort{
+		IP:   net.IPv4(123, 132, 123, 123),
+		Port: 10000,
+	})
+	require.ErrorIs(err, errNodeNotAllowed)
+
+	network.StartClose()
+}
+
+func TestPeersDoesNotGossipPrivateNodeIDs(t *testing.T) {
+	require := require.New(t)
+
+	privateNodeID, _, _ := getTLS(t, 2)
+	nodeIDs, networks, wg := newConfiguredFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{nil, nil, nil, nil},
+		func(i int, config *Config) {
+			if i == 0 {
+				config.PrivateNodeIDs = set.Set[ids.NodeID]{}
+				config.PrivateNodeIDs.Add(privateNodeID)
+			}
+		},
+	)
+	require.Equal(privateNodeID, nodeIDs[2])
+
+	// Forget which validators the peer knows about, so that every connected
+	// validator is a candidate to be gossiped.
+	sentry := networks[0].(*network)
+	peerID := nodeIDs[1]
+	sentry.gossipTracker.StopTrackingPeer(peerID)
+	require.True(sentry.gossipTracker.StartTrackingPeer(peerID))
+
+	peerIPs, err := sentry.Peers(peerID)
+	require.NoError(err)
+
+	gossiped := set.Set[ids.NodeID]{}
+	for _, ip := range peerIPs {
+		gossiped.Add(ids.NodeIDFromCert(ip.Cert))
+	}
+	require.True(gossiped.Contains(nodeIDs[3]))
+	require.False(gossiped.Contains(privateNodeID))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+// newTCPTestNetwork returns the listeners, dialers, nodeIDs and configs of
+// [count] nodes that communicate over real TCP connections. Every node listens
+// on [address], which should have a zero port.
+func newTCPTestNetwork(t *testing.T, count int, address string) ([]net.Listener, []dialer.Dialer, []ids.NodeID, []*Config) {
+	t.Helper()
+	require := require.New(t)
+
+	var (
+		listeners = make([]net.Listener, count)
+		dialers   = make([]dialer.Dialer, count)
+		nodeIDs   = make([]ids.NodeID, count)
+		configs   = make([]*Config, count)
+	)
+	for i := 0; i < count; i++ {
+		nodeID, tlsCert, tlsConfig := getTLS(t, i)
+
+		listener, err := net.Listen(constants.NetworkType, address)
+		if err != nil {
+			t.Skipf("couldn't listen on %s: %s", address, err)
+		}
+		ip, err := ips.ToIPPort(listener.Addr().String())
+		require.NoError(err)
+
+		config := defaultConfig
+		config.TLSConfig = tlsConfig
+		config.MyNodeID = nodeID
+		config.MyIPPort = ips.NewDynamicIPPort(ip.IP, ip.Port)
+		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
+
+		listeners[i] = listener
+		dialers[i] = dialer.NewDialer(constants.NetworkType, defaultDialerConfig, logging.NoLog{})
+		nodeIDs[i] = nodeID
+		configs[i] = &config
+	}
+	return listeners, dialers, nodeIDs, configs
+}
+
+func TestIPv6OnlyNetwork(t *testing.T) {
+	require := require.New(t)
+
+	listeners, dialers, nodeIDs, configs := newTCPTestNetwork(t, 3, "[::1]:0")
+
+	received := make(chan message.InboundMessage)
+	networks, wg := startFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+			router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
+				received <- msg
+			}),
+			router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+		},
+		listeners,
+		dialers,
+		nodeIDs,
+		configs,
+		func(int, *Config) {},
+	)
+
+	// Nodes that only have an IPv6 IP advertise it as their only IP. The nodes
+	// that weren't manually tracked were discovered through gossip.
+	for _, net := range networks {
+		peers := net.PeerInfo(nil)
+		require.Len(peers, 2)
+		for _, peer := range peers {
+			ip, err := ips.ToIPPort(peer.PublicIP)
+			require.NoError(err)
+			require.True(ip.IsIPv6())
+			require.Empty(peer.PublicIPv6)
+		}
+	}
+
+	mc := newMessageCreator(t)
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	toSend := set.Set[ids.NodeID]{}
+	toSend.Add(nodeIDs[1])
+	sentTo := networks[2].Send(outboundGetMsg, toSend, constants.PrimaryNetworkID, false)
+	require.EqualValues(toSend, sentTo)
+
+	inboundGetMsg := <-received
+	require.Equal(message.GetOp, inboundGetMsg.Op())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestDualStackNetwork(t *testing.T) {
+	require := require.New(t)
+
+	// Listening on the IPv6 wildcard address accepts both IPv4 and IPv6
+	// connections.
+	listeners, dialers, nodeIDs, configs := newTCPTestNetwork(t, 3, "[::]:0")
+	for _, config := range configs {
+		port := config.MyIPPort.IPPort().Port
+		config.MyIPPort = ips.NewDynamicIPPort(net.IPv4(127, 0, 0, 1), port)
+		config.MyIPv6Port = ips.NewDynamicIPPort(net.IPv6loopback, port)
+	}
+
+	networks, wg := startFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{nil, nil, nil},
+		listeners,
+		dialers,
+		nodeIDs,
+		configs,
+		func(int, *Config) {},
+	)
+
+	// Dual-stack nodes advertise both of their IPs during the handshake.
+	for i, net := range networks {
+		for _, peer := range net.PeerInfo(nil) {
+			require.NotEqual(nodeIDs[i], peer.ID)
+
+			ip, err := ips.ToIPPort(peer.PublicIP)
+			require.NoError(err)
+			require.False(ip.IsIPv6())
+
+			ipv6, err := ips.ToIPPort(peer.PublicIPv6)
+			require.NoError(err)
+			require.True(ipv6.IsIPv6())
+			require.Equal(ip.Port, ipv6.Port)
+		}
+	}
+
+	// Forget which validators the peer knows about, so that every connected
+	// validator is gossiped.
+	gossiper := networks[0].(*network)
+	peerID := nodeIDs[1]
+	gossiper.gossipTracker.StopTrackingPeer(peerID)
+	require.True(gossiper.gossipTracker.StartTrackingPeer(peerID))
+
+	// Both IPs of dual-stack peers are gossiped with the same timestamp.
+	peerIPs, err := gossiper.Peers(peerID)
+	require.NoError(err)
+
+	var gossipedIPs []ips.ClaimedIPPort
+	for _, ip := range peerIPs {
+		if ids.NodeIDFromCert(ip.Cert) == nodeIDs[2] {
+			gossipedIPs = append(gossipedIPs, ip)
+		}
+	}
+	require.Len(gossipedIPs, 2)
+	require.False(gossipedIPs[0].IPPort.IsIPv6())
+	require.True(gossipedIPs[1].IPPort.IsIPv6())
+	require.Equal(gossipedIPs[0].Timestamp, gossipedIPs[1].Timestamp)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestTrackDualStackIPs(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newConfiguredFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{nil},
+		func(_ int, config *Config) {
+			config.MyIPv6Port = ips.NewDynamicIPPort(net.IPv6loopback, 0)
+		},
+	)
+
+	network := networks[0].(*network)
+	nodeID, tlsCert, _ := getTLS(t, 1)
+	err := validators.Add(network.config.Validators, constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1)
+	require.NoError(err)
+
+	signer := peer.NewDualStackIPSigner(
+		ips.NewDynamicIPPort(net.IPv4(123, 132, 123, 123), 10000),
+		ips.NewDynamicIPPort(net.ParseIP("2001:db8::1"), 10000),
+		tlsCert.PrivateKey.(crypto.Signer),
+	)
+	signedIP, signedIPv6, err := signer.GetSignedIPs()
+	require.NoError(err)
+
+	claimedIPPort := func(signedIP *peer.SignedIP) ips.ClaimedIPPort {
+		return ips.ClaimedIPPort{
+			Cert:      tlsCert.Leaf,
+			IPPort:    signedIP.IP.IP,
+			Timestamp: signedIP.IP.Timestamp,
+			Signature: signedIP.Signature,
+		}
+	}
+	gossiperID := ids.GenerateTestNodeID()
+	require.True(network.Track(gossiperID, claimedIPPort(signedIP)))
+	require.True(network.Track(gossiperID, claimedIPPort(signedIPv6)))
+	// Tracking the same IP again isn't useful.
+	require.False(network.Track(gossiperID, claimedIPPort(signedIPv6)))
+
+	// This node has an IPv6 IP, so it dials the peer's IPv6 IP first.
+	network.peersLock.RLock()
+	tracked, ok := network.trackedIPs[nodeID]
+	require.True(ok)
+	require.Equal(
+		[]ips.IPPort{signedIPv6.IP.IP, signedIP.IP.IP},
+		tracked.dialOrder(network.prefersIPv6()),
+	)
+	network.peersLock.RUnlock()
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/snow/uptime"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
@@ -30,16 +32,20 @@ type Config struct {
 
 	Log                  logging.Logger
 	InboundMsgThrottler  throttling.InboundMsgThrottler
+	SubnetThrottler      throttling.SubnetBandwidthThrottler
 	Network              Network
 	Router               router.InboundHandler
 	VersionCompatibility version.Compatibility
-	MySubnets            set.Set[ids.ID]
 	Beacons              validators.Set
 	NetworkID            uint32
 	PingFrequency        time.Duration
 	PongTimeout          time.Duration
 	MaxClockDifference   time.Duration
 
+	// Holds the set.Set[ids.ID] of subnets this node tracks. The set is
+	// replaced, rather than modified, when the tracked subnets change.
+	MySubnets *utils.AtomicInterface
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
@@ -47,6 +53,14 @@ type Config struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker tracker.ResourceTracker
 
+	// Registers failures of peers that send messages that decompress to more
+	// than the maximum message size.
+	Benchlist benchlist.Manager
+
+	// Scores the behavior of peers. Offenses committed by this peer are
+	// reported to it.
+	Reputation Reputation
+
 	// Tracks which peer knows about which peers
 	GossipTracker GossipTracker
 
@@ -55,4 +69,15 @@ type Config struct {
 
 	// Signs my IP so I can send my signed IP address in the Version message
 	IPSigner *IPSigner
+
+	// Records every message exchanged with peers. If nil, messages aren't
+	// recorded.
+	Recorder Recorder
+}
+
+// mySubnets returns the subnets this node tracks. The returned set must not be
+// modified.
+func (c *Config) mySubnets() set.Set[ids.ID] {
+	mySubnets, _ := c.MySubnets.GetValue().(set.Set[ids.ID])
+	return mySubnets
 }
@@ -13,6 +13,7 @@ import (
 type Info struct {
 	IP                    string                 `json:"ip"`
 	PublicIP              string                 `json:"publicIP,omitempty"`
+	PublicIPv6            string                 `json:"publicIPv6,omitempty"`
 	ID                    ids.NodeID             `json:"nodeID"`
 	Version               string                 `json:"version"`
 	LastSent              time.Time              `json:"lastSent"`
@@ -20,4 +21,15 @@ type Info struct {
 	ObservedUptime        json.Uint32            `json:"observedUptime"`
 	ObservedSubnetUptimes map[ids.ID]json.Uint32 `json:"observedSubnetUptimes"`
 	TrackedSubnets        []ids.ID               `json:"trackedSubnets"`
+	// Number of messages from this peer that were rejected because they
+	// decompressed to more than the maximum message size.
+	RejectedOversizedMessages json.Uint64 `json:"rejectedOversizedMessages"`
+	// Messages and bytes exchanged with this peer since connecting, keyed by
+	// message type.
+	Traffic map[string]OpTraffic `json:"traffic"`
+	// Number of messages queued to be sent to this peer.
+	SendQueueLength json.Uint64 `json:"sendQueueLength"`
+	// Total time spent waiting on the inbound message throttler before
+	// reading messages from this peer, in nanoseconds.
+	InboundThrottlerWaitTime time.Duration `json:"inboundThrottlerWaitTime"`
 }
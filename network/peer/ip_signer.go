@@ -13,15 +13,18 @@ import (
 
 // IPSigner will return a signedIP for the current value of our dynamic IP.
 type IPSigner struct {
-	ip     ips.DynamicIPPort
+	ip ips.DynamicIPPort
+	// ipv6 is nil if this node isn't dual-stack.
+	ipv6   ips.DynamicIPPort
 	clock  mockable.Clock
 	signer crypto.Signer
 
-	// Must be held while accessing [signedIP]
+	// Must be held while accessing [signedIP] and [signedIPv6]
 	signedIPLock sync.RWMutex
-	// Note that the values in [*signedIP] are constants and can be inspected
-	// without holding [signedIPLock].
-	signedIP *SignedIP
+	// Note that the values in [*signedIP] and [*signedIPv6] are constants and
+	// can be inspected without holding [signedIPLock].
+	signedIP   *SignedIP
+	signedIPv6 *SignedIP
 }
 
 func NewIPSigner(
@@ -34,20 +37,45 @@ func NewIPSigner(
 	}
 }
 
+// NewDualStackIPSigner returns an IPSigner that also signs the IPv6 IP of a
+// dual-stack node. Both IPs are always signed with the same timestamp, so that
+// peers can tell that they were claimed together.
+func NewDualStackIPSigner(
+	ip ips.DynamicIPPort,
+	ipv6 ips.DynamicIPPort,
+	signer crypto.Signer,
+) *IPSigner {
+	return &IPSigner{
+		ip:     ip,
+		ipv6:   ipv6,
+		signer: signer,
+	}
+}
+
 // GetSignedIP returns the signedIP of the current value of the provided
 // dynamicIP. If the dynamicIP hasn't changed since the prior call to
 // GetSignedIP, then the same [SignedIP] will be returned.
 //
 // It's safe for multiple goroutines to concurrently call GetSignedIP.
 func (s *IPSigner) GetSignedIP() (*SignedIP, error) {
+	signedIP, _, err := s.GetSignedIPs()
+	return signedIP, err
+}
+
+// GetSignedIPs is GetSignedIP, but also returns the signedIP of the current
+// value of the IPv6 IP. If this node isn't dual-stack, the returned IPv6 IP is
+// nil.
+//
+// It's safe for multiple goroutines to concurrently call GetSignedIPs.
+func (s *IPSigner) GetSignedIPs() (*SignedIP, *SignedIP, error) {
 	// Optimistically, the IP should already be signed. By grabbing a read lock
 	// here we enable full concurrency of new connections.
 	s.signedIPLock.RLock()
-	signedIP := s.signedIP
+	signedIP, signedIPv6 := s.signedIP, s.signedIPv6
 	s.signedIPLock.RUnlock()
-	ip := s.ip.IPPort()
-	if signedIP != nil && signedIP.IP.IP.Equal(ip) {
-		return signedIP, nil
+	ip, ipv6 := s.currentIPs()
+	if s.isSigned(signedIP, signedIPv6, ip, ipv6) {
+		return signedIP, signedIPv6, nil
 	}
 
 	// If our current IP hasn't been signed yet - then we should sign it.
@@ -57,21 +85,53 @@ func (s *IPSigner) GetSignedIP() (*SignedIP, error) {
 	// It's possible that multiple threads read [n.signedIP] as incorrect at the
 	// same time, we should verify that we are the first thread to attempt to
 	// update it.
-	signedIP = s.signedIP
-	if signedIP != nil && signedIP.IP.IP.Equal(ip) {
-		return signedIP, nil
+	signedIP, signedIPv6 = s.signedIP, s.signedIPv6
+	if s.isSigned(signedIP, signedIPv6, ip, ipv6) {
+		return signedIP, signedIPv6, nil
 	}
 
-	// We should now sign our new IP at the current timestamp.
+	// We should now sign our new IPs at the current timestamp.
+	timestamp := s.clock.Unix()
 	unsignedIP := UnsignedIP{
 		IP:        ip,
-		Timestamp: s.clock.Unix(),
+		Timestamp: timestamp,
 	}
 	signedIP, err := unsignedIP.Sign(s.signer)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	signedIPv6 = nil
+	if s.ipv6 != nil {
+		unsignedIPv6 := UnsignedIP{
+			IP:        ipv6,
+			Timestamp: timestamp,
+		}
+		signedIPv6, err = unsignedIPv6.Sign(s.signer)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	s.signedIP = signedIP
-	return s.signedIP, nil
+	s.signedIPv6 = signedIPv6
+	return s.signedIP, s.signedIPv6, nil
+}
+
+func (s *IPSigner) currentIPs() (ips.IPPort, ips.IPPort) {
+	ip := s.ip.IPPort()
+	if s.ipv6 == nil {
+		return ip, ips.IPPort{}
+	}
+	return ip, s.ipv6.IPPort()
+}
+
+func (s *IPSigner) isSigned(signedIP, signedIPv6 *SignedIP, ip, ipv6 ips.IPPort) bool {
+	if signedIP == nil || !signedIP.IP.IP.Equal(ip) {
+		return false
+	}
+	if s.ipv6 == nil {
+		return true
+	}
+	return signedIPv6 != nil && signedIPv6.IP.IP.Equal(ipv6)
 }
@@ -53,3 +53,47 @@ func TestIPSigner(t *testing.T) {
 	require.EqualValues(11, signedIP3.IP.Timestamp)
 	require.NotEqualValues(signedIP2.Signature, signedIP3.Signature)
 }
+
+func TestDualStackIPSigner(t *testing.T) {
+	require := require.New(t)
+
+	dynIP := ips.NewDynamicIPPort(
+		net.IPv4(1, 2, 3, 4),
+		9651,
+	)
+	dynIPv6 := ips.NewDynamicIPPort(
+		net.ParseIP("2001:db8::1"),
+		9651,
+	)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	key := tlsCert.PrivateKey.(crypto.Signer)
+
+	s := NewDualStackIPSigner(dynIP, dynIPv6, key)
+
+	s.clock.Set(time.Unix(10, 0))
+
+	signedIP1, signedIPv61, err := s.GetSignedIPs()
+	require.NoError(err)
+	require.EqualValues(dynIP.IPPort(), signedIP1.IP.IP)
+	require.EqualValues(dynIPv6.IPPort(), signedIPv61.IP.IP)
+	require.EqualValues(10, signedIP1.IP.Timestamp)
+	require.EqualValues(10, signedIPv61.IP.Timestamp)
+	require.NoError(signedIPv61.Verify(tlsCert.Leaf))
+
+	s.clock.Set(time.Unix(11, 0))
+
+	// Changing only the IPv6 IP re-signs both IPs with the same timestamp.
+	dynIPv6.SetIP(net.ParseIP("2001:db8::2"))
+
+	signedIP2, signedIPv62, err := s.GetSignedIPs()
+	require.NoError(err)
+	require.EqualValues(dynIP.IPPort(), signedIP2.IP.IP)
+	require.EqualValues(dynIPv6.IPPort(), signedIPv62.IP.IP)
+	require.EqualValues(11, signedIP2.IP.Timestamp)
+	require.EqualValues(11, signedIPv62.IP.Timestamp)
+	require.NoError(signedIP2.Verify(tlsCert.Leaf))
+	require.NoError(signedIPv62.Verify(tlsCert.Leaf))
+}
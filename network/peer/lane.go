@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"io"
+	"net"
+
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/network/throttling"
+)
+
+// Lane is a class of outbound messages that is queued separately from the
+// other classes by the priority message queue.
+type Lane int
+
+const (
+	// HandshakeLane carries the messages that maintain the connection.
+	HandshakeLane Lane = iota
+	// ConsensusLane carries the latency-critical messages used while
+	// processing blocks and vertices.
+	ConsensusLane
+	// BootstrapLane carries the messages used while bootstrapping and state
+	// syncing, which are typically large and not latency-critical.
+	BootstrapLane
+	// AppLane carries the messages sent on behalf of VMs.
+	AppLane
+)
+
+// Lanes contains all the lanes, in the order they are scheduled.
+var Lanes = []Lane{
+	HandshakeLane,
+	ConsensusLane,
+	BootstrapLane,
+	AppLane,
+}
+
+// StreamConn is a connection that multiplexes independent streams, such as a
+// QUIC connection. The messages of each lane are written to their own stream,
+// so that the messages of one lane are never delayed by the messages of
+// another lane once they have been sent.
+type StreamConn interface {
+	net.Conn
+
+	// Stream returns a writer to the stream with index [stream].
+	Stream(stream int) io.Writer
+}
+
+// laneQuantum is the number of bytes a lane with a weight of 1 may send every
+// scheduling round.
+const laneQuantum = 16 * 1024
+
+type laneParams struct {
+	// weight is the relative number of bytes the lane is allowed to send while
+	// other lanes also have messages queued.
+	weight int
+	// bytePortion is the portion of the outbound throttler's byte allocations
+	// that is given to the lane.
+	bytePortion float64
+}
+
+var lanesParams = map[Lane]laneParams{
+	HandshakeLane: {
+		weight:      8,
+		bytePortion: .1,
+	},
+	ConsensusLane: {
+		weight:      4,
+		bytePortion: .4,
+	},
+	BootstrapLane: {
+		weight:      1,
+		bytePortion: .3,
+	},
+	AppLane: {
+		weight:      2,
+		bytePortion: .2,
+	},
+}
+
+func (l Lane) String() string {
+	switch l {
+	case HandshakeLane:
+		return "handshake"
+	case ConsensusLane:
+		return "consensus"
+	case BootstrapLane:
+		return "bootstrap"
+	case AppLane:
+		return "app"
+	default:
+		return "unknown"
+	}
+}
+
+// ThrottlerConfig returns the outbound throttler config of this lane, given
+// the config of the throttler that would be shared by all the lanes.
+func (l Lane) ThrottlerConfig(config throttling.MsgByteThrottlerConfig) throttling.MsgByteThrottlerConfig {
+	portion := lanesParams[l].bytePortion
+	return throttling.MsgByteThrottlerConfig{
+		VdrAllocSize:        uint64(float64(config.VdrAllocSize) * portion),
+		AtLargeAllocSize:    uint64(float64(config.AtLargeAllocSize) * portion),
+		NodeMaxAtLargeBytes: config.NodeMaxAtLargeBytes,
+	}
+}
+
+// LaneOf returns the lane that messages of type [op] are queued in.
+func LaneOf(op message.Op) Lane {
+	switch op {
+	case message.PingOp, message.PongOp, message.VersionOp,
+		message.PeerListOp, message.PeerListAckOp:
+		return HandshakeLane
+	case message.GetOp, message.PutOp, message.PushQueryOp,
+		message.PullQueryOp, message.ChitsOp:
+		return ConsensusLane
+	case message.GetStateSummaryFrontierOp, message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp, message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp, message.AcceptedFrontierOp,
+		message.GetAcceptedOp, message.AcceptedOp,
+		message.GetAncestorsOp, message.AncestorsOp:
+		return BootstrapLane
+	default:
+		return AppLane
+	}
+}
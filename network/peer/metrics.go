@@ -79,9 +79,60 @@ func NewMessageMetrics(
 	return msg
 }
 
+// LaneMetrics tracks the messages of a lane of the priority message queue,
+// across all peers.
+type LaneMetrics struct {
+	QueuedMsgs, QueuedBytes          prometheus.Gauge
+	NumSent, SentBytes, NumThrottled prometheus.Counter
+}
+
+func NewLaneMetrics(
+	lane Lane,
+	namespace string,
+	metrics prometheus.Registerer,
+	errs *wrappers.Errs,
+) *LaneMetrics {
+	m := &LaneMetrics{
+		QueuedMsgs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_lane_queued", lane),
+			Help:      fmt.Sprintf("Number of messages queued in the %s lane to be sent over the network", lane),
+		}),
+		QueuedBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_lane_queued_bytes", lane),
+			Help:      fmt.Sprintf("Size of the messages queued in the %s lane to be sent over the network", lane),
+		}),
+		NumSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_lane_sent", lane),
+			Help:      fmt.Sprintf("Number of messages popped from the %s lane to be sent over the network", lane),
+		}),
+		SentBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_lane_sent_bytes", lane),
+			Help:      fmt.Sprintf("Size of the messages popped from the %s lane to be sent over the network", lane),
+		}),
+		NumThrottled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_lane_throttled", lane),
+			Help:      fmt.Sprintf("Number of messages dropped because the %s lane's byte limit was reached", lane),
+		}),
+	}
+	errs.Add(
+		metrics.Register(m.QueuedMsgs),
+		metrics.Register(m.QueuedBytes),
+		metrics.Register(m.NumSent),
+		metrics.Register(m.SentBytes),
+		metrics.Register(m.NumThrottled),
+	)
+	return m
+}
+
 type Metrics struct {
 	Log                     logging.Logger
 	FailedToParse           prometheus.Counter
+	RejectedOversized       prometheus.Counter
 	NumUselessPeerListBytes prometheus.Counter
 	MessageMetrics          map[message.Op]*MessageMetrics
 }
@@ -98,6 +149,11 @@ func NewMetrics(
 			Name:      "msgs_failed_to_parse",
 			Help:      "Number of messages that could not be parsed or were invalidly formed",
 		}),
+		RejectedOversized: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "msgs_rejected_oversized",
+			Help:      "Number of messages that were rejected because they decompressed to more than the maximum message size",
+		}),
 		NumUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
@@ -109,6 +165,7 @@ func NewMetrics(
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(m.FailedToParse),
+		registerer.Register(m.RejectedOversized),
 		registerer.Register(m.NumUselessPeerListBytes),
 	)
 	for _, op := range message.ExternalOps {
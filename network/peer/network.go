@@ -19,12 +19,12 @@ type Network interface {
 	AllowConnection(ids.NodeID) bool
 
 	// Track allows the peer to notify the network of a potential new peer to
-	// connect to.
+	// connect to. [peerID] is the peer that gossiped the IP.
 	//
 	// Returns false if this call was not "useful". That is, we were already
 	// connected to this node, we already had this tracking information, the
 	// signature is invalid or we don't want to connect.
-	Track(ips.ClaimedIPPort) bool
+	Track(peerID ids.NodeID, ip ips.ClaimedIPPort) bool
 
 	// Disconnected is called when the peer finishes shutting down. It is not
 	// guaranteed that [Connected] was called for the provided peer. However, it
@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+var _ Reputation = noReputation{}
+
+// NewNoReputation returns a Reputation where every peer always has a penalty
+// of 0.
+func NewNoReputation() Reputation {
+	return noReputation{}
+}
+
+type noReputation struct{}
+
+func (noReputation) Penalize(ids.NodeID, Offense) {}
+
+func (noReputation) Penalty(ids.NodeID) float64 {
+	return 0
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
 	"github.com/lasthyphen/dijetsnodego/utils/json"
@@ -33,6 +34,22 @@ import (
 var (
 	errClosed = errors.New("closed")
 
+	// responseOps are the ops of the messages that answer requests of this
+	// node.
+	responseOps = func() set.Set[message.Op] {
+		ops := set.NewSet[message.Op](len(message.ConsensusResponseOps))
+		ops.Add(message.ConsensusResponseOps...)
+		return ops
+	}()
+
+	// droppableOps are the ops of the messages that are dropped, rather than
+	// waited on, when they exceed the bandwidth budget of their subnet.
+	droppableOps = func() set.Set[message.Op] {
+		ops := set.NewSet[message.Op](2)
+		ops.Add(message.AppRequestOp, message.AppGossipOp)
+		return ops
+	}()
+
 	_ Peer = (*peer)(nil)
 )
 
@@ -69,6 +86,11 @@ type Peer interface {
 	// handshake. It should only be called after [Ready] returns true.
 	IP() *SignedIP
 
+	// IPv6 returns the claimed IPv6 IP and signature provided by this peer
+	// during the handshake, or nil if the peer isn't dual-stack. It should
+	// only be called after [Ready] returns true.
+	IPv6() *SignedIP
+
 	// Version returns the claimed node version this peer is running. It should
 	// only be called after [Ready] returns true.
 	Version() *version.Application
@@ -123,12 +145,18 @@ type peer struct {
 
 	// ip is the claimed IP the peer gave us in the Version message.
 	ip *SignedIP
+	// ipv6 is the claimed IPv6 IP the peer gave us in the Version message, or
+	// nil if the peer isn't dual-stack.
+	ipv6 *SignedIP
 	// version is the claimed version the peer is running that we received in
 	// the Version message.
 	version *version.Application
 	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
 	// message that we are also tracking.
 	trackedSubnets set.Set[ids.ID]
+	// supportsZstd is true if the peer reported that it is able to decompress
+	// zstd compressed messages in the Version message.
+	supportsZstd utils.AtomicBool
 
 	observedUptimesLock sync.RWMutex
 	// [observedUptimesLock] must be held while accessing [observedUptime]
@@ -165,6 +193,19 @@ type peer struct {
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
 
+	// Number of messages from this peer that were rejected because they
+	// decompressed to more than the maximum message size.
+	// Must only be accessed atomically
+	rejectedOversizedMsgs uint64
+
+	// Total time, in nanoseconds, spent waiting on the inbound message
+	// throttler before reading messages from this peer.
+	// Must only be accessed atomically
+	inboundThrottlerWaitTime int64
+
+	// Messages and bytes exchanged with this peer by message type
+	traffic *traffic
+
 	// peerListChan signals that we should attempt to send a PeerList to this
 	// peer
 	peerListChan chan struct{}
@@ -194,6 +235,7 @@ func Start(
 		onClosingCtxCancel: onClosingCtxCancel,
 		onClosed:           make(chan struct{}),
 		observedUptimes:    make(map[ids.ID]uint32),
+		traffic:            newTraffic(),
 		peerListChan:       make(chan struct{}, 1),
 	}
 
@@ -251,6 +293,10 @@ func (p *peer) Info() Info {
 	if !p.ip.IP.IP.IsZero() {
 		publicIPStr = p.ip.IP.IP.String()
 	}
+	publicIPv6Str := ""
+	if p.ipv6 != nil && !p.ipv6.IP.IP.IsZero() {
+		publicIPv6Str = p.ipv6.IP.IP.String()
+	}
 
 	trackedSubnets := p.trackedSubnets.List()
 	uptimes := make(map[ids.ID]json.Uint32, len(trackedSubnets))
@@ -271,6 +317,7 @@ func (p *peer) Info() Info {
 	return Info{
 		IP:                    p.conn.RemoteAddr().String(),
 		PublicIP:              publicIPStr,
+		PublicIPv6:            publicIPv6Str,
 		ID:                    p.id,
 		Version:               p.version.String(),
 		LastSent:              time.Unix(atomic.LoadInt64(&p.lastSent), 0),
@@ -278,6 +325,11 @@ func (p *peer) Info() Info {
 		ObservedUptime:        json.Uint32(primaryUptime),
 		ObservedSubnetUptimes: uptimes,
 		TrackedSubnets:        trackedSubnets,
+
+		RejectedOversizedMessages: json.Uint64(atomic.LoadUint64(&p.rejectedOversizedMsgs)),
+		Traffic:                   p.traffic.info(),
+		SendQueueLength:           json.Uint64(p.messageQueue.Len()),
+		InboundThrottlerWaitTime:  time.Duration(atomic.LoadInt64(&p.inboundThrottlerWaitTime)),
 	}
 }
 
@@ -285,6 +337,10 @@ func (p *peer) IP() *SignedIP {
 	return p.ip
 }
 
+func (p *peer) IPv6() *SignedIP {
+	return p.ipv6
+}
+
 func (p *peer) Version() *version.Application {
 	return p.version
 }
@@ -302,6 +358,21 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
+	// Every peer is able to decompress gzip compressed messages, so messages
+	// are recompressed with gzip for peers that haven't reported supporting
+	// zstd.
+	if msg.CompressionType() == compression.TypeZstd && !p.supportsZstd.GetValue() {
+		gzipMsg, err := p.MessageCreator.Recompress(msg, compression.TypeGzip)
+		if err != nil {
+			p.Log.Error("failed to recompress message",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Error(err),
+			)
+			return false
+		}
+		msg = gzipMsg
+	}
 	return p.messageQueue.Push(ctx, msg)
 }
 
@@ -413,11 +484,14 @@ func (p *peer) readMessages() {
 		// exited before calling [Network.Disconnected] to guarantee that there
 		// can't be multiple instances of this goroutine running over different
 		// peer instances.
-		onFinishedHandling := p.InboundMsgThrottler.Acquire(
+		startedWaiting := p.Clock.Time()
+		onFinishedHandling, exceededBandwidth := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		waitTime := p.Clock.Time().Sub(startedWaiting)
+		atomic.AddInt64(&p.inboundThrottlerWaitTime, int64(waitTime))
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -469,6 +543,15 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			p.Reputation.Penalize(p.id, InvalidMessageOffense)
+			if errors.Is(err, compression.ErrDecompressedMsgTooLarge) {
+				// The peer sent a message that would have decompressed into
+				// more than the maximum message size. Honest peers never do
+				// this, so count it against the peer.
+				atomic.AddUint64(&p.rejectedOversizedMsgs, 1)
+				p.Metrics.RejectedOversized.Inc()
+				p.Benchlist.RegisterNodeFailure(p.id)
+			}
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -480,6 +563,39 @@ func (p *peer) readMessages() {
 		atomic.StoreInt64(&p.Config.LastReceived, now)
 		atomic.StoreInt64(&p.lastReceived, now)
 		p.Metrics.Received(msg, msgLen)
+		p.traffic.received(msg.Op(), int(msgLen))
+		if p.Recorder != nil {
+			p.Recorder.Record(Inbound, p.id, msg.Op(), p.Clock.Time(), msgBytes)
+		}
+
+		// The time spent waiting also depends on how busy this node is, so
+		// only sending faster than the peer's bandwidth allocation is counted
+		// against it. Responses are sent at the rate this node requests them,
+		// and beacons are needed to bootstrap, so neither is penalized.
+		if exceededBandwidth && !responseOps.Contains(msg.Op()) && !p.Beacons.Contains(p.id) {
+			p.Reputation.Penalize(p.id, ThrottledOffense)
+		}
+
+		// Charge the message to the bandwidth budget of its chain's subnet,
+		// using the bytes read from the wire. App requests and gossip are
+		// dropped when the budget is exceeded, so that a subnet's gossip can't
+		// delay its consensus messages. Other messages wait for the budget to
+		// refill, since dropping them would cause queries to time out.
+		if chainID, err := message.GetChainID(msg.Message()); err == nil {
+			if !droppableOps.Contains(msg.Op()) {
+				p.SubnetThrottler.Acquire(p.onClosingCtx, chainID, uint64(msgLen))
+			} else if !p.SubnetThrottler.Allow(chainID, uint64(msgLen)) {
+				p.Log.Debug("dropping message",
+					zap.Stringer("nodeID", p.id),
+					zap.Stringer("messageOp", msg.Op()),
+					zap.Stringer("chainID", chainID),
+					zap.String("reason", "subnet bandwidth budget exceeded"),
+				)
+				msg.OnFinishedHandling()
+				p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+				continue
+			}
+		}
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -494,10 +610,10 @@ func (p *peer) writeMessages() {
 		p.close()
 	}()
 
-	writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
+	writers := p.newLaneWriters()
 
 	// Make sure that the version is the first message sent
-	mySignedIP, err := p.IPSigner.GetSignedIP()
+	mySignedIP, mySignedIPv6, err := p.IPSigner.GetSignedIPs()
 	if err != nil {
 		p.Log.Error("failed to get signed IP",
 			zap.Error(err),
@@ -505,6 +621,15 @@ func (p *peer) writeMessages() {
 		return
 	}
 
+	var (
+		myIPv6    ips.IPPort
+		myIPv6Sig []byte
+	)
+	if mySignedIPv6 != nil {
+		myIPv6 = mySignedIPv6.IP.IP
+		myIPv6Sig = mySignedIPv6.Signature
+	}
+
 	msg, err := p.MessageCreator.Version(
 		p.NetworkID,
 		p.Clock.Unix(),
@@ -512,7 +637,9 @@ func (p *peer) writeMessages() {
 		p.VersionCompatibility.Version().String(),
 		mySignedIP.IP.Timestamp,
 		mySignedIP.Signature,
-		p.MySubnets.List(),
+		myIPv6,
+		myIPv6Sig,
+		p.mySubnets().List(),
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -522,23 +649,25 @@ func (p *peer) writeMessages() {
 		return
 	}
 
-	p.writeMessage(writer, msg)
+	p.writeMessage(writers[LaneOf(msg.Op())], msg)
 
 	for {
 		msg, ok := p.messageQueue.PopNow()
 		if ok {
-			p.writeMessage(writer, msg)
+			p.writeMessage(writers[LaneOf(msg.Op())], msg)
 			continue
 		}
 
 		// Make sure the peer was fully sent all prior messages before
 		// blocking.
-		if err := writer.Flush(); err != nil {
-			p.Log.Verbo("failed to flush writer",
-				zap.Stringer("nodeID", p.id),
-				zap.Error(err),
-			)
-			return
+		for _, writer := range writers {
+			if err := writer.Flush(); err != nil {
+				p.Log.Verbo("failed to flush writer",
+					zap.Stringer("nodeID", p.id),
+					zap.Error(err),
+				)
+				return
+			}
 		}
 
 		msg, ok = p.messageQueue.Pop()
@@ -547,8 +676,28 @@ func (p *peer) writeMessages() {
 			return
 		}
 
-		p.writeMessage(writer, msg)
+		p.writeMessage(writers[LaneOf(msg.Op())], msg)
+	}
+}
+
+// newLaneWriters returns the writer of every lane. If [p.conn] is a
+// StreamConn, every lane is written to its own stream. Otherwise, all the
+// lanes share the same writer.
+func (p *peer) newLaneWriters() map[Lane]*bufio.Writer {
+	writers := make(map[Lane]*bufio.Writer, len(Lanes))
+	streamConn, ok := p.conn.(StreamConn)
+	if !ok {
+		writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
+		for _, lane := range Lanes {
+			writers[lane] = writer
+		}
+		return writers
+	}
+
+	for _, lane := range Lanes {
+		writers[lane] = bufio.NewWriterSize(streamConn.Stream(int(lane)), p.Config.WriteBufferSize)
 	}
+	return writers
 }
 
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
@@ -590,6 +739,10 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	atomic.StoreInt64(&p.Config.LastSent, now)
 	atomic.StoreInt64(&p.lastSent, now)
 	p.Metrics.Sent(msg)
+	p.traffic.sent(msg.Op(), int(msgLen))
+	if p.Recorder != nil {
+		p.Recorder.Record(Outbound, p.id, msg.Op(), p.Clock.Time(), msgBytes)
+	}
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -654,6 +807,7 @@ func (p *peer) sendNetworkMessages() {
 						zap.Stringer("peerVersion", p.version),
 						zap.Error(err),
 					)
+					p.Reputation.Penalize(p.id, IncompatibleVersionOffense)
 					return
 				}
 			}
@@ -870,6 +1024,7 @@ func (p *peer) handleVersion(msg *p2ppb.Version) {
 			zap.Stringer("peerVersion", peerVersion),
 			zap.Error(err),
 		)
+		p.Reputation.Penalize(p.id, IncompatibleVersionOffense)
 		p.StartClose()
 		return
 	}
@@ -887,6 +1042,7 @@ func (p *peer) handleVersion(msg *p2ppb.Version) {
 	}
 
 	// handle subnet IDs
+	mySubnets := p.mySubnets()
 	for _, subnetIDBytes := range msg.TrackedSubnets {
 		subnetID, err := ids.ToID(subnetIDBytes)
 		if err != nil {
@@ -898,11 +1054,14 @@ func (p *peer) handleVersion(msg *p2ppb.Version) {
 			return
 		}
 		// add only if we also track this subnet
-		if p.MySubnets.Contains(subnetID) {
+		if mySubnets.Contains(subnetID) {
 			p.trackedSubnets.Add(subnetID)
 		}
 	}
 
+	supportedCompressionTypes := message.DecodeCompressionTypes(msg.SupportedCompressionTypes)
+	p.supportsZstd.SetValue(supportedCompressionTypes.Contains(compression.TypeZstd))
+
 	// "net.IP" type in Golang is 16-byte
 	if ipLen := len(msg.IpAddr); ipLen != net.IPv6len {
 		p.Log.Debug("message with invalid field",
@@ -934,6 +1093,42 @@ func (p *peer) handleVersion(msg *p2ppb.Version) {
 		return
 	}
 
+	// Dual-stack peers also claim an IPv6 IP, signed at the same time as their
+	// primary IP.
+	if len(msg.Ipv6Addr) != 0 {
+		ipv6 := ips.IPPort{
+			IP:   net.IP(msg.Ipv6Addr),
+			Port: uint16(msg.Ipv6Port),
+		}
+		if !ipv6.IsIPv6() {
+			p.Log.Debug("message with invalid field",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.VersionOp),
+				zap.String("field", "IPv6"),
+				zap.Int("ipLen", len(msg.Ipv6Addr)),
+			)
+			p.StartClose()
+			return
+		}
+
+		p.ipv6 = &SignedIP{
+			IP: UnsignedIP{
+				IP:        ipv6,
+				Timestamp: msg.MyVersionTime,
+			},
+			Signature: msg.Ipv6Sig,
+		}
+		if err := p.ipv6.Verify(p.cert); err != nil {
+			p.Log.Debug("signature verification failed",
+				zap.Stringer("nodeID", p.id),
+				zap.String("field", "IPv6"),
+				zap.Error(err),
+			)
+			p.StartClose()
+			return
+		}
+	}
+
 	p.gotVersion.SetValue(true)
 
 	peerIPs, err := p.Network.Peers(p.id)
@@ -1054,7 +1249,7 @@ func (p *peer) handlePeerList(msg *p2ppb.PeerList) {
 		// gossip tracking. In addition to the racy behavior documented above,
 		// the gossip tracker should not care if the received IP was new. The
 		// gossip tracker only tracks if the peer knows the IP.
-		if !p.Network.Track(ip) {
+		if !p.Network.Track(p.id, ip) {
 			p.Metrics.NumUselessPeerListBytes.Add(float64(ip.BytesLen()))
 		}
 	}
@@ -11,24 +11,34 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/staking"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/math/meter"
 	"github.com/lasthyphen/dijetsnodego/utils/resource"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/version"
+
+	p2ppb "github.com/lasthyphen/dijetsnodego/proto/pb/p2p"
 )
 
 type testPeer struct {
@@ -50,7 +60,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -98,14 +108,17 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 		MessageCreator:       mc,
 		Log:                  logging.NoLog{},
 		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
+		SubnetThrottler:      throttling.NewNoSubnetBandwidthThrottler(),
 		VersionCompatibility: version.GetCompatibility(constants.LocalID),
-		MySubnets:            set.Set[ids.ID]{},
+		MySubnets:            utils.NewAtomicInterface(set.Set[ids.ID]{}),
 		Beacons:              validators.NewSet(),
 		NetworkID:            constants.LocalID,
 		PingFrequency:        constants.DefaultPingFrequency,
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+		Benchlist:            benchlist.NewNoBenchlist(),
+		Reputation:           NewNoReputation(),
 		GossipTracker:        gossipTracker,
 	}
 	peerConfig0 := sharedConfig
@@ -275,3 +288,330 @@ func TestSend(t *testing.T) {
 	err = peer1.AwaitClosed(context.Background())
 	require.NoError(err)
 }
+
+func TestInfoReportsTraffic(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t)
+	mc := newMessageCreator(t)
+
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	numBytes := json.Uint64(len(outboundGetMsg.Bytes()))
+
+	sent := peer0.Send(context.Background(), outboundGetMsg)
+	require.True(sent)
+
+	<-peer1.inboundMsgChan
+	received := peer1.Info().Traffic[message.GetOp.String()]
+	require.Equal(json.Uint64(1), received.NumReceived)
+	require.Equal(numBytes, received.ReceivedBytes)
+	require.Zero(received.NumSent)
+
+	require.Eventually(
+		func() bool {
+			sent := peer0.Info().Traffic[message.GetOp.String()]
+			return sent.NumSent == 1 && sent.SentBytes == numBytes
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+	require.Zero(peer0.Info().SendQueueLength)
+
+	peer1.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	require.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	require.NoError(err)
+}
+
+func TestSendZstd(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t)
+	require.True(peer0.Peer.(*peer).supportsZstd.GetValue())
+	require.True(peer1.Peer.(*peer).supportsZstd.GetValue())
+
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	outboundPutMsg, err := mc.Put(ids.Empty, 1, make([]byte, 1024))
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, outboundPutMsg.CompressionType())
+
+	sent := peer0.Send(context.Background(), outboundPutMsg)
+	require.True(sent)
+
+	inboundPutMsg := <-peer1.inboundMsgChan
+	require.Equal(message.PutOp, inboundPutMsg.Op())
+
+	peer1.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	require.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	require.NoError(err)
+}
+
+func TestSendZstdFallsBackToGzip(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(err)
+	rawPeer0.config.MessageCreator = mc
+
+	// The remote peer hasn't reported supporting zstd.
+	messageQueue := NewThrottledMessageQueue(
+		rawPeer0.config.Metrics,
+		rawPeer1.nodeID,
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+	p := &peer{
+		Config:       rawPeer0.config,
+		id:           rawPeer1.nodeID,
+		messageQueue: messageQueue,
+	}
+
+	outboundPutMsg, err := mc.Put(ids.Empty, 1, make([]byte, 1024))
+	require.NoError(err)
+	require.True(p.Send(context.Background(), outboundPutMsg))
+
+	queuedMsg, ok := messageQueue.PopNow()
+	require.True(ok)
+	require.Equal(compression.TypeGzip, queuedMsg.CompressionType())
+	require.Equal(message.PutOp, queuedMsg.Op())
+}
+
+func TestRejectOversizedMessage(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	peer0, peer1 := makeReadyTestPeers(t)
+
+	// Build a message that is small on the wire but decompresses into more
+	// than the maximum message size.
+	compressor, err := compression.NewGzipCompressor(2 * constants.DefaultMaxMessageSize)
+	require.NoError(err)
+	putBytes, err := proto.Marshal(&p2ppb.Message{
+		Message: &p2ppb.Message_Put{
+			Put: &p2ppb.Put{
+				ChainId:   ids.Empty[:],
+				Container: make([]byte, constants.DefaultMaxMessageSize),
+			},
+		},
+	})
+	require.NoError(err)
+	compressedPutBytes, err := compressor.Compress(putBytes)
+	require.NoError(err)
+	msgBytes, err := proto.Marshal(&p2ppb.Message{
+		Message: &p2ppb.Message_CompressedGzip{
+			CompressedGzip: compressedPutBytes,
+		},
+	})
+	require.NoError(err)
+
+	outboundMsg := message.NewMockOutboundMessage(ctrl)
+	outboundMsg.EXPECT().Bytes().Return(msgBytes).AnyTimes()
+	outboundMsg.EXPECT().Op().Return(message.PutOp).AnyTimes()
+	outboundMsg.EXPECT().CompressionType().Return(compression.TypeGzip).AnyTimes()
+	outboundMsg.EXPECT().BypassThrottling().Return(false).AnyTimes()
+	outboundMsg.EXPECT().BytesSavedCompression().Return(0).AnyTimes()
+
+	sent := peer0.Send(context.Background(), outboundMsg)
+	require.True(sent)
+
+	require.Eventually(
+		func() bool {
+			return peer1.Info().RejectedOversizedMessages == 1
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+
+	peer1.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	require.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	require.NoError(err)
+}
+
+func TestDropMessagesOverSubnetBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	mc := newMessageCreator(t)
+
+	gossipChainID := ids.GenerateTestID()
+	queryChainID := ids.GenerateTestID()
+	unthrottledChainID := ids.GenerateTestID()
+	gossipMsg, err := mc.AppGossip(gossipChainID, []byte{1})
+	require.NoError(err)
+	queryMsg, err := mc.Get(queryChainID, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	unthrottledMsg, err := mc.Get(unthrottledChainID, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	// The budget of each subnet only fits a single message. The budget of the
+	// gossip subnet is refilled too slowly for a second message to fit during
+	// the test, while the budget of the query subnet is refilled in 50ms.
+	gossipSubnetID := ids.GenerateTestID()
+	querySubnetID := ids.GenerateTestID()
+	subnetThrottler, err := throttling.NewSubnetBandwidthThrottler(
+		"",
+		prometheus.NewRegistry(),
+		map[ids.ID]throttling.BandwidthThrottlerConfig{
+			gossipSubnetID: {
+				RefillRate:   1,
+				MaxBurstSize: uint64(len(gossipMsg.Bytes())),
+			},
+			querySubnetID: {
+				RefillRate:   20 * uint64(len(queryMsg.Bytes())),
+				MaxBurstSize: uint64(len(queryMsg.Bytes())),
+			},
+		},
+	)
+	require.NoError(err)
+	subnetThrottler.RegisterChain(gossipChainID, gossipSubnetID)
+	subnetThrottler.RegisterChain(queryChainID, querySubnetID)
+	rawPeer1.config.SubnetThrottler = subnetThrottler
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	for _, msg := range []message.OutboundMessage{gossipMsg, gossipMsg, queryMsg, queryMsg, unthrottledMsg} {
+		require.True(peer0.Send(context.Background(), msg))
+	}
+
+	// The second gossip message is dropped, while the second query waits for
+	// the budget of its subnet to refill.
+	chainIDs := make([]ids.ID, 0, 4)
+	for i := 0; i < 4; i++ {
+		msg := <-rawPeer1.inboundMsgChan
+		chainID, err := message.GetChainID(msg.Message())
+		require.NoError(err)
+		chainIDs = append(chainIDs, chainID)
+	}
+	require.Equal([]ids.ID{gossipChainID, queryChainID, queryChainID, unthrottledChainID}, chainIDs)
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+// exceededInboundMsgThrottler reports that every message read once [exceeded]
+// is set exceeded its sender's bandwidth allocation.
+type exceededInboundMsgThrottler struct {
+	throttling.InboundMsgThrottler
+	exceeded utils.AtomicBool
+}
+
+func (t *exceededInboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) (throttling.ReleaseFunc, bool) {
+	release, _ := t.InboundMsgThrottler.Acquire(ctx, msgSize, nodeID)
+	return release, t.exceeded.GetValue()
+}
+
+func TestPenalizeExceededBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	mc := newMessageCreator(t)
+
+	reputation, err := NewReputation(time.Hour, "", prometheus.NewRegistry())
+	require.NoError(err)
+	throttler := &exceededInboundMsgThrottler{
+		InboundMsgThrottler: throttling.NewNoInboundThrottler(),
+	}
+	beacons := validators.NewSet()
+	rawPeer1.config.Reputation = reputation
+	rawPeer1.config.InboundMsgThrottler = throttler
+	rawPeer1.config.Beacons = beacons
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+	throttler.exceeded.SetValue(true)
+
+	putMsg, err := mc.Put(ids.Empty, 1, []byte{1})
+	require.NoError(err)
+	getMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	// Responses are sent at the rate this node requests them.
+	require.True(peer0.Send(context.Background(), putMsg))
+	<-rawPeer1.inboundMsgChan
+	require.Zero(reputation.Penalty(rawPeer0.nodeID))
+
+	// Unrequested messages are counted against the sender.
+	require.True(peer0.Send(context.Background(), getMsg))
+	<-rawPeer1.inboundMsgChan
+	require.InDelta(ThrottledOffense.Weight(), reputation.Penalty(rawPeer0.nodeID), 0.01)
+
+	// Beacons are never penalized.
+	require.NoError(beacons.Add(rawPeer0.nodeID, nil, ids.Empty, 1))
+	require.True(peer0.Send(context.Background(), getMsg))
+	<-rawPeer1.inboundMsgChan
+	require.InDelta(ThrottledOffense.Weight(), reputation.Penalty(rawPeer0.nodeID), 0.01)
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/utils/buffer"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+)
+
+var _ MessageQueue = (*priorityMessageQueue)(nil)
+
+// LaneConfig is the state of a lane that is shared by the priority message
+// queues of all peers.
+type LaneConfig struct {
+	// Limits the number of bytes that can be queued in this lane.
+	Throttler throttling.OutboundMsgThrottler
+	Metrics   *LaneMetrics
+}
+
+type lane struct {
+	config  *LaneConfig
+	quantum int
+
+	// deficit is the number of bytes this lane may still send in the current
+	// scheduling round.
+	deficit int
+
+	queue buffer.Deque[message.OutboundMessage]
+}
+
+// priorityMessageQueue queues messages in separate lanes based on their type.
+// Lanes are scheduled with deficit round robin, so that every lane with queued
+// messages is allowed to send bytes proportionally to its weight. This
+// prevents large bootstrapping or VM messages from delaying latency-critical
+// consensus messages.
+type priorityMessageQueue struct {
+	onFailed SendFailedCallback
+	// [id] of the peer we're sending messages to
+	id  ids.NodeID
+	log logging.Logger
+
+	// Signalled when a message is added to the queue and when Close() is
+	// called.
+	cond *sync.Cond
+
+	// closed flags whether the send queue has been closed.
+	// [cond.L] must be held while accessing [closed].
+	closed bool
+
+	// [cond.L] must be held while accessing any of the below fields.
+	lanes map[Lane]*lane
+	// index into [Lanes] of the lane currently being scheduled
+	current int
+	// true if the current lane has been given its quantum for this round
+	currentFunded bool
+	// total number of messages in all the lanes
+	numMsgs int
+}
+
+// NewPriorityMessageQueue returns a message queue that schedules messages
+// by lane. [lanes] must contain a config for every lane in [Lanes].
+func NewPriorityMessageQueue(
+	onFailed SendFailedCallback,
+	id ids.NodeID,
+	log logging.Logger,
+	lanes map[Lane]*LaneConfig,
+) MessageQueue {
+	q := &priorityMessageQueue{
+		onFailed: onFailed,
+		id:       id,
+		log:      log,
+		cond:     sync.NewCond(&sync.Mutex{}),
+		lanes:    make(map[Lane]*lane, len(Lanes)),
+	}
+	for _, l := range Lanes {
+		q.lanes[l] = &lane{
+			config:  lanes[l],
+			quantum: lanesParams[l].weight * laneQuantum,
+			queue:   buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
+		}
+	}
+	return q
+}
+
+func (q *priorityMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
+	if err := ctx.Err(); err != nil {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+			zap.Error(err),
+		)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	laneID := LaneOf(msg.Op())
+	l := q.lanes[laneID]
+
+	// Acquire space on the lane, or drop [msg] if we can't.
+	if !l.config.Throttler.Acquire(msg, q.id) {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("lane", laneID),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		l.config.Metrics.NumThrottled.Inc()
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	// Invariant: must call l.config.Throttler.Release(msg, q.id) when [msg]
+	// is popped or, if this queue closes before [msg] is popped, when this
+	// queue closes.
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "closed queue"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		l.config.Throttler.Release(msg, q.id)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	l.queue.PushRight(msg)
+	l.config.Metrics.QueuedMsgs.Inc()
+	l.config.Metrics.QueuedBytes.Add(float64(len(msg.Bytes())))
+	q.numMsgs++
+	q.cond.Signal()
+	return true
+}
+
+func (q *priorityMessageQueue) Pop() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if q.numMsgs > 0 {
+			// There is a message
+			break
+		}
+		// Wait until there is a message
+		q.cond.Wait()
+	}
+
+	return q.pop(), true
+}
+
+func (q *priorityMessageQueue) PopNow() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed || q.numMsgs == 0 {
+		// There isn't a message
+		return nil, false
+	}
+
+	return q.pop(), true
+}
+
+// pop returns the next message to send.
+//
+// Invariant: [numMsgs] must be greater than 0.
+func (q *priorityMessageQueue) pop() message.OutboundMessage {
+	for {
+		l := q.lanes[Lanes[q.current]]
+		if l.queue.Len() == 0 {
+			// Idle lanes don't accumulate credit.
+			l.deficit = 0
+			q.nextLane()
+			continue
+		}
+
+		if !q.currentFunded {
+			l.deficit += l.quantum
+			q.currentFunded = true
+		}
+
+		msg, _ := l.queue.PeekLeft()
+		msgLen := len(msg.Bytes())
+		if msgLen > l.deficit {
+			// This lane has used its quantum for this round.
+			q.nextLane()
+			continue
+		}
+
+		_, _ = l.queue.PopLeft()
+		l.deficit -= msgLen
+		q.numMsgs--
+
+		l.config.Throttler.Release(msg, q.id)
+		l.config.Metrics.QueuedMsgs.Dec()
+		l.config.Metrics.QueuedBytes.Sub(float64(msgLen))
+		l.config.Metrics.NumSent.Inc()
+		l.config.Metrics.SentBytes.Add(float64(msgLen))
+		return msg
+	}
+}
+
+func (q *priorityMessageQueue) nextLane() {
+	q.current = (q.current + 1) % len(Lanes)
+	q.currentFunded = false
+}
+
+func (q *priorityMessageQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.numMsgs
+}
+
+func (q *priorityMessageQueue) Close() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+
+	for _, l := range q.lanes {
+		for l.queue.Len() > 0 {
+			msg, _ := l.queue.PopLeft()
+			l.config.Throttler.Release(msg, q.id)
+			l.config.Metrics.QueuedMsgs.Dec()
+			l.config.Metrics.QueuedBytes.Sub(float64(len(msg.Bytes())))
+			q.onFailed.SendFailed(msg)
+		}
+		l.queue = nil
+	}
+	q.numMsgs = 0
+
+	q.cond.Broadcast()
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+func newTestLanes(t *testing.T, throttler throttling.OutboundMsgThrottler) map[Lane]*LaneConfig {
+	t.Helper()
+
+	registerer := prometheus.NewRegistry()
+	errs := wrappers.Errs{}
+	lanes := make(map[Lane]*LaneConfig, len(Lanes))
+	for _, lane := range Lanes {
+		lanes[lane] = &LaneConfig{
+			Throttler: throttler,
+			Metrics:   NewLaneMetrics(lane, "", registerer, &errs),
+		}
+	}
+	require.NoError(t, errs.Err)
+	return lanes
+}
+
+func newUncompressedMessageCreator(t *testing.T) message.Creator {
+	t.Helper()
+
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+func gaugeValue(t *testing.T, g prometheus.Gauge) float64 {
+	t.Helper()
+
+	m := &dto.Metric{}
+	require.NoError(t, g.Write(m))
+	return m.GetGauge().GetValue()
+}
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+
+	m := &dto.Metric{}
+	require.NoError(t, c.Write(m))
+	return m.GetCounter().GetValue()
+}
+
+func TestLaneOf(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(HandshakeLane, LaneOf(message.PingOp))
+	require.Equal(HandshakeLane, LaneOf(message.VersionOp))
+	require.Equal(ConsensusLane, LaneOf(message.PushQueryOp))
+	require.Equal(ConsensusLane, LaneOf(message.ChitsOp))
+	require.Equal(BootstrapLane, LaneOf(message.AncestorsOp))
+	require.Equal(BootstrapLane, LaneOf(message.GetAcceptedFrontierOp))
+	require.Equal(AppLane, LaneOf(message.AppGossipOp))
+	require.Equal(AppLane, LaneOf(message.AppRequestOp))
+}
+
+func TestPriorityMessageQueueSchedulesByLane(t *testing.T) {
+	require := require.New(t)
+
+	mc := newUncompressedMessageCreator(t)
+	lanes := newTestLanes(t, throttling.NewNoOutboundThrottler())
+	q := NewPriorityMessageQueue(
+		SendFailedFunc(func(message.OutboundMessage) {
+			require.FailNow("unexpected failed send")
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		lanes,
+	)
+
+	// Queue enough bootstrapping messages to exceed the bootstrap lane's
+	// quantum many times over.
+	numAncestors := 10
+	ancestorsMsgs := make([]message.OutboundMessage, numAncestors)
+	for i := range ancestorsMsgs {
+		msg, err := mc.Ancestors(ids.Empty, uint32(i), [][]byte{make([]byte, 2*laneQuantum)})
+		require.NoError(err)
+		ancestorsMsgs[i] = msg
+		require.True(q.Push(context.Background(), msg))
+	}
+
+	chitsMsg, err := mc.Chits(ids.Empty, 0, []ids.ID{ids.Empty})
+	require.NoError(err)
+	require.True(q.Push(context.Background(), chitsMsg))
+
+	require.Equal(numAncestors+1, q.Len())
+
+	// The consensus message is sent before most of the bootstrapping messages
+	// even though it was queued last.
+	numPopped := 0
+	for {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		numPopped++
+		if msg == chitsMsg {
+			break
+		}
+	}
+	require.Less(numPopped, numAncestors)
+
+	// The bootstrapping messages are still sent in order.
+	nextAncestors := numPopped - 1
+	for {
+		msg, ok := q.PopNow()
+		if !ok {
+			break
+		}
+		require.Equal(ancestorsMsgs[nextAncestors], msg)
+		nextAncestors++
+	}
+	require.Equal(numAncestors, nextAncestors)
+	require.Zero(q.Len())
+
+	require.Equal(float64(0), gaugeValue(t, lanes[BootstrapLane].Metrics.QueuedMsgs))
+	require.Equal(float64(numAncestors), counterValue(t, lanes[BootstrapLane].Metrics.NumSent))
+	require.Equal(float64(1), counterValue(t, lanes[ConsensusLane].Metrics.NumSent))
+}
+
+func TestPriorityMessageQueueLaneByteLimit(t *testing.T) {
+	require := require.New(t)
+
+	mc := newUncompressedMessageCreator(t)
+
+	vdrs := validators.NewSet()
+	throttler, err := throttling.NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		vdrs,
+		throttling.MsgByteThrottlerConfig{
+			AtLargeAllocSize:    1024,
+			NodeMaxAtLargeBytes: 1024,
+		},
+	)
+	require.NoError(err)
+
+	var failed []message.OutboundMessage
+	lanes := newTestLanes(t, throttler)
+	q := NewPriorityMessageQueue(
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			failed = append(failed, msg)
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		lanes,
+	)
+
+	appGossipMsg, err := mc.AppGossip(ids.Empty, make([]byte, 2048))
+	require.NoError(err)
+	require.False(q.Push(context.Background(), appGossipMsg))
+	require.Equal([]message.OutboundMessage{appGossipMsg}, failed)
+	require.Equal(float64(1), counterValue(t, lanes[AppLane].Metrics.NumThrottled))
+
+	pingMsg, err := mc.Ping()
+	require.NoError(err)
+	require.True(q.Push(context.Background(), pingMsg))
+
+	// Closing the queue fails the queued messages.
+	q.Close()
+	require.Equal([]message.OutboundMessage{appGossipMsg, pingMsg}, failed)
+
+	_, ok := q.Pop()
+	require.False(ok)
+	require.False(q.Push(context.Background(), pingMsg))
+}
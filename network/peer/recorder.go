@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+// CaptureFileName is the name of the file that messages are currently being
+// captured to. Rotated capture files are kept next to it, with the time they
+// were rotated added to their name.
+const CaptureFileName = "capture.bin"
+
+const nodeIDLen = len(ids.NodeID{})
+
+// captureQueueSize is the number of captured messages that may be waiting to
+// be written before new messages are dropped.
+const captureQueueSize = 1024
+
+// maxCapturedMessageLen is the maximum length of a captured message entry: the
+// direction, the node ID, the op, the timestamp, and the message.
+const maxCapturedMessageLen = wrappers.ByteLen + nodeIDLen + wrappers.ByteLen +
+	wrappers.LongLen + wrappers.IntLen + constants.DefaultMaxMessageSize
+
+var (
+	_ Recorder = (*fileRecorder)(nil)
+
+	errInvalidDirection      = errors.New("invalid message direction")
+	errCapturedMessageTooBig = errors.New("captured message is too large")
+)
+
+// Direction is the direction of a message relative to this node.
+type Direction byte
+
+const (
+	Inbound Direction = iota
+	Outbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
+
+// Recorder records the messages exchanged with peers.
+type Recorder interface {
+	// Record records that [msgBytes], a message of type [op], was exchanged
+	// with [nodeID] at [timestamp]. [msgBytes] are the bytes that were sent
+	// over the wire.
+	Record(
+		direction Direction,
+		nodeID ids.NodeID,
+		op message.Op,
+		timestamp time.Time,
+		msgBytes []byte,
+	)
+
+	// Close flushes and closes the capture.
+	Close() error
+}
+
+// CapturedMessage is a message read from a capture.
+type CapturedMessage struct {
+	Direction Direction
+	NodeID    ids.NodeID
+	Op        message.Op
+	Timestamp time.Time
+	Bytes     []byte
+}
+
+type fileRecorder struct {
+	log    logging.Logger
+	writer io.WriteCloser
+
+	// entries are written to [writer] in the background, so that a slow disk
+	// never blocks the peers recording messages. If [entries] is full, new
+	// entries are dropped.
+	entries chan []byte
+	// done is closed once every entry in [entries] has been written.
+	done chan struct{}
+
+	// closeLock guards closing [entries].
+	closeLock sync.RWMutex
+	closed    bool
+}
+
+// NewFileRecorder returns a recorder that writes messages to [CaptureFileName]
+// in [dir]. Once the capture file reaches [maxSize] megabytes, it is rotated.
+// At most [maxFiles] rotated capture files are kept. If [maxFiles] is 0, all
+// rotated capture files are kept.
+func NewFileRecorder(log logging.Logger, dir string, maxSize int, maxFiles int) Recorder {
+	return newFileRecorder(
+		log,
+		&lumberjack.Logger{
+			Filename:   filepath.Join(dir, CaptureFileName),
+			MaxSize:    maxSize,  // megabytes
+			MaxBackups: maxFiles, // files
+		},
+		captureQueueSize,
+	)
+}
+
+func newFileRecorder(log logging.Logger, writer io.WriteCloser, queueSize int) *fileRecorder {
+	r := &fileRecorder{
+		log:     log,
+		writer:  writer,
+		entries: make(chan []byte, queueSize),
+		done:    make(chan struct{}),
+	}
+	go r.write()
+	return r
+}
+
+func (r *fileRecorder) Record(
+	direction Direction,
+	nodeID ids.NodeID,
+	op message.Op,
+	timestamp time.Time,
+	msgBytes []byte,
+) {
+	entry, err := packCapturedMessage(&CapturedMessage{
+		Direction: direction,
+		NodeID:    nodeID,
+		Op:        op,
+		Timestamp: timestamp,
+		Bytes:     msgBytes,
+	})
+	if err != nil {
+		r.log.Warn("failed to pack captured message",
+			zap.Stringer("direction", direction),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("messageOp", op),
+			zap.Error(err),
+		)
+		return
+	}
+
+	r.closeLock.RLock()
+	defer r.closeLock.RUnlock()
+
+	if r.closed {
+		return
+	}
+	select {
+	case r.entries <- entry:
+	default:
+		r.log.Debug("dropping captured message",
+			zap.Stringer("direction", direction),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("messageOp", op),
+		)
+	}
+}
+
+// write writes the queued entries to [r.writer] until [r.entries] is closed.
+func (r *fileRecorder) write() {
+	defer close(r.done)
+
+	failing := false
+	for entry := range r.entries {
+		// Each entry is written with a single call so that it is never split
+		// across rotated files.
+		_, err := r.writer.Write(entry)
+		switch {
+		case err != nil && !failing:
+			// Only the first of consecutive failures is logged, so that a full
+			// disk doesn't flood the logs.
+			r.log.Warn("failed to write captured message",
+				zap.Error(err),
+			)
+		case err == nil && failing:
+			r.log.Info("resumed writing captured messages")
+		}
+		failing = err != nil
+	}
+}
+
+func (r *fileRecorder) Close() error {
+	r.closeLock.Lock()
+	if r.closed {
+		r.closeLock.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.entries)
+	r.closeLock.Unlock()
+
+	<-r.done
+	return r.writer.Close()
+}
+
+// packCapturedMessage returns [msg] prefixed with its length.
+func packCapturedMessage(msg *CapturedMessage) ([]byte, error) {
+	entryLen := wrappers.ByteLen + nodeIDLen + wrappers.ByteLen +
+		wrappers.LongLen + wrappers.IntLen + len(msg.Bytes)
+	p := wrappers.Packer{
+		MaxSize: wrappers.IntLen + entryLen,
+		Bytes:   make([]byte, 0, wrappers.IntLen+entryLen),
+	}
+	p.PackInt(uint32(entryLen))
+	p.PackByte(byte(msg.Direction))
+	p.PackFixedBytes(msg.NodeID[:])
+	p.PackByte(byte(msg.Op))
+	p.PackLong(uint64(msg.Timestamp.UnixNano()))
+	p.PackBytes(msg.Bytes)
+	return p.Bytes, p.Err
+}
+
+// CaptureReader reads the messages written by a [Recorder] created with
+// NewFileRecorder. To read a rotated capture, the capture files should be
+// concatenated in the order they were written, for example with
+// io.MultiReader.
+type CaptureReader struct {
+	reader io.Reader
+}
+
+func NewCaptureReader(reader io.Reader) *CaptureReader {
+	return &CaptureReader{
+		reader: reader,
+	}
+}
+
+// Next returns the next captured message. Returns io.EOF once all the
+// messages have been read.
+func (r *CaptureReader) Next() (*CapturedMessage, error) {
+	lenBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(r.reader, lenBytes); err != nil {
+		return nil, err
+	}
+	lenPacker := wrappers.Packer{Bytes: lenBytes}
+	entryLen := int(lenPacker.UnpackInt())
+	if entryLen > maxCapturedMessageLen {
+		return nil, fmt.Errorf("%w: %d > %d", errCapturedMessageTooBig, entryLen, maxCapturedMessageLen)
+	}
+
+	entry := make([]byte, entryLen)
+	if _, err := io.ReadFull(r.reader, entry); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	p := wrappers.Packer{Bytes: entry}
+	msg := &CapturedMessage{
+		Direction: Direction(p.UnpackByte()),
+	}
+	copy(msg.NodeID[:], p.UnpackFixedBytes(nodeIDLen))
+	msg.Op = message.Op(p.UnpackByte())
+	msg.Timestamp = time.Unix(0, int64(p.UnpackLong()))
+	msg.Bytes = p.UnpackBytes()
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if msg.Direction != Inbound && msg.Direction != Outbound {
+		return nil, fmt.Errorf("%w: %d", errInvalidDirection, msg.Direction)
+	}
+	return msg, nil
+}
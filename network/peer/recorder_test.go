@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestRecorderCapturesMessages(t *testing.T) {
+	require := require.New(t)
+
+	buf := &bytes.Buffer{}
+	recorder := newFileRecorder(logging.NoLog{}, nopWriteCloser{buf}, captureQueueSize)
+
+	expected := []*CapturedMessage{
+		{
+			Direction: Inbound,
+			NodeID:    ids.GenerateTestNodeID(),
+			Op:        message.PushQueryOp,
+			Timestamp: time.Unix(1, 2),
+			Bytes:     []byte{1, 2, 3},
+		},
+		{
+			Direction: Outbound,
+			NodeID:    ids.GenerateTestNodeID(),
+			Op:        message.ChitsOp,
+			Timestamp: time.Unix(3, 4),
+			Bytes:     []byte{},
+		},
+	}
+	for _, msg := range expected {
+		recorder.Record(msg.Direction, msg.NodeID, msg.Op, msg.Timestamp, msg.Bytes)
+	}
+	require.NoError(recorder.Close())
+
+	// Messages aren't recorded once the recorder is closed.
+	recorder.Record(Inbound, ids.GenerateTestNodeID(), message.PingOp, time.Unix(5, 6), nil)
+
+	reader := NewCaptureReader(buf)
+	for _, expectedMsg := range expected {
+		msg, err := reader.Next()
+		require.NoError(err)
+		require.Equal(expectedMsg.Direction, msg.Direction)
+		require.Equal(expectedMsg.NodeID, msg.NodeID)
+		require.Equal(expectedMsg.Op, msg.Op)
+		require.True(expectedMsg.Timestamp.Equal(msg.Timestamp))
+		require.Equal(expectedMsg.Bytes, msg.Bytes)
+	}
+	_, err := reader.Next()
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestCaptureReaderTruncatedEntry(t *testing.T) {
+	require := require.New(t)
+
+	entry, err := packCapturedMessage(&CapturedMessage{
+		Direction: Inbound,
+		Bytes:     []byte{1, 2, 3},
+	})
+	require.NoError(err)
+
+	reader := NewCaptureReader(bytes.NewReader(entry[:len(entry)-1]))
+	_, err = reader.Next()
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
+func TestFileRecorderRotatesWholeMessages(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	recorder := NewFileRecorder(logging.NoLog{}, dir, 1, 0)
+
+	// Each message is larger than half of the maximum file size, so the
+	// capture is rotated before the second message is written.
+	msgBytes := make([]byte, 600*units.KiB)
+	nodeID := ids.GenerateTestNodeID()
+	recorder.Record(Inbound, nodeID, message.AncestorsOp, time.Unix(1, 0), msgBytes)
+	recorder.Record(Inbound, nodeID, message.AncestorsOp, time.Unix(2, 0), msgBytes)
+	require.NoError(recorder.Close())
+
+	rotatedFiles, err := filepath.Glob(filepath.Join(dir, "capture-*.bin"))
+	require.NoError(err)
+	require.Len(rotatedFiles, 1)
+
+	files := []string{rotatedFiles[0], filepath.Join(dir, CaptureFileName)}
+	for i, file := range files {
+		f, err := os.Open(file)
+		require.NoError(err)
+
+		reader := NewCaptureReader(f)
+		msg, err := reader.Next()
+		require.NoError(err)
+		require.Equal(int64(i+1), msg.Timestamp.Unix())
+		require.Len(msg.Bytes, len(msgBytes))
+
+		_, err = reader.Next()
+		require.ErrorIs(err, io.EOF)
+		require.NoError(f.Close())
+	}
+}
+
+// blockingWriteCloser blocks every write until [unblock] is closed.
+type blockingWriteCloser struct {
+	io.Writer
+	unblock chan struct{}
+}
+
+func (w blockingWriteCloser) Write(b []byte) (int, error) {
+	<-w.unblock
+	return w.Writer.Write(b)
+}
+
+func (blockingWriteCloser) Close() error {
+	return nil
+}
+
+func TestRecorderDropsMessagesWhenFull(t *testing.T) {
+	require := require.New(t)
+
+	buf := &bytes.Buffer{}
+	writer := blockingWriteCloser{
+		Writer:  buf,
+		unblock: make(chan struct{}),
+	}
+	recorder := newFileRecorder(logging.NoLog{}, writer, 1)
+
+	// Recording never blocks, even though nothing can be written.
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 10; i++ {
+		recorder.Record(Inbound, nodeID, message.PingOp, time.Unix(int64(i), 0), nil)
+	}
+	close(writer.unblock)
+	require.NoError(recorder.Close())
+
+	// At most the message being written and the queued message are captured.
+	reader := NewCaptureReader(buf)
+	numCaptured := 0
+	for {
+		_, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		numCaptured++
+	}
+	require.Positive(numCaptured)
+	require.LessOrEqual(numCaptured, 2)
+}
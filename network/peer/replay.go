@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
+)
+
+// Replay parses the inbound messages read from [reader] with [msgCreator] and
+// passes them to [handler] in the order they were captured. Outbound messages
+// are skipped. Returns the number of messages that were replayed.
+//
+// If [paced] is true, the messages are spaced out by the time that passed
+// between their captures, starting from the first replayed message. Otherwise,
+// they're replayed as quickly as [handler] accepts them.
+//
+// [handler] is typically the router.ChainRouter of a test node. To bypass the
+// router's request tracking, the messages can be pushed directly into a chain's
+// handler.Handler by wrapping its Push method with router.InboundHandlerFunc.
+func Replay(
+	ctx context.Context,
+	reader *CaptureReader,
+	msgCreator message.InboundMsgBuilder,
+	handler router.InboundHandler,
+	paced bool,
+) (int, error) {
+	var (
+		numReplayed int
+		// Time the first replayed message was captured and replayed at
+		// respectively. Only used if [paced] is true.
+		firstCaptureTime, firstReplayTime time.Time
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return numReplayed, err
+		}
+
+		captured, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return numReplayed, nil
+		}
+		if err != nil {
+			return numReplayed, err
+		}
+		if captured.Direction != Inbound {
+			continue
+		}
+
+		if paced {
+			if numReplayed == 0 {
+				firstCaptureTime = captured.Timestamp
+				firstReplayTime = time.Now()
+			}
+			replayTime := firstReplayTime.Add(captured.Timestamp.Sub(firstCaptureTime))
+			if err := waitUntil(ctx, replayTime); err != nil {
+				return numReplayed, err
+			}
+		}
+
+		// The message is parsed once it's due, so that its deadline is
+		// relative to when it's replayed.
+		msg, err := msgCreator.Parse(captured.Bytes, captured.NodeID, func() {})
+		if err != nil {
+			return numReplayed, err
+		}
+		handler.HandleInbound(ctx, msg)
+		numReplayed++
+	}
+}
+
+// waitUntil returns once [t] has passed, or with the context's error if [ctx]
+// is done first.
+func waitUntil(ctx context.Context, t time.Time) error {
+	delay := time.Until(t)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/handler"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/timeout"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/math/meter"
+	"github.com/lasthyphen/dijetsnodego/utils/resource"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/utils/timer"
+)
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	buf := &bytes.Buffer{}
+	recorder := newFileRecorder(logging.NoLog{}, nopWriteCloser{buf}, captureQueueSize)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+
+	// Inbound messages are recorded as they were read from the wire, which
+	// is how the peer sent them.
+	pushQuery, err := mc.PushQuery(chainID, 1, time.Second, []byte{1})
+	require.NoError(err)
+	recorder.Record(Inbound, nodeID, message.PushQueryOp, time.Unix(1, 0), pushQuery.Bytes())
+
+	chits, err := mc.Chits(chainID, 2, []ids.ID{ids.GenerateTestID()})
+	require.NoError(err)
+	recorder.Record(Outbound, nodeID, message.ChitsOp, time.Unix(2, 0), chits.Bytes())
+
+	put, err := mc.Put(chainID, 3, []byte{2})
+	require.NoError(err)
+	recorder.Record(Inbound, nodeID, message.PutOp, time.Unix(3, 0), put.Bytes())
+	require.NoError(recorder.Close())
+
+	var replayed []message.InboundMessage
+	handler := router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
+		replayed = append(replayed, msg)
+	})
+	numReplayed, err := Replay(context.Background(), NewCaptureReader(buf), mc, handler, false)
+	require.NoError(err)
+	require.Equal(2, numReplayed)
+
+	require.Len(replayed, 2)
+	require.Equal(message.PushQueryOp, replayed[0].Op())
+	require.Equal(nodeID, replayed[0].NodeID())
+	require.Equal(message.PutOp, replayed[1].Op())
+	require.Equal(nodeID, replayed[1].NodeID())
+}
+
+func TestReplayPaced(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	buf := &bytes.Buffer{}
+	recorder := newFileRecorder(logging.NoLog{}, nopWriteCloser{buf}, captureQueueSize)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+
+	// The capture starts long before the first inbound message, which
+	// shouldn't delay the replay.
+	chits, err := mc.Chits(chainID, 1, []ids.ID{ids.GenerateTestID()})
+	require.NoError(err)
+	recorder.Record(Outbound, nodeID, message.ChitsOp, time.Unix(0, 0), chits.Bytes())
+
+	captureTime := time.Unix(100, 0)
+	pacing := 50 * time.Millisecond
+	for i := 0; i < 3; i++ {
+		put, err := mc.Put(chainID, uint32(i), []byte{byte(i)})
+		require.NoError(err)
+		recorder.Record(Inbound, nodeID, message.PutOp, captureTime.Add(time.Duration(i)*pacing), put.Bytes())
+	}
+	require.NoError(recorder.Close())
+
+	var replayTimes []time.Time
+	handler := router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+		replayTimes = append(replayTimes, time.Now())
+	})
+	startTime := time.Now()
+	numReplayed, err := Replay(context.Background(), NewCaptureReader(buf), mc, handler, true)
+	require.NoError(err)
+	require.Equal(3, numReplayed)
+
+	require.Len(replayTimes, 3)
+	require.Less(replayTimes[0].Sub(startTime), pacing)
+	require.GreaterOrEqual(replayTimes[1].Sub(replayTimes[0]), pacing)
+	require.GreaterOrEqual(replayTimes[2].Sub(replayTimes[0]), 2*pacing)
+}
+
+func TestReplayPacedCanceled(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	buf := &bytes.Buffer{}
+	recorder := newFileRecorder(logging.NoLog{}, nopWriteCloser{buf}, captureQueueSize)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	for i := 0; i < 2; i++ {
+		put, err := mc.Put(chainID, uint32(i), []byte{byte(i)})
+		require.NoError(err)
+		recorder.Record(Inbound, nodeID, message.PutOp, time.Unix(int64(i)*3600, 0), put.Bytes())
+	}
+	require.NoError(recorder.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+		// The second message is due an hour later, so the replay is canceled
+		// while waiting for it.
+		cancel()
+	})
+	numReplayed, err := Replay(ctx, NewCaptureReader(buf), mc, handler, true)
+	require.ErrorIs(err, context.Canceled)
+	require.Equal(1, numReplayed)
+}
+
+func TestReplayIntoChainRouter(t *testing.T) {
+	require := require.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     10 * time.Millisecond,
+			MinimumTimeout:     10 * time.Millisecond,
+			MaximumTimeout:     25 * time.Millisecond,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go tm.Dispatch()
+
+	chainRouter := &router.ChainRouter{}
+	err = chainRouter.Initialize(
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		tm,
+		time.Millisecond,
+		set.Set[ids.ID]{},
+		set.Set[ids.ID]{},
+		nil,
+		router.HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	chainHandler, err := handler.New(
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+	)
+	require.NoError(err)
+
+	pushQueries := make(chan uint32, 2)
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+	bootstrapper.PushQueryF = func(_ context.Context, _ ids.NodeID, requestID uint32, _ []byte) error {
+		pushQueries <- requestID
+		return nil
+	}
+	bootstrapper.PutF = func(context.Context, ids.NodeID, uint32, []byte) error {
+		t.Error("unrequested Put wasn't dropped by the router")
+		return nil
+	}
+	chainHandler.SetBootstrapper(bootstrapper)
+	ctx.SetState(snow.Bootstrapping)
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	chainHandler.SetConsensus(engine)
+
+	chainRouter.AddChain(context.Background(), chainHandler)
+	chainHandler.Start(context.Background(), false)
+	defer chainRouter.Shutdown(context.Background())
+
+	mc := newMessageCreator(t)
+	buf := &bytes.Buffer{}
+	recorder := newFileRecorder(logging.NoLog{}, nopWriteCloser{buf}, captureQueueSize)
+
+	nodeID := ids.GenerateTestNodeID()
+	pushQuery, err := mc.PushQuery(ctx.ChainID, 1, time.Hour, []byte{1})
+	require.NoError(err)
+	recorder.Record(Inbound, nodeID, message.PushQueryOp, time.Unix(1, 0), pushQuery.Bytes())
+
+	// This node never requested the container, so the router drops it.
+	put, err := mc.Put(ctx.ChainID, 2, []byte{2})
+	require.NoError(err)
+	recorder.Record(Inbound, nodeID, message.PutOp, time.Unix(2, 0), put.Bytes())
+
+	pushQuery, err = mc.PushQuery(ctx.ChainID, 3, time.Hour, []byte{3})
+	require.NoError(err)
+	recorder.Record(Inbound, nodeID, message.PushQueryOp, time.Unix(3, 0), pushQuery.Bytes())
+	require.NoError(recorder.Close())
+
+	numReplayed, err := Replay(context.Background(), NewCaptureReader(buf), mc, chainRouter, false)
+	require.NoError(err)
+	require.Equal(3, numReplayed)
+
+	// The chain handles messages in order, so the dropped Put would have been
+	// handled between the two queries.
+	require.Equal(uint32(1), <-pushQueries)
+	require.Equal(uint32(3), <-pushQueries)
+}
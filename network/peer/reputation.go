@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
+)
+
+// Penalties that decayed below this value are forgotten.
+const minPenalty = 0.01
+
+var _ Reputation = (*reputation)(nil)
+
+// Offense is a misbehavior of a peer that is counted against its reputation.
+type Offense byte
+
+const (
+	// BenchedOffense is committed by peers that were benched on a chain
+	// because they repeatedly failed to respond to queries.
+	BenchedOffense Offense = iota
+	// InvalidMessageOffense is committed by peers that sent a message that
+	// couldn't be parsed.
+	InvalidMessageOffense
+	// ThrottledOffense is committed by peers that sent messages faster than
+	// their inbound bandwidth allocation.
+	ThrottledOffense
+	// InvalidSignatureOffense is committed by peers that gossiped an IP with
+	// an invalid signature.
+	InvalidSignatureOffense
+	// IncompatibleVersionOffense is committed by peers that attempted to
+	// connect with a version we don't support.
+	IncompatibleVersionOffense
+)
+
+func (o Offense) String() string {
+	switch o {
+	case BenchedOffense:
+		return "benched"
+	case InvalidMessageOffense:
+		return "invalid_message"
+	case ThrottledOffense:
+		return "throttled"
+	case InvalidSignatureOffense:
+		return "invalid_signature"
+	case IncompatibleVersionOffense:
+		return "incompatible_version"
+	default:
+		return "unknown"
+	}
+}
+
+// Weight is the penalty a peer receives when it commits this offense.
+func (o Offense) Weight() float64 {
+	switch o {
+	case BenchedOffense:
+		return 5
+	case InvalidMessageOffense:
+		return 1
+	case ThrottledOffense:
+		return 1
+	case InvalidSignatureOffense:
+		return 5
+	case IncompatibleVersionOffense:
+		return 10
+	default:
+		return 0
+	}
+}
+
+// Reputation scores the behavior of peers across the chains and the network
+// layer. Every offense a peer commits adds to its penalty, which decays over
+// time so that peers that stop misbehaving are eventually forgiven.
+type Reputation interface {
+	// Penalize records that [nodeID] committed [offense].
+	Penalize(nodeID ids.NodeID, offense Offense)
+
+	// Penalty returns the current penalty of [nodeID]. Peers that never
+	// misbehaved, or were forgiven, have a penalty of 0.
+	Penalty(nodeID ids.NodeID) float64
+}
+
+type penalty struct {
+	value       float64
+	lastUpdated time.Time
+}
+
+type reputation struct {
+	clock    mockable.Clock
+	halflife time.Duration
+	offenses *prometheus.CounterVec
+
+	lock       sync.Mutex
+	penalties  map[ids.NodeID]*penalty
+	lastPruned time.Time
+}
+
+// NewReputation returns a new Reputation where penalties are halved every
+// [halflife].
+func NewReputation(
+	halflife time.Duration,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Reputation, error) {
+	r := &reputation{
+		halflife: halflife,
+		offenses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_offenses",
+				Help:      "Number of offenses committed by peers",
+			},
+			[]string{"offense"},
+		),
+		penalties: make(map[ids.NodeID]*penalty),
+	}
+	r.lastPruned = r.clock.Time()
+	return r, registerer.Register(r.offenses)
+}
+
+func (r *reputation) Penalize(nodeID ids.NodeID, offense Offense) {
+	r.offenses.WithLabelValues(offense.String()).Inc()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Time()
+	p, ok := r.penalties[nodeID]
+	if !ok {
+		p = &penalty{}
+		r.penalties[nodeID] = p
+	}
+	p.value = r.decay(p, now) + offense.Weight()
+	p.lastUpdated = now
+
+	// Forget the peers that were forgiven. Pruning at most once per halflife
+	// keeps the cost of penalizing a peer constant.
+	if now.Sub(r.lastPruned) < r.halflife {
+		return
+	}
+	r.lastPruned = now
+	for nodeID, p := range r.penalties {
+		if r.decay(p, now) < minPenalty {
+			delete(r.penalties, nodeID)
+		}
+	}
+}
+
+func (r *reputation) Penalty(nodeID ids.NodeID) float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	p, ok := r.penalties[nodeID]
+	if !ok {
+		return 0
+	}
+	value := r.decay(p, r.clock.Time())
+	if value < minPenalty {
+		delete(r.penalties, nodeID)
+		return 0
+	}
+	return value
+}
+
+// decay returns the value of [p] at [now].
+func (r *reputation) decay(p *penalty, now time.Time) float64 {
+	elapsed := now.Sub(p.lastUpdated)
+	if elapsed <= 0 {
+		return p.value
+	}
+	return p.value * math.Exp2(-float64(elapsed)/float64(r.halflife))
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestReputationDecays(t *testing.T) {
+	require := require.New(t)
+
+	reputationIntf, err := NewReputation(time.Minute, "", prometheus.NewRegistry())
+	require.NoError(err)
+	r, ok := reputationIntf.(*reputation)
+	require.True(ok)
+
+	now := time.Now()
+	r.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.Zero(r.Penalty(nodeID))
+
+	r.Penalize(nodeID, IncompatibleVersionOffense)
+	r.Penalize(nodeID, InvalidMessageOffense)
+	require.Equal(IncompatibleVersionOffense.Weight()+InvalidMessageOffense.Weight(), r.Penalty(nodeID))
+
+	// The penalty is halved after every halflife.
+	r.clock.Set(now.Add(time.Minute))
+	require.InDelta(5.5, r.Penalty(nodeID), 1e-9)
+
+	r.clock.Set(now.Add(2 * time.Minute))
+	require.InDelta(2.75, r.Penalty(nodeID), 1e-9)
+
+	// Peers are eventually forgiven.
+	r.clock.Set(now.Add(time.Hour))
+	require.Zero(r.Penalty(nodeID))
+	require.NotContains(r.penalties, nodeID)
+}
+
+func TestReputationPrunesForgivenPeers(t *testing.T) {
+	require := require.New(t)
+
+	reputationIntf, err := NewReputation(time.Minute, "", prometheus.NewRegistry())
+	require.NoError(err)
+	r, ok := reputationIntf.(*reputation)
+	require.True(ok)
+
+	now := time.Now()
+	r.clock.Set(now)
+
+	forgivenNodeID := ids.GenerateTestNodeID()
+	r.Penalize(forgivenNodeID, ThrottledOffense)
+
+	// Penalizing another peer after the forgiven peer's penalty decayed
+	// forgets the forgiven peer.
+	r.clock.Set(now.Add(time.Hour))
+	penalizedNodeID := ids.GenerateTestNodeID()
+	r.Penalize(penalizedNodeID, ThrottledOffense)
+	require.Len(r.penalties, 1)
+	require.Contains(r.penalties, penalizedNodeID)
+}
@@ -18,7 +18,7 @@ func (testNetwork) AllowConnection(ids.NodeID) bool {
 	return true
 }
 
-func (testNetwork) Track(ips.ClaimedIPPort) bool {
+func (testNetwork) Track(ids.NodeID, ips.ClaimedIPPort) bool {
 	return true
 }
 
@@ -14,10 +14,13 @@ import (
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/message"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/router"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/staking"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
@@ -71,7 +74,7 @@ func StartTestPeer(
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	if err != nil {
@@ -106,16 +109,19 @@ func StartTestPeer(
 			MessageCreator:       mc,
 			Log:                  logging.NoLog{},
 			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
+			SubnetThrottler:      throttling.NewNoSubnetBandwidthThrottler(),
 			Network:              TestNetwork,
 			Router:               router,
 			VersionCompatibility: version.GetCompatibility(networkID),
-			MySubnets:            set.Set[ids.ID]{},
+			MySubnets:            utils.NewAtomicInterface(set.Set[ids.ID]{}),
 			Beacons:              validators.NewSet(),
 			NetworkID:            networkID,
 			PingFrequency:        constants.DefaultPingFrequency,
 			PongTimeout:          constants.DefaultPingPongTimeout,
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
+			Benchlist:            benchlist.NewNoBenchlist(),
+			Reputation:           NewNoReputation(),
 			IPSigner:             NewIPSigner(signerIP, tls),
 		},
 		conn,
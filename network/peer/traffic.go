@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/message"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+)
+
+// OpTraffic is the traffic of one type of message exchanged with a peer.
+type OpTraffic struct {
+	NumSent       json.Uint64 `json:"numSent"`
+	SentBytes     json.Uint64 `json:"sentBytes"`
+	NumReceived   json.Uint64 `json:"numReceived"`
+	ReceivedBytes json.Uint64 `json:"receivedBytes"`
+}
+
+// traffic accumulates the traffic exchanged with a peer by message type.
+type traffic struct {
+	lock sync.Mutex
+	ops  map[message.Op]*OpTraffic
+}
+
+func newTraffic() *traffic {
+	return &traffic{
+		ops: make(map[message.Op]*OpTraffic),
+	}
+}
+
+func (t *traffic) sent(op message.Op, numBytes int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	opTraffic := t.get(op)
+	opTraffic.NumSent++
+	opTraffic.SentBytes += json.Uint64(numBytes)
+}
+
+func (t *traffic) received(op message.Op, numBytes int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	opTraffic := t.get(op)
+	opTraffic.NumReceived++
+	opTraffic.ReceivedBytes += json.Uint64(numBytes)
+}
+
+// get returns the traffic of [op].
+//
+// Assumes [t.lock] is held.
+func (t *traffic) get(op message.Op) *OpTraffic {
+	opTraffic, ok := t.ops[op]
+	if !ok {
+		opTraffic = &OpTraffic{}
+		t.ops[op] = opTraffic
+	}
+	return opTraffic
+}
+
+// info returns a copy of the traffic, keyed by the name of the message type.
+func (t *traffic) info() map[string]OpTraffic {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	info := make(map[string]OpTraffic, len(t.ops))
+	for op, opTraffic := range t.ops {
+		info[op.String()] = *opTraffic
+	}
+	return info
+}
@@ -13,10 +13,12 @@ import (
 )
 
 var (
-	errNoCert = errors.New("tls handshake finished with no peer certificate")
+	errNoCert           = errors.New("tls handshake finished with no peer certificate")
+	errNotAuthenticated = errors.New("connection wasn't authenticated while being established")
 
 	_ Upgrader = (*tlsServerUpgrader)(nil)
 	_ Upgrader = (*tlsClientUpgrader)(nil)
+	_ Upgrader = (*authenticatedUpgrader)(nil)
 )
 
 type Upgrader interface {
@@ -52,12 +54,39 @@ func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *x509.C
 	return connToIDAndCert(tls.Client(conn, t.config))
 }
 
+// authenticatedConn is a connection that performed its TLS handshake while
+// being established, such as a QUIC connection.
+type authenticatedConn interface {
+	net.Conn
+	ConnectionState() tls.ConnectionState
+}
+
+type authenticatedUpgrader struct{}
+
+// NewAuthenticatedUpgrader returns an upgrader of connections that already
+// performed their TLS handshake, such as QUIC connections. The peer's NodeID
+// is derived from the certificate presented during that handshake, as it is
+// for TLS connections.
+func NewAuthenticatedUpgrader() Upgrader {
+	return authenticatedUpgrader{}
+}
+
+func (authenticatedUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *x509.Certificate, error) {
+	authConn, ok := conn.(authenticatedConn)
+	if !ok {
+		return ids.NodeID{}, nil, nil, errNotAuthenticated
+	}
+	return stateToIDAndCert(authConn, authConn.ConnectionState())
+}
+
 func connToIDAndCert(conn *tls.Conn) (ids.NodeID, net.Conn, *x509.Certificate, error) {
 	if err := conn.Handshake(); err != nil {
 		return ids.NodeID{}, nil, nil, err
 	}
+	return stateToIDAndCert(conn, conn.ConnectionState())
+}
 
-	state := conn.ConnectionState()
+func stateToIDAndCert(conn net.Conn, state tls.ConnectionState) (ids.NodeID, net.Conn, *x509.Certificate, error) {
 	if len(state.PeerCertificates) == 0 {
 		return ids.NodeID{}, nil, nil, errNoCert
 	}
@@ -0,0 +1,284 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+const (
+	// The most significant bit of a frame's length is not part of the length.
+	// See network/peer/msg_length.go.
+	frameLenMask = uint32(1 << 31)
+
+	// maxBufferedBytes is the maximum number of bytes of frame bodies read
+	// from the peer's streams that Read hasn't returned yet. Frames are
+	// buffered before the messages they contain are throttled, so this
+	// bounds the memory a peer can use on this node without being throttled.
+	maxBufferedBytes = constants.DefaultMaxMessageSize
+)
+
+var (
+	_ net.Conn = (*Conn)(nil)
+
+	errInvalidStream = errors.New("invalid stream")
+	errFrameTooLarge = errors.New("frame is too large")
+)
+
+// Conn is a QUIC connection that can be used as a net.Conn.
+//
+// Writes are split across independent streams, so that the frames written to
+// one stream are never delayed by the frames written to another stream, even
+// if packets are lost. Every frame must be prefixed with its length as a 4
+// byte big-endian integer. Reads return the frames of all the streams, each
+// frame being returned in full before the next one is returned.
+type Conn struct {
+	conn quicgo.Connection
+
+	// Complete frames read from the peer's streams. Each stream reads at most
+	// one frame ahead of Read, so at most [MaxStreams] frames are buffered.
+	frames chan []byte
+	// Bytes of the frame bodies that are buffered. A stream only allocates a
+	// frame once its body fits in [maxBufferedBytes].
+	buffered *semaphore.Weighted
+
+	readLock sync.Mutex
+	// remainder of the frame currently being returned by Read
+	pending []byte
+	// number of bytes of [buffered] held by the frame currently being
+	// returned by Read
+	pendingBodyLen int64
+
+	deadlineLock  sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	streamsLock sync.Mutex
+	streams     [MaxStreams]quicgo.SendStream
+}
+
+func newConn(conn quicgo.Connection) *Conn {
+	c := &Conn{
+		conn:     conn,
+		frames:   make(chan []byte),
+		buffered: semaphore.NewWeighted(maxBufferedBytes),
+	}
+	go c.acceptStreams()
+	return c
+}
+
+// ConnectionState returns the state of the TLS handshake that was performed
+// when the connection was established.
+func (c *Conn) ConnectionState() tls.ConnectionState {
+	return c.conn.ConnectionState().TLS
+}
+
+// Stream returns a writer to the stream with index [stream], which must be
+// less than [MaxStreams]. Writing to the connection directly is equivalent to
+// writing to stream 0.
+func (c *Conn) Stream(stream int) io.Writer {
+	return streamWriter{
+		conn:   c,
+		stream: stream,
+	}
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+
+	if len(c.pending) == 0 {
+		frame, err := c.nextFrame()
+		if err != nil {
+			return 0, err
+		}
+		c.pending = frame
+		c.pendingBodyLen = int64(len(frame) - wrappers.IntLen)
+	}
+
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+	if len(c.pending) == 0 {
+		c.buffered.Release(c.pendingBodyLen)
+		c.pendingBodyLen = 0
+	}
+	return n, nil
+}
+
+func (c *Conn) Write(b []byte) (int, error) {
+	return c.write(0, b)
+}
+
+func (c *Conn) Close() error {
+	return c.conn.CloseWithError(closedErrorCode, "")
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.readDeadline = t
+	return nil
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.deadlineLock.Lock()
+	defer c.deadlineLock.Unlock()
+
+	c.writeDeadline = t
+	return nil
+}
+
+// nextFrame blocks until a frame is received, the read deadline is reached, or
+// the connection is closed.
+func (c *Conn) nextFrame() ([]byte, error) {
+	c.deadlineLock.Lock()
+	deadline := c.readDeadline
+	c.deadlineLock.Unlock()
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case frame := <-c.frames:
+		return frame, nil
+	case <-c.conn.Context().Done():
+		return nil, net.ErrClosed
+	case <-timeout:
+		return nil, os.ErrDeadlineExceeded
+	}
+}
+
+// acceptStreams reads the frames of every stream opened by the peer until the
+// connection is closed.
+func (c *Conn) acceptStreams() {
+	ctx := c.conn.Context()
+	for {
+		stream, err := c.conn.AcceptUniStream(ctx)
+		if err != nil {
+			return
+		}
+		go c.readFrames(stream)
+	}
+}
+
+func (c *Conn) readFrames(stream quicgo.ReceiveStream) {
+	ctx := c.conn.Context()
+	frameLenBytes := make([]byte, wrappers.IntLen)
+	for {
+		if _, err := io.ReadFull(stream, frameLenBytes); err != nil {
+			return
+		}
+
+		frameLen := binary.BigEndian.Uint32(frameLenBytes) &^ frameLenMask
+		if frameLen > constants.DefaultMaxMessageSize {
+			_ = c.conn.CloseWithError(
+				invalidFrameErrorCode,
+				fmt.Sprintf("%s: %d > %d", errFrameTooLarge, frameLen, constants.DefaultMaxMessageSize),
+			)
+			return
+		}
+
+		// Wait for the frames that are already buffered to be read before
+		// allocating this one.
+		if err := c.buffered.Acquire(ctx, int64(frameLen)); err != nil {
+			return
+		}
+
+		frame := make([]byte, wrappers.IntLen+int(frameLen))
+		copy(frame, frameLenBytes)
+		if _, err := io.ReadFull(stream, frame[wrappers.IntLen:]); err != nil {
+			c.buffered.Release(int64(frameLen))
+			return
+		}
+
+		select {
+		case c.frames <- frame:
+		case <-ctx.Done():
+			c.buffered.Release(int64(frameLen))
+			return
+		}
+	}
+}
+
+func (c *Conn) write(streamIndex int, b []byte) (int, error) {
+	stream, err := c.sendStream(streamIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	c.deadlineLock.Lock()
+	deadline := c.writeDeadline
+	c.deadlineLock.Unlock()
+
+	if err := stream.SetWriteDeadline(deadline); err != nil {
+		return 0, err
+	}
+	return stream.Write(b)
+}
+
+// sendStream returns the stream with index [streamIndex], opening it if it
+// hasn't been written to yet.
+func (c *Conn) sendStream(streamIndex int) (quicgo.SendStream, error) {
+	if streamIndex < 0 || streamIndex >= MaxStreams {
+		return nil, fmt.Errorf("%w: %d", errInvalidStream, streamIndex)
+	}
+
+	c.streamsLock.Lock()
+	defer c.streamsLock.Unlock()
+
+	if stream := c.streams[streamIndex]; stream != nil {
+		return stream, nil
+	}
+
+	stream, err := c.conn.OpenUniStream()
+	if err != nil {
+		return nil, err
+	}
+	c.streams[streamIndex] = stream
+	return stream, nil
+}
+
+type streamWriter struct {
+	conn   *Conn
+	stream int
+}
+
+func (w streamWriter) Write(b []byte) (int, error) {
+	return w.conn.write(w.stream, b)
+}
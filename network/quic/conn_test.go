@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/staking"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+func newTLSConfig(t *testing.T) (ids.NodeID, *tls.Config) {
+	t.Helper()
+
+	cert, err := staking.NewTLSCert()
+	require.NoError(t, err)
+	return ids.NodeIDFromCert(cert.Leaf), peer.TLSConfig(*cert, nil)
+}
+
+// newConnPair returns the two ends of a QUIC connection established on
+// loopback, along with the NodeIDs of the dialer and of the listener.
+func newConnPair(t *testing.T) (*Conn, ids.NodeID, *Conn, ids.NodeID) {
+	t.Helper()
+	require := require.New(t)
+
+	serverNodeID, serverTLSConfig := newTLSConfig(t)
+	clientNodeID, clientTLSConfig := newTLSConfig(t)
+
+	listener, err := Listen("127.0.0.1:0", serverTLSConfig, time.Second)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- conn
+	}()
+
+	clientConn, err := Dial(context.Background(), listener.Addr().String(), clientTLSConfig, time.Second)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+	})
+
+	serverConn, ok := <-accepted
+	require.True(ok)
+	t.Cleanup(func() {
+		_ = serverConn.Close()
+	})
+	return clientConn, clientNodeID, serverConn.(*Conn), serverNodeID
+}
+
+func frame(body []byte) []byte {
+	f := make([]byte, wrappers.IntLen+len(body))
+	binary.BigEndian.PutUint32(f, uint32(len(body))|frameLenMask)
+	copy(f[wrappers.IntLen:], body)
+	return f
+}
+
+func TestConnAuthenticatesBothEnds(t *testing.T) {
+	require := require.New(t)
+
+	clientConn, clientNodeID, serverConn, serverNodeID := newConnPair(t)
+
+	clientState := clientConn.ConnectionState()
+	require.NotEmpty(clientState.PeerCertificates)
+	require.Equal(serverNodeID, ids.NodeIDFromCert(clientState.PeerCertificates[0]))
+
+	serverState := serverConn.ConnectionState()
+	require.NotEmpty(serverState.PeerCertificates)
+	require.Equal(clientNodeID, ids.NodeIDFromCert(serverState.PeerCertificates[0]))
+}
+
+func TestConnStreamsAreIndependent(t *testing.T) {
+	require := require.New(t)
+
+	clientConn, _, serverConn, _ := newConnPair(t)
+
+	// Start writing a large frame on one stream without finishing it.
+	largeFrame := frame(make([]byte, 1024*1024))
+	_, err := clientConn.Stream(2).Write(largeFrame[:len(largeFrame)/2])
+	require.NoError(err)
+
+	// A frame written to another stream is read in full even though the large
+	// frame is incomplete.
+	smallFrame := frame([]byte("consensus"))
+	_, err = clientConn.Stream(1).Write(smallFrame)
+	require.NoError(err)
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(10 * time.Second)))
+	received := make([]byte, len(smallFrame))
+	_, err = io.ReadFull(serverConn, received)
+	require.NoError(err)
+	require.Equal(smallFrame, received)
+
+	// Once the large frame is finished, it is read in full.
+	_, err = clientConn.Stream(2).Write(largeFrame[len(largeFrame)/2:])
+	require.NoError(err)
+
+	received = make([]byte, len(largeFrame))
+	_, err = io.ReadFull(serverConn, received)
+	require.NoError(err)
+	require.Equal(largeFrame, received)
+}
+
+func TestConnBufferedBytesAreCapped(t *testing.T) {
+	require := require.New(t)
+
+	clientConn, _, serverConn, _ := newConnPair(t)
+
+	bodyLen := int64(maxBufferedBytes / 2)
+	largeFrame := frame(make([]byte, bodyLen))
+	_, err := clientConn.Stream(1).Write(largeFrame)
+	require.NoError(err)
+
+	// Once the frame is buffered, its body counts against the buffered bytes
+	// of the connection.
+	require.Eventually(
+		func() bool {
+			return !serverConn.buffered.TryAcquire(maxBufferedBytes - bodyLen + 1)
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+
+	// Frames that don't fit are only read from their stream once the buffered
+	// frames are read, so their writes block on flow control until then.
+	writeErr := make(chan error, 1)
+	go func() {
+		_, err := clientConn.Stream(2).Write(frame(make([]byte, bodyLen+1)))
+		writeErr <- err
+	}()
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(10 * time.Second)))
+	received := make([]byte, len(largeFrame))
+	_, err = io.ReadFull(serverConn, received)
+	require.NoError(err)
+	require.Equal(largeFrame, received)
+
+	received = make([]byte, wrappers.IntLen+bodyLen+1)
+	_, err = io.ReadFull(serverConn, received)
+	require.NoError(err)
+	require.NoError(<-writeErr)
+
+	// The bytes of the frames that were read are released.
+	require.True(serverConn.buffered.TryAcquire(maxBufferedBytes))
+}
+
+func TestConnReadDeadline(t *testing.T) {
+	require := require.New(t)
+
+	_, _, serverConn, _ := newConnPair(t)
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(10 * time.Millisecond)))
+	_, err := serverConn.Read(make([]byte, 1))
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+}
+
+func TestConnInvalidStream(t *testing.T) {
+	clientConn, _, _, _ := newConnPair(t)
+
+	_, err := clientConn.Stream(MaxStreams).Write(frame(nil))
+	require.ErrorIs(t, err, errInvalidStream)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+var (
+	errListenerClosed = errors.New("listener closed")
+
+	_ net.Listener = (*fallbackListener)(nil)
+)
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+type fallbackListener struct {
+	quicListener net.Listener
+	tcpListener  net.Listener
+
+	accepted  chan acceptResult
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFallbackListener returns a listener that accepts connections from both
+// [quicListener] and [tcpListener], so that peers which don't support QUIC can
+// still connect over TCP. Both listeners are expected to listen on the same
+// port, which is reported by Addr.
+func NewFallbackListener(quicListener, tcpListener net.Listener) net.Listener {
+	l := &fallbackListener{
+		quicListener: quicListener,
+		tcpListener:  tcpListener,
+		accepted:     make(chan acceptResult),
+		closed:       make(chan struct{}),
+	}
+	go l.accept(quicListener)
+	go l.accept(tcpListener)
+	return l
+}
+
+func (l *fallbackListener) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		select {
+		case l.accepted <- acceptResult{conn: conn, err: err}:
+		case <-l.closed:
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+	}
+}
+
+func (l *fallbackListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.accepted:
+		return result.conn, result.err
+	case <-l.closed:
+		return nil, errListenerClosed
+	}
+}
+
+func (l *fallbackListener) Close() error {
+	errs := wrappers.Errs{}
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		errs.Add(
+			l.quicListener.Close(),
+			l.tcpListener.Close(),
+		)
+	})
+	return errs.Err
+}
+
+func (l *fallbackListener) Addr() net.Addr {
+	return l.tcpListener.Addr()
+}
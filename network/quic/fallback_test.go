@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackListenerAcceptsBothTransports(t *testing.T) {
+	require := require.New(t)
+
+	_, serverTLSConfig := newTLSConfig(t)
+	_, clientTLSConfig := newTLSConfig(t)
+
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	quicListener, err := Listen(tcpListener.Addr().String(), serverTLSConfig, time.Second)
+	require.NoError(err)
+
+	listener := NewFallbackListener(quicListener, tcpListener)
+	defer func() {
+		_ = listener.Close()
+	}()
+	require.Equal(tcpListener.Addr(), listener.Addr())
+
+	quicConn, err := Dial(context.Background(), listener.Addr().String(), clientTLSConfig, time.Second)
+	require.NoError(err)
+	defer func() {
+		_ = quicConn.Close()
+	}()
+
+	accepted, err := listener.Accept()
+	require.NoError(err)
+	require.IsType(&Conn{}, accepted)
+	_ = accepted.Close()
+
+	tcpConn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer func() {
+		_ = tcpConn.Close()
+	}()
+
+	accepted, err = listener.Accept()
+	require.NoError(err)
+	require.IsType(&net.TCPConn{}, accepted)
+	_ = accepted.Close()
+
+	require.NoError(listener.Close())
+	_, err = listener.Accept()
+	require.ErrorIs(err, errListenerClosed)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+)
+
+const (
+	// MaxStreams is the maximum number of streams that can be written to on a
+	// connection.
+	MaxStreams = 8
+
+	// Application protocol negotiated during the TLS handshake.
+	alpn = "dijets"
+
+	// Keep-alives are sent well before the idle timeout so that connections to
+	// quiet peers aren't closed.
+	keepAlivePeriod = 15 * time.Second
+	maxIdleTimeout  = time.Minute
+
+	// Error code sent to the peer when the connection is closed.
+	closedErrorCode quicgo.ApplicationErrorCode = 0
+	// Error code sent to the peer when it sent a malformed frame.
+	invalidFrameErrorCode quicgo.ApplicationErrorCode = 1
+)
+
+var _ net.Listener = (*listener)(nil)
+
+type listener struct {
+	listener *quicgo.Listener
+}
+
+// Listen returns a listener that accepts QUIC connections on the UDP
+// [address]. The TLS handshake is performed with [tlsConfig] before the
+// connection is returned by Accept.
+func Listen(address string, tlsConfig *tls.Config, handshakeTimeout time.Duration) (net.Listener, error) {
+	l, err := quicgo.ListenAddr(address, withALPN(tlsConfig), newConfig(handshakeTimeout))
+	if err != nil {
+		return nil, err
+	}
+	return &listener{
+		listener: l,
+	}, nil
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	conn, err := l.listener.Accept(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn), nil
+}
+
+func (l *listener) Close() error {
+	return l.listener.Close()
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Dial establishes a QUIC connection to [address]. The TLS handshake is
+// performed with [tlsConfig] before Dial returns.
+func Dial(ctx context.Context, address string, tlsConfig *tls.Config, handshakeTimeout time.Duration) (*Conn, error) {
+	conn, err := quicgo.DialAddr(ctx, address, withALPN(tlsConfig), newConfig(handshakeTimeout))
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn), nil
+}
+
+// newConfig returns the QUIC config used by both ends of a connection.
+func newConfig(handshakeTimeout time.Duration) *quicgo.Config {
+	return &quicgo.Config{
+		HandshakeIdleTimeout:  handshakeTimeout,
+		MaxIdleTimeout:        maxIdleTimeout,
+		KeepAlivePeriod:       keepAlivePeriod,
+		MaxIncomingStreams:    -1, // bidirectional streams aren't used
+		MaxIncomingUniStreams: MaxStreams,
+	}
+}
+
+// withALPN returns a copy of [tlsConfig] that negotiates the application
+// protocol, which QUIC requires.
+func withALPN(tlsConfig *tls.Config) *tls.Config {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{alpn}
+	return tlsConfig
+}
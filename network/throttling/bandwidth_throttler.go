@@ -31,7 +31,9 @@ type bandwidthThrottler interface {
 	// the last time RemoveNode([nodeID]) was called, if any.
 	// It's safe for multiple goroutines to concurrently call Acquire.
 	// Returns immediately if [ctx] is canceled.
-	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID)
+	// Returns true if [nodeID] had exceeded its bandwidth allocation, so the
+	// message had to wait for the allocation to refill.
+	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) bool
 
 	// Add a new node to this throttler.
 	// Must be called before Acquire(..., [nodeID]) is called.
@@ -49,6 +51,11 @@ type bandwidthThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// Update the bandwidth allocation of every node, including the nodes
+	// that are already registered.
+	// It's safe for multiple goroutines to concurrently call SetConfig.
+	SetConfig(config BandwidthThrottlerConfig)
 }
 
 type BandwidthThrottlerConfig struct {
@@ -108,7 +115,7 @@ func (t *bandwidthThrottlerImpl) Acquire(
 	ctx context.Context,
 	msgSize uint64,
 	nodeID ids.NodeID,
-) {
+) bool {
 	startTime := time.Now()
 	t.metrics.awaitingAcquire.Inc()
 	defer func() {
@@ -125,8 +132,11 @@ func (t *bandwidthThrottlerImpl) Acquire(
 			zap.Uint64("messageSize", msgSize),
 			zap.Stringer("nodeID", nodeID),
 		)
-		return
+		return false
 	}
+	// The allocation of a node only runs out if it sends faster than its
+	// refill rate, regardless of how busy this node is.
+	exceeded := limiter.TokensAt(startTime) < float64(msgSize)
 	if err := limiter.WaitN(ctx, int(msgSize)); err != nil {
 		// This should only happen on shutdown.
 		t.log.Debug("error while waiting for throttler",
@@ -135,6 +145,7 @@ func (t *bandwidthThrottlerImpl) Acquire(
 			zap.Error(err),
 		)
 	}
+	return exceeded
 }
 
 // See BandwidthThrottler.
@@ -164,3 +175,15 @@ func (t *bandwidthThrottlerImpl) RemoveNode(nodeID ids.NodeID) {
 	}
 	delete(t.limiters, nodeID)
 }
+
+// See BandwidthThrottler.
+func (t *bandwidthThrottlerImpl) SetConfig(config BandwidthThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.BandwidthThrottlerConfig = config
+	for _, limiter := range t.limiters {
+		limiter.SetLimit(rate.Limit(config.RefillRate))
+		limiter.SetBurst(int(config.MaxBurstSize))
+	}
+}
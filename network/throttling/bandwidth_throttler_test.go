@@ -60,3 +60,61 @@ func TestBandwidthThrottler(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBandwidthThrottlerExceeded(t *testing.T) {
+	require := require.New(t)
+
+	config := BandwidthThrottlerConfig{
+		RefillRate:   1000,
+		MaxBurstSize: 10,
+	}
+	throttler, err := newBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), config)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+
+	// The allocation covers the first message.
+	require.False(throttler.Acquire(context.Background(), 10, nodeID))
+
+	// The allocation is exhausted, so the next message exceeds it.
+	require.True(throttler.Acquire(context.Background(), 10, nodeID))
+
+	// Unknown nodes are never reported as exceeding their allocation.
+	require.False(throttler.Acquire(context.Background(), 10, ids.GenerateTestNodeID()))
+}
+
+func TestBandwidthThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := BandwidthThrottlerConfig{
+		RefillRate:   8,
+		MaxBurstSize: 10,
+	}
+	throttlerIntf, err := newBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), config)
+	require.NoError(err)
+	throttler, ok := throttlerIntf.(*bandwidthThrottlerImpl)
+	require.True(ok)
+
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+
+	newConfig := BandwidthThrottlerConfig{
+		RefillRate:   16,
+		MaxBurstSize: 20,
+	}
+	throttler.SetConfig(newConfig)
+	require.Equal(newConfig, throttler.BandwidthThrottlerConfig)
+
+	// Registered nodes are given the new allocation.
+	limiter := throttler.limiters[nodeID]
+	require.EqualValues(16, limiter.Limit())
+	require.Equal(20, limiter.Burst())
+
+	// So are nodes registered afterwards.
+	nodeID2 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID2)
+	limiter = throttler.limiters[nodeID2]
+	require.EqualValues(16, limiter.Limit())
+	require.Equal(20, limiter.Burst())
+}
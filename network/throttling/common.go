@@ -9,6 +9,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
 )
 
 // Used by the sybil-safe inbound and outbound message throttlers
@@ -38,4 +39,74 @@ type commonMsgThrottler struct {
 	nodeToAtLargeBytesUsed map[ids.NodeID]uint64
 	// Max number of unprocessed bytes from validators
 	maxVdrBytes uint64
+	// Size of the at-large byte allocation
+	atLargeAllocSize uint64
+	// Number of released bytes that aren't given back to the validator and
+	// at-large allocations respectively. Only non-zero after an allocation
+	// shrank to less than the number of bytes taken from it.
+	vdrBytesOwed, atLargeBytesOwed uint64
+}
+
+// setConfig resizes the allocations to the sizes in [config]. Bytes that are
+// already taken from an allocation stay taken, so an allocation that shrinks
+// to less than the number of bytes taken from it only has bytes remaining once
+// enough bytes are released.
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) setConfig(config MsgByteThrottlerConfig) {
+	t.remainingVdrBytes, t.vdrBytesOwed = resizeAllocation(
+		t.maxVdrBytes,
+		t.remainingVdrBytes,
+		t.vdrBytesOwed,
+		config.VdrAllocSize,
+	)
+	t.remainingAtLargeBytes, t.atLargeBytesOwed = resizeAllocation(
+		t.atLargeAllocSize,
+		t.remainingAtLargeBytes,
+		t.atLargeBytesOwed,
+		config.AtLargeAllocSize,
+	)
+	t.maxVdrBytes = config.VdrAllocSize
+	t.atLargeAllocSize = config.AtLargeAllocSize
+	t.nodeMaxAtLargeBytes = config.NodeMaxAtLargeBytes
+}
+
+// atLargeBytesAllowed returns the number of bytes [nodeID] may still take from
+// the at-large allocation.
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) atLargeBytesAllowed(nodeID ids.NodeID) uint64 {
+	atLargeBytesUsed := t.nodeToAtLargeBytesUsed[nodeID]
+	if atLargeBytesUsed >= t.nodeMaxAtLargeBytes {
+		// The per-node limit may have been lowered below the bytes in use.
+		return 0
+	}
+	return t.nodeMaxAtLargeBytes - atLargeBytesUsed
+}
+
+// returnVdrBytes gives [bytes] released bytes back to the validator
+// allocation, after paying off the bytes it owes.
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) returnVdrBytes(bytes uint64) {
+	paid := math.Min(bytes, t.vdrBytesOwed)
+	t.vdrBytesOwed -= paid
+	t.remainingVdrBytes += bytes - paid
+}
+
+// returnAtLargeBytes gives [bytes] released bytes back to the at-large
+// allocation, after paying off the bytes it owes.
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) returnAtLargeBytes(bytes uint64) {
+	paid := math.Min(bytes, t.atLargeBytesOwed)
+	t.atLargeBytesOwed -= paid
+	t.remainingAtLargeBytes += bytes - paid
+}
+
+// resizeAllocation returns the remaining and owed bytes of an allocation of
+// [size] bytes, with [remaining] bytes remaining and [owed] bytes owed, once
+// it's resized to [newSize] bytes.
+func resizeAllocation(size, remaining, owed, newSize uint64) (uint64, uint64) {
+	taken := size - remaining + owed
+	if newSize >= taken {
+		return newSize - taken, 0
+	}
+	return 0, taken - newSize
 }
@@ -8,15 +8,12 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/utils/ips"
+	"github.com/lasthyphen/dijetsnodego/utils/linkedhashmap"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
-	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 )
 
-var (
-	_ InboundConnUpgradeThrottler = (*inboundConnUpgradeThrottler)(nil)
-	_ InboundConnUpgradeThrottler = (*noInboundConnUpgradeThrottler)(nil)
-)
+var _ InboundConnUpgradeThrottler = (*inboundConnUpgradeThrottler)(nil)
 
 // InboundConnUpgradeThrottler returns whether we should upgrade an inbound connection from IP [ipStr].
 // If ShouldUpgrade(ipStr) returns false, the connection to that IP should be closed.
@@ -24,19 +21,16 @@ var (
 // inbound connections, whereas throttledListener rate-limits
 // _acceptance_ of inbound connections.
 type InboundConnUpgradeThrottler interface {
-	// Dispatch starts this InboundConnUpgradeThrottler.
-	// Must be called before [ShouldUpgrade].
-	// Blocks until [Stop] is called (i.e. should be called in a goroutine.)
-	Dispatch()
-	// Stop this InboundConnUpgradeThrottler and causes [Dispatch] to return.
-	// Should be called when we're done with this InboundConnUpgradeThrottler.
-	// This InboundConnUpgradeThrottler must not be used after [Stop] is called.
-	Stop()
 	// Returns whether we should upgrade an inbound connection from [ipStr].
-	// Must only be called after [Dispatch] has been called.
 	// If [ip] is a local IP, this method always returns true.
-	// Must not be called after [Stop] has been called.
+	// It's safe for multiple goroutines to concurrently call ShouldUpgrade.
 	ShouldUpgrade(ip ips.IPPort) bool
+
+	// Update the limits to the values in [config]. An IP that was upgraded
+	// within the last [config.UpgradeCooldown] isn't upgraded again until the
+	// new cooldown has passed.
+	// It's safe for multiple goroutines to concurrently call SetConfig.
+	SetConfig(config InboundConnUpgradeThrottlerConfig)
 }
 
 type InboundConnUpgradeThrottlerConfig struct {
@@ -46,8 +40,6 @@ type InboundConnUpgradeThrottlerConfig struct {
 	// If <= 0, inbound connections not rate-limited.
 	UpgradeCooldown time.Duration `json:"upgradeCooldown"`
 	// Maximum number of inbound connections upgraded within [UpgradeCooldown].
-	// (As implemented in inboundConnUpgradeThrottler, may actually upgrade
-	// [MaxRecentConnsUpgraded+1] due to a race condition but that's fine.)
 	// If <= 0, inbound connections not rate-limited.
 	MaxRecentConnsUpgraded int `json:"maxRecentConnsUpgraded"`
 }
@@ -55,49 +47,24 @@ type InboundConnUpgradeThrottlerConfig struct {
 // Returns an InboundConnUpgradeThrottler that upgrades an inbound
 // connection from a given IP at most every [UpgradeCooldown].
 func NewInboundConnUpgradeThrottler(log logging.Logger, config InboundConnUpgradeThrottlerConfig) InboundConnUpgradeThrottler {
-	if config.UpgradeCooldown <= 0 || config.MaxRecentConnsUpgraded <= 0 {
-		return &noInboundConnUpgradeThrottler{}
-	}
 	return &inboundConnUpgradeThrottler{
 		InboundConnUpgradeThrottlerConfig: config,
 		log:                               log,
-		done:                              make(chan struct{}),
-		recentIPsAndTimes:                 make(chan ipAndTime, config.MaxRecentConnsUpgraded),
+		recentIPs:                         linkedhashmap.New[string, time.Time](),
 	}
 }
 
-// noInboundConnUpgradeThrottler upgrades all inbound connections
-type noInboundConnUpgradeThrottler struct{}
-
-func (*noInboundConnUpgradeThrottler) Dispatch() {}
-
-func (*noInboundConnUpgradeThrottler) Stop() {}
-
-func (*noInboundConnUpgradeThrottler) ShouldUpgrade(ips.IPPort) bool {
-	return true
-}
-
-type ipAndTime struct {
-	ip                string
-	cooldownElapsedAt time.Time
-}
-
 type inboundConnUpgradeThrottler struct {
 	InboundConnUpgradeThrottlerConfig
 	log  logging.Logger
 	lock sync.Mutex
 	// Useful for faking time in tests
 	clock mockable.Clock
-	// When [done] is closed, Dispatch returns.
-	done chan struct{}
-	// IP --> Present if ShouldUpgrade(ipStr) returned true
+	// IP --> Last time ShouldUpgrade(ipStr) returned true, if that was
 	// within the last [UpgradeCooldown].
-	recentIPs set.Set[string]
-	// Sorted in order of increasing time
-	// of last call to ShouldUpgrade that returned true.
-	// For each IP in this channel, ShouldUpgrade(ipStr)
-	// returned true within the last [UpgradeCooldown].
-	recentIPsAndTimes chan ipAndTime
+	// Sorted in order of increasing time of last call to ShouldUpgrade that
+	// returned true.
+	recentIPs linkedhashmap.LinkedHashmap[string, time.Time]
 }
 
 // Returns whether we should upgrade an inbound connection from [ipStr].
@@ -112,51 +79,41 @@ func (n *inboundConnUpgradeThrottler) ShouldUpgrade(ip ips.IPPort) bool {
 	n.lock.Lock()
 	defer n.lock.Unlock()
 
-	if n.recentIPs.Contains(ipStr) {
+	if n.UpgradeCooldown <= 0 || n.MaxRecentConnsUpgraded <= 0 {
+		// Inbound connections aren't rate-limited
+		return true
+	}
+
+	now := n.clock.Time()
+	n.removeExpiredIPs(now)
+
+	if _, ok := n.recentIPs.Get(ipStr); ok {
 		// We recently upgraded an inbound connection from this IP
 		return false
 	}
-
-	select {
-	case n.recentIPsAndTimes <- ipAndTime{
-		ip:                ipStr,
-		cooldownElapsedAt: n.clock.Time().Add(n.UpgradeCooldown),
-	}:
-		n.recentIPs.Add(ipStr)
-		return true
-	default:
+	if n.recentIPs.Len() >= n.MaxRecentConnsUpgraded {
 		return false
 	}
+	n.recentIPs.Put(ipStr, now)
+	return true
 }
 
-func (n *inboundConnUpgradeThrottler) Dispatch() {
-	timer := time.NewTimer(0)
-	if !timer.Stop() {
-		<-timer.C
-	}
+func (n *inboundConnUpgradeThrottler) SetConfig(config InboundConnUpgradeThrottlerConfig) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.InboundConnUpgradeThrottlerConfig = config
+}
 
-	defer timer.Stop()
+// Removes the IPs that were last upgraded at least [UpgradeCooldown] before
+// [now], as we'd upgrade another inbound connection from them.
+// Assumes [n.lock] is held.
+func (n *inboundConnUpgradeThrottler) removeExpiredIPs(now time.Time) {
 	for {
-		select {
-		case next := <-n.recentIPsAndTimes:
-			// Sleep until it's time to remove the next IP
-			timer.Reset(next.cooldownElapsedAt.Sub(n.clock.Time()))
-
-			select {
-			case <-timer.C:
-				// Remove the next IP (we'd upgrade another inbound connection from it)
-				n.lock.Lock()
-				n.recentIPs.Remove(next.ip)
-				n.lock.Unlock()
-			case <-n.done:
-				return
-			}
-		case <-n.done:
+		ipStr, upgradedAt, ok := n.recentIPs.Oldest()
+		if !ok || now.Sub(upgradedAt) < n.UpgradeCooldown {
 			return
 		}
+		n.recentIPs.Delete(ipStr)
 	}
 }
-
-func (n *inboundConnUpgradeThrottler) Stop() {
-	close(n.done)
-}
@@ -87,21 +87,45 @@ func TestInboundConnUpgradeThrottler(t *testing.T) {
 	require.True(throttlerIntf.ShouldUpgrade(loopbackIP))
 	require.True(throttlerIntf.ShouldUpgrade(loopbackIP))
 
-	// Make sure [throttler.done] isn't closed
+	// Once [cooldown] has passed, the IPs can be upgraded again
 	throttler := throttlerIntf.(*inboundConnUpgradeThrottler)
-	select {
-	case <-throttler.done:
-		t.Fatal("shouldn't be done")
-	default:
-	}
+	throttler.clock.Set(time.Now().Add(cooldown))
+	require.True(throttlerIntf.ShouldUpgrade(host4))
+	require.True(throttlerIntf.ShouldUpgrade(host1))
+}
 
-	throttler.Stop()
+func TestInboundConnUpgradeThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
 
-	// Make sure [throttler.done] is closed
-	select {
-	case _, chanOpen := <-throttler.done:
-		require.False(chanOpen)
-	default:
-		t.Fatal("should be done")
-	}
+	throttlerIntf := NewInboundConnUpgradeThrottler(
+		logging.NoLog{},
+		InboundConnUpgradeThrottlerConfig{},
+	)
+	throttler := throttlerIntf.(*inboundConnUpgradeThrottler)
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	// Inbound connections aren't rate-limited yet
+	require.True(throttler.ShouldUpgrade(host1))
+	require.True(throttler.ShouldUpgrade(host1))
+
+	throttler.SetConfig(InboundConnUpgradeThrottlerConfig{
+		UpgradeCooldown:        time.Minute,
+		MaxRecentConnsUpgraded: 1,
+	})
+	require.True(throttler.ShouldUpgrade(host1))
+	require.False(throttler.ShouldUpgrade(host1))
+	require.False(throttler.ShouldUpgrade(host2))
+
+	// Raising the limit allows more IPs to be upgraded, and the cooldown of
+	// the IPs that were already upgraded is changed as well.
+	throttler.SetConfig(InboundConnUpgradeThrottlerConfig{
+		UpgradeCooldown:        time.Second,
+		MaxRecentConnsUpgraded: 2,
+	})
+	require.True(throttler.ShouldUpgrade(host2))
+	require.False(throttler.ShouldUpgrade(host1))
+
+	throttler.clock.Set(now.Add(time.Second))
+	require.True(throttler.ShouldUpgrade(host1))
 }
@@ -124,6 +124,16 @@ func (t *inboundMsgBufferThrottler) release(nodeID ids.NodeID) {
 	}
 }
 
+// setMaxProcessingMsgsPerNode updates the maximum number of messages
+// processed at once from each node. Nodes that are waiting for space on the
+// buffer are let through once one of their messages is released.
+func (t *inboundMsgBufferThrottler) setMaxProcessingMsgsPerNode(maxProcessingMsgsPerNode uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.maxProcessingMsgsPerNode = maxProcessingMsgsPerNode
+}
+
 type inboundMsgBufferThrottlerMetrics struct {
 	acquireLatency  metric.Averager
 	awaitingAcquire prometheus.Gauge
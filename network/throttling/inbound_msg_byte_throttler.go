@@ -36,6 +36,7 @@ func newInboundMsgByteThrottler(
 			maxVdrBytes:            config.VdrAllocSize,
 			remainingVdrBytes:      config.VdrAllocSize,
 			remainingAtLargeBytes:  config.AtLargeAllocSize,
+			atLargeAllocSize:       config.AtLargeAllocSize,
 			nodeMaxAtLargeBytes:    config.NodeMaxAtLargeBytes,
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
@@ -111,7 +112,7 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 		// only give as many bytes as needed
 		metadata.bytesNeeded,
 		// don't exceed per-node limit
-		t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[nodeID],
+		t.atLargeBytesAllowed(nodeID),
 		// don't give more bytes than are in the allocation
 		t.remainingAtLargeBytes,
 	)
@@ -193,6 +194,17 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 	}
 }
 
+// Resizes the allocations to the sizes in [config].
+func (t *inboundMsgByteThrottler) setConfig(config MsgByteThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.commonMsgThrottler.setConfig(config)
+	t.giveAtLargeBytesToWaitingMsgs()
+	t.metrics.remainingAtLargeBytes.Set(float64(t.remainingAtLargeBytes))
+	t.metrics.remainingVdrBytes.Set(float64(t.remainingVdrBytes))
+}
+
 // Must correspond to a previous call of Acquire([msgSize], [nodeID])
 func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.NodeID) {
 	t.lock.Lock()
@@ -216,46 +228,13 @@ func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.Node
 	atLargeBytesToReturn := releasedBytes - vdrBytesToReturn
 	if atLargeBytesToReturn > 0 {
 		// Mark that [nodeID] has released these bytes.
-		t.remainingAtLargeBytes += atLargeBytesToReturn
+		t.returnAtLargeBytes(atLargeBytesToReturn)
 		t.nodeToAtLargeBytesUsed[nodeID] -= atLargeBytesToReturn
 		if t.nodeToAtLargeBytesUsed[nodeID] == 0 {
 			delete(t.nodeToAtLargeBytesUsed, nodeID)
 		}
 
-		// Iterates over messages waiting to acquire bytes from oldest
-		// (waiting the longest) to newest. Try to give bytes to the
-		// oldest message, then next oldest, etc. until there are no
-		// waiting messages or we exhaust the bytes.
-		iter := t.waitingToAcquire.NewIterator()
-		for t.remainingAtLargeBytes > 0 && iter.Next() {
-			msg := iter.Value()
-			// From the at-large allocation, take the maximum number of bytes
-			// without exceeding the per-node limit on taking from at-large pool.
-			atLargeBytesGiven := math.Min(
-				// don't give [msg] too many bytes
-				msg.bytesNeeded,
-				// don't exceed per-node limit
-				t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[msg.nodeID],
-				// don't give more bytes than are in the allocation
-				t.remainingAtLargeBytes,
-			)
-			if atLargeBytesGiven > 0 {
-				// Mark that we gave [atLargeBytesGiven] to [msg]
-				t.nodeToAtLargeBytesUsed[msg.nodeID] += atLargeBytesGiven
-				t.remainingAtLargeBytes -= atLargeBytesGiven
-				atLargeBytesToReturn -= atLargeBytesGiven
-				msg.bytesNeeded -= atLargeBytesGiven
-			}
-			if msg.bytesNeeded == 0 {
-				// [msg] has acquired enough bytes to be read.
-				// Unblock the corresponding thread in Acquire
-				close(msg.closeOnAcquireChan)
-				// Mark that this message is no longer waiting to acquire bytes
-				delete(t.nodeToWaitingMsgID, msg.nodeID)
-
-				t.waitingToAcquire.Delete(iter.Key())
-			}
-		}
+		t.giveAtLargeBytesToWaitingMsgs()
 	}
 
 	// Get the message from [nodeID], if any, waiting to acquire
@@ -288,7 +267,46 @@ func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.Node
 		if t.nodeToVdrBytesUsed[nodeID] == 0 {
 			delete(t.nodeToVdrBytesUsed, nodeID)
 		}
-		t.remainingVdrBytes += vdrBytesToReturn
+		t.returnVdrBytes(vdrBytesToReturn)
+	}
+}
+
+// Gives the remaining bytes of the at-large allocation to the messages waiting
+// to acquire bytes.
+// Assumes [t.lock] is held.
+func (t *inboundMsgByteThrottler) giveAtLargeBytesToWaitingMsgs() {
+	// Iterates over messages waiting to acquire bytes from oldest
+	// (waiting the longest) to newest. Try to give bytes to the
+	// oldest message, then next oldest, etc. until there are no
+	// waiting messages or we exhaust the bytes.
+	iter := t.waitingToAcquire.NewIterator()
+	for t.remainingAtLargeBytes > 0 && iter.Next() {
+		msg := iter.Value()
+		// From the at-large allocation, take the maximum number of bytes
+		// without exceeding the per-node limit on taking from at-large pool.
+		atLargeBytesGiven := math.Min(
+			// don't give [msg] too many bytes
+			msg.bytesNeeded,
+			// don't exceed per-node limit
+			t.atLargeBytesAllowed(msg.nodeID),
+			// don't give more bytes than are in the allocation
+			t.remainingAtLargeBytes,
+		)
+		if atLargeBytesGiven > 0 {
+			// Mark that we gave [atLargeBytesGiven] to [msg]
+			t.nodeToAtLargeBytesUsed[msg.nodeID] += atLargeBytesGiven
+			t.remainingAtLargeBytes -= atLargeBytesGiven
+			msg.bytesNeeded -= atLargeBytesGiven
+		}
+		if msg.bytesNeeded == 0 {
+			// [msg] has acquired enough bytes to be read.
+			// Unblock the corresponding thread in Acquire
+			close(msg.closeOnAcquireChan)
+			// Mark that this message is no longer waiting to acquire bytes
+			delete(t.nodeToWaitingMsgID, msg.nodeID)
+
+			t.waitingToAcquire.Delete(iter.Key())
+		}
 	}
 }
 
@@ -321,6 +321,73 @@ func TestInboundMsgByteThrottler(t *testing.T) {
 }
 
 // Ensure that the limit on taking from the at-large allocation is enforced
+// Ensure that bytes released after the allocations shrank are only given to
+// waiting messages once the allocations are back within their new sizes, and
+// that the bytes added by growing the allocations are given to them right away
+func TestInboundMsgByteThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        0,
+		AtLargeAllocSize:    100,
+		NodeMaxAtLargeBytes: 100,
+	}
+	throttler, err := newInboundMsgByteThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		validators.NewSet(),
+		config,
+	)
+	require.NoError(err)
+
+	node1ID := ids.GenerateTestNodeID()
+	node2ID := ids.GenerateTestNodeID()
+	release := throttler.Acquire(context.Background(), 100, node1ID)
+
+	throttler.setConfig(MsgByteThrottlerConfig{
+		AtLargeAllocSize:    50,
+		NodeMaxAtLargeBytes: 100,
+	})
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.EqualValues(50, throttler.atLargeBytesOwed)
+
+	node2Done := make(chan struct{})
+	go func() {
+		throttler.Acquire(context.Background(), 60, node2ID)
+		close(node2Done)
+	}()
+
+	// Wait for node2 to be waiting
+	require.Eventually(func() bool {
+		throttler.lock.Lock()
+		defer throttler.lock.Unlock()
+		return throttler.waitingToAcquire.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	// Only 50 bytes are given back, so node2 keeps waiting
+	release()
+	select {
+	case <-node2Done:
+		t.Fatal("should block on acquiring more bytes than the allocation has")
+	case <-time.After(50 * time.Millisecond):
+	}
+	throttler.lock.Lock()
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Zero(throttler.atLargeBytesOwed)
+	throttler.lock.Unlock()
+
+	// Growing the allocation gives the new bytes to node2
+	throttler.setConfig(MsgByteThrottlerConfig{
+		AtLargeAllocSize:    200,
+		NodeMaxAtLargeBytes: 100,
+	})
+	<-node2Done
+	throttler.lock.Lock()
+	require.EqualValues(140, throttler.remainingAtLargeBytes)
+	require.EqualValues(60, throttler.nodeToAtLargeBytesUsed[node2ID])
+	throttler.lock.Unlock()
+}
+
 func TestSybilMsgThrottlerMaxNonVdr(t *testing.T) {
 	require := require.New(t)
 	config := MsgByteThrottlerConfig{
@@ -27,7 +27,10 @@ type InboundMsgThrottler interface {
 	// needs to be called so that any allocated resources will be released
 	// invariant: There should be a maximum of 1 blocking call to Acquire for a
 	//            given nodeID. Callers must enforce this invariant.
-	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) ReleaseFunc
+	// The returned bool is true if [nodeID] had exceeded its bandwidth
+	// allocation. Unlike the time spent in Acquire, which also depends on how
+	// busy this node is, this only depends on the behavior of [nodeID].
+	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) (ReleaseFunc, bool)
 
 	// Add a new node to this throttler.
 	// Must be called before Acquire(..., [nodeID]) is called.
@@ -41,6 +44,13 @@ type InboundMsgThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// Update the byte allocations, the bandwidth allocation of each node and
+	// the maximum number of messages processed at once from each node to the
+	// values in [config]. The other limits can't change once the throttler is
+	// created.
+	// It's safe for multiple goroutines to concurrently call UpdateConfig.
+	UpdateConfig(config InboundMsgThrottlerConfig)
 }
 
 type InboundMsgThrottlerConfig struct {
@@ -150,11 +160,11 @@ type inboundMsgThrottler struct {
 // or when we give up trying to read the message, if applicable.
 // Even if [ctx] is canceled, The returned release function
 // needs to be called so that any allocated resources will be released.
-func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) ReleaseFunc {
+func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) (ReleaseFunc, bool) {
 	// Acquire space on the inbound message buffer
 	bufferRelease := t.bufferThrottler.Acquire(ctx, nodeID)
 	// Acquire bandwidth
-	t.bandwidthThrottler.Acquire(ctx, msgSize, nodeID)
+	exceededBandwidth := t.bandwidthThrottler.Acquire(ctx, msgSize, nodeID)
 	// Wait until our CPU usage drops to an acceptable level.
 	t.cpuThrottler.Acquire(ctx, nodeID)
 	// Wait until our disk usage drops to an acceptable level.
@@ -164,7 +174,7 @@ func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeI
 	return func() {
 		bufferRelease()
 		byteRelease()
-	}
+	}, exceededBandwidth
 }
 
 // See BandwidthThrottler.
@@ -176,3 +186,10 @@ func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
 }
+
+// See InboundMsgThrottler.
+func (t *inboundMsgThrottler) UpdateConfig(config InboundMsgThrottlerConfig) {
+	t.byteThrottler.setConfig(config.MsgByteThrottlerConfig)
+	t.bufferThrottler.setMaxProcessingMsgsPerNode(config.MaxProcessingMsgsPerNode)
+	t.bandwidthThrottler.SetConfig(config.BandwidthThrottlerConfig)
+}
@@ -19,10 +19,12 @@ func NewNoInboundThrottler() InboundMsgThrottler {
 // [Acquire] always returns immediately.
 type noInboundMsgThrottler struct{}
 
-func (*noInboundMsgThrottler) Acquire(context.Context, uint64, ids.NodeID) ReleaseFunc {
-	return noopRelease
+func (*noInboundMsgThrottler) Acquire(context.Context, uint64, ids.NodeID) (ReleaseFunc, bool) {
+	return noopRelease, false
 }
 
 func (*noInboundMsgThrottler) AddNode(ids.NodeID) {}
 
 func (*noInboundMsgThrottler) RemoveNode(ids.NodeID) {}
+
+func (*noInboundMsgThrottler) UpdateConfig(InboundMsgThrottlerConfig) {}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+var _ SubnetBandwidthThrottler = (*noSubnetBandwidthThrottler)(nil)
+
+// Returns a SubnetBandwidthThrottler where Acquire() always returns
+// immediately and Allow() always returns true.
+func NewNoSubnetBandwidthThrottler() SubnetBandwidthThrottler {
+	return &noSubnetBandwidthThrottler{}
+}
+
+// [Acquire] always returns immediately and [Allow] always returns true.
+type noSubnetBandwidthThrottler struct{}
+
+func (*noSubnetBandwidthThrottler) RegisterChain(ids.ID, ids.ID) {}
+
+func (*noSubnetBandwidthThrottler) AddSubnet(ids.ID, BandwidthThrottlerConfig) {}
+
+func (*noSubnetBandwidthThrottler) Acquire(context.Context, ids.ID, uint64) {}
+
+func (*noSubnetBandwidthThrottler) Allow(ids.ID, uint64) bool {
+	return true
+}
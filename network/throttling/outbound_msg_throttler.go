@@ -32,6 +32,11 @@ type OutboundMsgThrottler interface {
 	// sending the message. Must correspond to a previous call to
 	// Acquire([msg], [nodeID]) that returned true.
 	Release(msg message.OutboundMessage, nodeID ids.NodeID)
+
+	// Resize the byte allocations to the sizes in [config]. Bytes acquired by
+	// messages that weren't released yet stay acquired.
+	// It's safe for multiple goroutines to concurrently call SetConfig.
+	SetConfig(config MsgByteThrottlerConfig)
 }
 
 type outboundMsgThrottler struct {
@@ -53,6 +58,7 @@ func NewSybilOutboundMsgThrottler(
 			maxVdrBytes:            config.VdrAllocSize,
 			remainingVdrBytes:      config.VdrAllocSize,
 			remainingAtLargeBytes:  config.AtLargeAllocSize,
+			atLargeAllocSize:       config.AtLargeAllocSize,
 			nodeMaxAtLargeBytes:    config.NodeMaxAtLargeBytes,
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
@@ -76,7 +82,7 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 		// only give as many bytes as needed
 		bytesNeeded,
 		// don't exceed per-node limit
-		t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[nodeID],
+		t.atLargeBytesAllowed(nodeID),
 		// don't give more bytes than are in the allocation
 		t.remainingAtLargeBytes,
 	)
@@ -147,19 +153,28 @@ func (t *outboundMsgThrottler) Release(msg message.OutboundMessage, nodeID ids.N
 	if t.nodeToVdrBytesUsed[nodeID] == 0 {
 		delete(t.nodeToVdrBytesUsed, nodeID)
 	}
-	t.remainingVdrBytes += vdrBytesToReturn
+	t.returnVdrBytes(vdrBytesToReturn)
 
 	// [atLargeBytesToReturn] is the number of bytes from [msgSize]
 	// that will be given to the at-large allocation.
 	atLargeBytesToReturn := msgSize - vdrBytesToReturn
 	// Mark that [nodeID] has released these bytes.
-	t.remainingAtLargeBytes += atLargeBytesToReturn
+	t.returnAtLargeBytes(atLargeBytesToReturn)
 	t.nodeToAtLargeBytesUsed[nodeID] -= atLargeBytesToReturn
 	if t.nodeToAtLargeBytesUsed[nodeID] == 0 {
 		delete(t.nodeToAtLargeBytesUsed, nodeID)
 	}
 }
 
+func (t *outboundMsgThrottler) SetConfig(config MsgByteThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.commonMsgThrottler.setConfig(config)
+	t.metrics.remainingAtLargeBytes.Set(float64(t.remainingAtLargeBytes))
+	t.metrics.remainingVdrBytes.Set(float64(t.remainingVdrBytes))
+}
+
 type outboundMsgThrottlerMetrics struct {
 	acquireSuccesses      prometheus.Counter
 	acquireFailures       prometheus.Counter
@@ -209,7 +224,7 @@ func NewNoOutboundThrottler() OutboundMsgThrottler {
 	return &noOutboundMsgThrottler{}
 }
 
-// [Acquire] always returns true. [Release] does nothing.
+// [Acquire] always returns true. [Release] and [SetConfig] do nothing.
 type noOutboundMsgThrottler struct{}
 
 func (*noOutboundMsgThrottler) Acquire(message.OutboundMessage, ids.NodeID) bool {
@@ -217,3 +232,5 @@ func (*noOutboundMsgThrottler) Acquire(message.OutboundMessage, ids.NodeID) bool
 }
 
 func (*noOutboundMsgThrottler) Release(message.OutboundMessage, ids.NodeID) {}
+
+func (*noOutboundMsgThrottler) SetConfig(MsgByteThrottlerConfig) {}
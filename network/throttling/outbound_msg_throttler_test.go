@@ -212,6 +212,74 @@ func TestSybilOutboundMsgThrottlerMaxNonVdr(t *testing.T) {
 	require.EqualValues(config.AtLargeAllocSize-config.NodeMaxAtLargeBytes*3, throttler.remainingAtLargeBytes)
 }
 
+// Ensure that the allocations can be resized while bytes are acquired
+func TestSybilOutboundMsgThrottlerSetConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	require := require.New(t)
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        100,
+		AtLargeAllocSize:    100,
+		NodeMaxAtLargeBytes: 100,
+	}
+	vdrs := validators.NewSet()
+	vdr1ID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdr1ID, nil, ids.Empty, 1))
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		vdrs,
+		config,
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+	nonVdrNodeID := ids.GenerateTestNodeID()
+	msg := testMsgWithSize(ctrl, 100)
+	require.True(throttlerIntf.Acquire(msg, nonVdrNodeID))
+	require.True(throttlerIntf.Acquire(msg, vdr1ID))
+
+	// Shrinking the allocations to less than the acquired bytes leaves no
+	// bytes remaining until enough bytes are released.
+	throttlerIntf.SetConfig(MsgByteThrottlerConfig{
+		VdrAllocSize:        50,
+		AtLargeAllocSize:    50,
+		NodeMaxAtLargeBytes: 10,
+	})
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Zero(throttler.remainingVdrBytes)
+	require.EqualValues(50, throttler.atLargeBytesOwed)
+	require.EqualValues(50, throttler.vdrBytesOwed)
+
+	throttlerIntf.Release(msg, nonVdrNodeID)
+	throttlerIntf.Release(msg, vdr1ID)
+	require.EqualValues(50, throttler.remainingAtLargeBytes)
+	require.EqualValues(50, throttler.remainingVdrBytes)
+	require.Zero(throttler.atLargeBytesOwed)
+	require.Zero(throttler.vdrBytesOwed)
+
+	// The new per-node limit is enforced
+	require.False(throttlerIntf.Acquire(testMsgWithSize(ctrl, 11), nonVdrNodeID))
+	msg = testMsgWithSize(ctrl, 10)
+	require.True(throttlerIntf.Acquire(msg, nonVdrNodeID))
+
+	// Lowering the per-node limit below the bytes a node acquired doesn't
+	// allow it to acquire more.
+	throttlerIntf.SetConfig(MsgByteThrottlerConfig{
+		VdrAllocSize:        200,
+		AtLargeAllocSize:    200,
+		NodeMaxAtLargeBytes: 5,
+	})
+	require.EqualValues(190, throttler.remainingAtLargeBytes)
+	require.EqualValues(200, throttler.remainingVdrBytes)
+	require.False(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1), nonVdrNodeID))
+
+	throttlerIntf.Release(msg, nonVdrNodeID)
+	require.EqualValues(200, throttler.remainingAtLargeBytes)
+	require.Empty(throttler.nodeToAtLargeBytesUsed)
+}
+
 // Ensure that the throttler honors requested bypasses
 func TestBypassThrottling(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+const subnetIDLabel = "subnetID"
+
+var _ SubnetBandwidthThrottler = (*subnetBandwidthThrottler)(nil)
+
+// SubnetBandwidthThrottler rate-limits the bandwidth consumed by the messages
+// received for the chains of each subnet, so that the messages of one subnet
+// can't starve the messages of the other subnets.
+// It uses a token bucket model per subnet, where each token is 1 byte of a
+// message as it was read from the wire.
+// See https://pkg.go.dev/golang.org/x/time/rate#Limiter
+type SubnetBandwidthThrottler interface {
+	// RegisterChain charges the messages of [chainID] to the budget of
+	// [subnetID].
+	RegisterChain(chainID ids.ID, subnetID ids.ID)
+
+	// AddSubnet gives [subnetID] a budget of [config] if its refill rate is
+	// non-zero. Only the chains registered after the budget is given are
+	// charged to it. Does nothing if [subnetID] already has a budget.
+	AddSubnet(subnetID ids.ID, config BandwidthThrottlerConfig)
+
+	// Acquire blocks until a message of [msgSize] bytes for [chainID] fits in
+	// the budget of the chain's subnet, and then consumes the bytes. Returns
+	// immediately if the chain's subnet doesn't have a budget or if [ctx] is
+	// canceled.
+	// It's safe for multiple goroutines to concurrently call Acquire.
+	Acquire(ctx context.Context, chainID ids.ID, msgSize uint64)
+
+	// Allow returns true if a message of [msgSize] bytes for [chainID] fits in
+	// the budget of the chain's subnet, in which case the bytes are consumed.
+	// If false is returned, the message should be dropped. Messages of chains
+	// whose subnet doesn't have a budget are always allowed.
+	// Allow never blocks.
+	// It's safe for multiple goroutines to concurrently call Allow.
+	Allow(chainID ids.ID, msgSize uint64) bool
+}
+
+type subnetBandwidthThrottlerMetrics struct {
+	bytesAdmitted *prometheus.CounterVec
+	bytesDropped  *prometheus.CounterVec
+	waitTime      *prometheus.CounterVec
+}
+
+type subnetBandwidthThrottler struct {
+	metrics subnetBandwidthThrottlerMetrics
+
+	lock sync.RWMutex
+	// Subnet ID --> token bucket based rate limiter where each token is a
+	// byte of bandwidth. Subnets without a limiter aren't rate-limited.
+	limiters map[ids.ID]*rate.Limiter
+	// Chain ID --> limiter of the chain's subnet, for the chains of the
+	// subnets with a limiter.
+	chainLimiters map[ids.ID]chainLimiter
+}
+
+type chainLimiter struct {
+	subnetID ids.ID
+	limiter  *rate.Limiter
+}
+
+// NewSubnetBandwidthThrottler returns a new SubnetBandwidthThrottler. Each
+// subnet in [configs] with a non-zero refill rate is given its own bandwidth
+// budget. The messages of the other subnets aren't rate-limited.
+func NewSubnetBandwidthThrottler(
+	namespace string,
+	registerer prometheus.Registerer,
+	configs map[ids.ID]BandwidthThrottlerConfig,
+) (SubnetBandwidthThrottler, error) {
+	t := &subnetBandwidthThrottler{
+		metrics: subnetBandwidthThrottlerMetrics{
+			bytesAdmitted: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Name:      "subnet_bandwidth_throttler_bytes_admitted",
+					Help:      "Number of bytes admitted by the subnet bandwidth throttler",
+				},
+				[]string{subnetIDLabel},
+			),
+			bytesDropped: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Name:      "subnet_bandwidth_throttler_bytes_dropped",
+					Help:      "Number of bytes of the messages dropped by the subnet bandwidth throttler",
+				},
+				[]string{subnetIDLabel},
+			),
+			waitTime: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Name:      "subnet_bandwidth_throttler_wait_time",
+					Help:      "Time (in ns) spent waiting to acquire bytes from the subnet bandwidth throttler",
+				},
+				[]string{subnetIDLabel},
+			),
+		},
+		limiters:      make(map[ids.ID]*rate.Limiter),
+		chainLimiters: make(map[ids.ID]chainLimiter),
+	}
+	for subnetID, config := range configs {
+		t.AddSubnet(subnetID, config)
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(t.metrics.bytesAdmitted),
+		registerer.Register(t.metrics.bytesDropped),
+		registerer.Register(t.metrics.waitTime),
+	)
+	return t, errs.Err
+}
+
+// See SubnetBandwidthThrottler.
+func (t *subnetBandwidthThrottler) RegisterChain(chainID ids.ID, subnetID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	limiter, ok := t.limiters[subnetID]
+	if !ok {
+		return
+	}
+	t.chainLimiters[chainID] = chainLimiter{
+		subnetID: subnetID,
+		limiter:  limiter,
+	}
+}
+
+// See SubnetBandwidthThrottler.
+func (t *subnetBandwidthThrottler) AddSubnet(subnetID ids.ID, config BandwidthThrottlerConfig) {
+	if config.RefillRate == 0 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.limiters[subnetID]; ok {
+		return
+	}
+	t.limiters[subnetID] = rate.NewLimiter(rate.Limit(config.RefillRate), int(config.MaxBurstSize))
+}
+
+// See SubnetBandwidthThrottler.
+func (t *subnetBandwidthThrottler) Acquire(ctx context.Context, chainID ids.ID, msgSize uint64) {
+	t.lock.RLock()
+	chain, ok := t.chainLimiters[chainID]
+	t.lock.RUnlock()
+	if !ok {
+		return
+	}
+
+	subnetIDStr := chain.subnetID.String()
+	startTime := time.Now()
+	// A message larger than the burst size can never fit in the budget, so
+	// WaitN returns an error immediately and the message is admitted anyway.
+	// Otherwise, this only errors on shutdown.
+	if err := chain.limiter.WaitN(ctx, int(msgSize)); err != nil && ctx.Err() != nil {
+		return
+	}
+	t.metrics.waitTime.WithLabelValues(subnetIDStr).Add(float64(time.Since(startTime)))
+	t.metrics.bytesAdmitted.WithLabelValues(subnetIDStr).Add(float64(msgSize))
+}
+
+// See SubnetBandwidthThrottler.
+func (t *subnetBandwidthThrottler) Allow(chainID ids.ID, msgSize uint64) bool {
+	t.lock.RLock()
+	chain, ok := t.chainLimiters[chainID]
+	t.lock.RUnlock()
+	if !ok {
+		return true
+	}
+
+	subnetIDStr := chain.subnetID.String()
+	if !chain.limiter.AllowN(time.Now(), int(msgSize)) {
+		t.metrics.bytesDropped.WithLabelValues(subnetIDStr).Add(float64(msgSize))
+		return false
+	}
+	t.metrics.bytesAdmitted.WithLabelValues(subnetIDStr).Add(float64(msgSize))
+	return true
+}
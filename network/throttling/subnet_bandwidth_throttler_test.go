@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestSubnetBandwidthThrottler(t *testing.T) {
+	require := require.New(t)
+
+	throttledSubnetID := ids.GenerateTestID()
+	unthrottledSubnetID := ids.GenerateTestID()
+	configs := map[ids.ID]BandwidthThrottlerConfig{
+		throttledSubnetID: {
+			RefillRate:   1,
+			MaxBurstSize: 10,
+		},
+		unthrottledSubnetID: {},
+	}
+	registry := prometheus.NewRegistry()
+	throttlerIntf, err := NewSubnetBandwidthThrottler("", registry, configs)
+	require.NoError(err)
+	throttler, ok := throttlerIntf.(*subnetBandwidthThrottler)
+	require.True(ok)
+
+	// Only subnets with a refill rate are given a budget.
+	require.Len(throttler.limiters, 1)
+	require.Contains(throttler.limiters, throttledSubnetID)
+
+	throttledChainID := ids.GenerateTestID()
+	unthrottledChainID := ids.GenerateTestID()
+	unregisteredChainID := ids.GenerateTestID()
+	throttler.RegisterChain(throttledChainID, throttledSubnetID)
+	throttler.RegisterChain(unthrottledChainID, unthrottledSubnetID)
+
+	// Only the chains of subnets with a budget are tracked.
+	require.Len(throttler.chainLimiters, 1)
+	require.Equal(throttledSubnetID, throttler.chainLimiters[throttledChainID].subnetID)
+
+	// Should be able to consume the burst size.
+	require.True(throttler.Allow(throttledChainID, 10))
+
+	// The budget is exhausted, so the message is dropped rather than waiting
+	// for the budget to refill.
+	require.False(throttler.Allow(throttledChainID, 10))
+
+	// Chains without a budget are never rate-limited.
+	for i := 0; i < 100; i++ {
+		require.True(throttler.Allow(unthrottledChainID, 10))
+		require.True(throttler.Allow(unregisteredChainID, 10))
+	}
+
+	// Only the bytes of throttled subnets are reported.
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	bytes := make(map[string]map[string]float64)
+	for _, metric := range metrics {
+		require.Equal(dto.MetricType_COUNTER, metric.GetType())
+		if metric.GetName() == "subnet_bandwidth_throttler_wait_time" {
+			continue
+		}
+		values := make(map[string]float64)
+		for _, m := range metric.GetMetric() {
+			values[m.GetLabel()[0].GetValue()] = m.GetCounter().GetValue()
+		}
+		bytes[metric.GetName()] = values
+	}
+	require.Equal(
+		map[string]map[string]float64{
+			"subnet_bandwidth_throttler_bytes_admitted": {throttledSubnetID.String(): 10},
+			"subnet_bandwidth_throttler_bytes_dropped":  {throttledSubnetID.String(): 10},
+		},
+		bytes,
+	)
+}
+
+func TestSubnetBandwidthThrottlerAcquire(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	registry := prometheus.NewRegistry()
+	throttler, err := NewSubnetBandwidthThrottler(
+		"",
+		registry,
+		map[ids.ID]BandwidthThrottlerConfig{
+			subnetID: {
+				RefillRate:   100,
+				MaxBurstSize: 10,
+			},
+		},
+	)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	unregisteredChainID := ids.GenerateTestID()
+	throttler.RegisterChain(chainID, subnetID)
+
+	// Should be able to consume the burst size without waiting.
+	throttler.Acquire(context.Background(), chainID, 10)
+
+	// The budget is exhausted, so this waits for it to refill.
+	startTime := time.Now()
+	throttler.Acquire(context.Background(), chainID, 10)
+	require.GreaterOrEqual(time.Since(startTime), 50*time.Millisecond)
+
+	// A message larger than the burst size is admitted rather than waiting
+	// forever.
+	throttler.Acquire(context.Background(), chainID, 11)
+
+	// Once the context is canceled, Acquire returns immediately.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	throttler.Acquire(ctx, chainID, 10)
+	require.False(throttler.Allow(chainID, 10))
+
+	// Chains without a budget never wait.
+	for i := 0; i < 100; i++ {
+		throttler.Acquire(context.Background(), unregisteredChainID, 10)
+	}
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+
+	values := make(map[string]float64)
+	for _, metric := range metrics {
+		for _, m := range metric.GetMetric() {
+			require.Equal(subnetID.String(), m.GetLabel()[0].GetValue())
+			values[metric.GetName()] = m.GetCounter().GetValue()
+		}
+	}
+	require.Equal(float64(31), values["subnet_bandwidth_throttler_bytes_admitted"])
+	require.Equal(float64(10), values["subnet_bandwidth_throttler_bytes_dropped"])
+	require.GreaterOrEqual(values["subnet_bandwidth_throttler_wait_time"], float64(50*time.Millisecond))
+}
+
+func TestSubnetBandwidthThrottlerAddSubnet(t *testing.T) {
+	require := require.New(t)
+
+	throttlerIntf, err := NewSubnetBandwidthThrottler("", prometheus.NewRegistry(), nil)
+	require.NoError(err)
+	throttler, ok := throttlerIntf.(*subnetBandwidthThrottler)
+	require.True(ok)
+
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+
+	// Subnets without a refill rate aren't given a budget.
+	throttler.AddSubnet(subnetID, BandwidthThrottlerConfig{})
+	require.Empty(throttler.limiters)
+
+	throttler.AddSubnet(subnetID, BandwidthThrottlerConfig{
+		RefillRate:   1,
+		MaxBurstSize: 10,
+	})
+	require.Len(throttler.limiters, 1)
+
+	// The budget of a subnet can't be replaced.
+	throttler.AddSubnet(subnetID, BandwidthThrottlerConfig{
+		RefillRate:   1,
+		MaxBurstSize: 100,
+	})
+
+	throttler.RegisterChain(chainID, subnetID)
+	require.True(throttler.Allow(chainID, 10))
+	require.False(throttler.Allow(chainID, 10))
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
 )
 
 func init() {
@@ -21,6 +22,12 @@ type trackedIP struct {
 
 	ip *peer.UnsignedIP
 
+	// alternateIPLock must be held while accessing [alternateIP].
+	alternateIPLock sync.RWMutex
+	// alternateIP is the IP of the other IP version that a dual-stack peer
+	// claimed at the same time as [ip], if any.
+	alternateIP *peer.UnsignedIP
+
 	stopTrackingOnce sync.Once
 	onStopTracking   chan struct{}
 }
@@ -41,6 +48,51 @@ func (ip *trackedIP) trackNewIP(newIP *peer.UnsignedIP) *trackedIP {
 	}
 }
 
+// retrack returns a new trackedIP of the same IPs, and stops tracking [ip].
+func (ip *trackedIP) retrack() *trackedIP {
+	newIP := ip.trackNewIP(ip.ip)
+	newIP.alternateIP = ip.getAlternateIP()
+	return newIP
+}
+
+// isAlternateIP returns true if [newIP] should be tracked as the alternate IP
+// of [ip]. That is, [newIP] was claimed at the same time as [ip.ip], is of the
+// other IP version, and isn't already tracked.
+func (ip *trackedIP) isAlternateIP(newIP *peer.UnsignedIP) bool {
+	if ip.ip.Timestamp != newIP.Timestamp || ip.ip.IP.IsIPv6() == newIP.IP.IsIPv6() {
+		return false
+	}
+	alternateIP := ip.getAlternateIP()
+	return alternateIP == nil || !alternateIP.IP.Equal(newIP.IP)
+}
+
+func (ip *trackedIP) getAlternateIP() *peer.UnsignedIP {
+	ip.alternateIPLock.RLock()
+	defer ip.alternateIPLock.RUnlock()
+
+	return ip.alternateIP
+}
+
+func (ip *trackedIP) setAlternateIP(alternateIP *peer.UnsignedIP) {
+	ip.alternateIPLock.Lock()
+	defer ip.alternateIPLock.Unlock()
+
+	ip.alternateIP = alternateIP
+}
+
+// dialOrder returns the IPs to attempt to dial, in the order they should be
+// attempted. If [preferIPv6] is true, IPv6 IPs are attempted first.
+func (ip *trackedIP) dialOrder(preferIPv6 bool) []ips.IPPort {
+	alternateIP := ip.getAlternateIP()
+	if alternateIP == nil {
+		return []ips.IPPort{ip.ip.IP}
+	}
+	if ip.ip.IP.IsIPv6() == preferIPv6 {
+		return []ips.IPPort{ip.ip.IP, alternateIP.IP}
+	}
+	return []ips.IPPort{alternateIP.IP, ip.ip.IP}
+}
+
 func (ip *trackedIP) getDelay() time.Duration {
 	ip.delayLock.RLock()
 	delay := ip.delay
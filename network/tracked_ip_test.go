@@ -4,10 +4,14 @@
 package network
 
 import (
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/utils/ips"
 )
 
 func TestTrackedIP(t *testing.T) {
@@ -46,3 +50,50 @@ func TestTrackedIP(t *testing.T) {
 	ip.stopTracking()
 	<-ip.onStopTracking
 }
+
+func TestTrackedIPDialOrder(t *testing.T) {
+	require := require.New(t)
+
+	ipv4 := &peer.UnsignedIP{
+		IP: ips.IPPort{
+			IP:   net.IPv4(123, 132, 123, 123),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+	}
+	ipv6 := &peer.UnsignedIP{
+		IP: ips.IPPort{
+			IP:   net.ParseIP("2001:db8::1"),
+			Port: 10000,
+		},
+		Timestamp: 1000,
+	}
+
+	ip := newTrackedIP(ipv4)
+	require.Equal([]ips.IPPort{ipv4.IP}, ip.dialOrder(true))
+
+	// Only an IP of the other version claimed at the same time is an alternate.
+	require.False(ip.isAlternateIP(ipv4))
+	require.False(ip.isAlternateIP(&peer.UnsignedIP{
+		IP:        ipv6.IP,
+		Timestamp: ipv6.Timestamp + 1,
+	}))
+	require.True(ip.isAlternateIP(ipv6))
+
+	ip.setAlternateIP(ipv6)
+	require.False(ip.isAlternateIP(ipv6))
+	require.Equal([]ips.IPPort{ipv6.IP, ipv4.IP}, ip.dialOrder(true))
+	require.Equal([]ips.IPPort{ipv4.IP, ipv6.IP}, ip.dialOrder(false))
+
+	// Retracking keeps the alternate IP.
+	retracked := ip.retrack()
+	<-ip.onStopTracking
+	require.Equal([]ips.IPPort{ipv6.IP, ipv4.IP}, retracked.dialOrder(true))
+
+	// Tracking a new IP drops the alternate IP.
+	newIP := retracked.trackNewIP(&peer.UnsignedIP{
+		IP:        ipv4.IP,
+		Timestamp: ipv4.Timestamp + 1,
+	})
+	require.Equal([]ips.IPPort{ipv4.IP}, newIP.dialOrder(true))
+}
@@ -76,6 +76,10 @@ type IPConfig struct {
 	IPPort           ips.DynamicIPPort `json:"ip"`
 	IPUpdater        dynamicip.Updater `json:"-"`
 	IPResolutionFreq time.Duration     `json:"ipResolutionFrequency"`
+	// IPv6Port is the IPv6 IP of a dual-stack node, which is advertised in
+	// addition to [IPPort]. Nil if the node only has one IP.
+	IPv6Port    ips.DynamicIPPort `json:"ipv6,omitempty"`
+	IPv6Updater dynamicip.Updater `json:"-"`
 	// True if we attempted NAT traversal
 	AttemptedNATTraversal bool `json:"attemptedNATTraversal"`
 	// Tries to perform network address translation
@@ -151,6 +155,9 @@ type Config struct {
 	// ID of the network this node should connect to
 	NetworkID uint32 `json:"networkID"`
 
+	// Time at which the P-chain switches from static to dynamic fees
+	DynamicFeesTime time.Time `json:"dynamicFeesTime"`
+
 	// Health
 	HealthCheckFreq time.Duration `json:"healthCheckFreq"`
 
@@ -240,4 +247,24 @@ type Config struct {
 	// ChainDataDir is the root path for per-chain directories where VMs can
 	// write arbitrary data.
 	ChainDataDir string `json:"chainDataDir"`
+
+	// ConfigReloader re-reads this config when the node is asked to reload
+	// it. If nil, the config can't be reloaded.
+	ConfigReloader ConfigReloader `json:"-"`
+}
+
+// ConfigReloader re-reads the config of a node from the same sources it was
+// originally read from.
+type ConfigReloader interface {
+	// Reload returns the re-read config, along with the keys whose values
+	// changed since the config was last committed. The values of
+	// [reloadedKeys] can be applied while the node is running, whereas the
+	// values of [restartRequiredKeys] only take effect once the node is
+	// restarted.
+	Reload() (config *Config, reloadedKeys []string, restartRequiredKeys []string, err error)
+
+	// Commit marks the config returned by the last call to Reload as applied.
+	// If the config isn't committed, the next call to Reload reports its
+	// changes again.
+	Commit()
 }
@@ -23,6 +23,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/exp/slices"
+
 	coreth "github.com/lasthyphen/coreth/plugin/evm"
 
 	"github.com/lasthyphen/dijetsnodego/api/admin"
@@ -38,6 +40,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database/leveldb"
 	"github.com/lasthyphen/dijetsnodego/database/manager"
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
+	"github.com/lasthyphen/dijetsnodego/database/pebble"
 	"github.com/lasthyphen/dijetsnodego/database/prefixdb"
 	"github.com/lasthyphen/dijetsnodego/genesis"
 	"github.com/lasthyphen/dijetsnodego/ids"
@@ -47,6 +50,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/network"
 	"github.com/lasthyphen/dijetsnodego/network/dialer"
 	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/network/quic"
 	"github.com/lasthyphen/dijetsnodego/network/throttling"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
@@ -85,11 +89,15 @@ import (
 )
 
 var (
-	genesisHashKey  = []byte("genesisID")
-	indexerDBPrefix = []byte{0x00}
+	genesisHashKey      = []byte("genesisID")
+	indexerDBPrefix     = []byte{0x00}
+	addressBookDBPrefix = []byte("address book")
+	bansDBPrefix        = []byte("bans")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
+
+	errConfigReloadUnsupported = errors.New("config reloading isn't supported")
 )
 
 // Node is an instance of an Avalanche node.
@@ -131,6 +139,9 @@ type Node struct {
 
 	uptimeCalculator uptime.LockedCalculator
 
+	// Starts tracking subnets on the P-chain while the node is running
+	subnetTracker config.LockedSubnetTracker
+
 	// dispatcher for events as they happen in consensus
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
@@ -157,6 +168,9 @@ type Node struct {
 	// This node's configuration
 	Config *Config
 
+	// Ensures that the config is only reloaded by one caller at a time.
+	reloadLock sync.Mutex
+
 	tracer trace.Tracer
 
 	// ensures that we only close the node once.
@@ -192,6 +206,10 @@ type Node struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	diskTargeter tracker.Targeter
+
+	// Rate-limits the bandwidth consumed by the messages received for the
+	// chains of each subnet.
+	subnetThrottler throttling.SubnetBandwidthThrottler
 }
 
 /*
@@ -203,11 +221,42 @@ type Node struct {
 // Initialize the networking layer.
 // Assumes [n.CPUTracker] and [n.CPUTargeter] have been initialized.
 func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
+	if n.Config.NetworkConfig.TLSKeyLogFile != "" {
+		var err error
+		n.tlsKeyLogWriterCloser, err = perms.Create(n.Config.NetworkConfig.TLSKeyLogFile, perms.ReadWrite)
+		if err != nil {
+			return err
+		}
+		n.Log.Warn("TLS key logging is enabled",
+			zap.String("filename", n.Config.NetworkConfig.TLSKeyLogFile),
+		)
+	}
+
+	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
+
 	currentIPPort := n.Config.IPPort.IPPort()
-	listener, err := net.Listen(constants.NetworkType, fmt.Sprintf(":%d", currentIPPort.Port))
+	listenAddress := fmt.Sprintf(":%d", currentIPPort.Port)
+	listener, err := net.Listen(constants.NetworkType, listenAddress)
 	if err != nil {
 		return err
 	}
+	peerDialer := dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log)
+
+	// With QUIC, TCP connections are still accepted and dialed as a fallback
+	// so that peers which don't use QUIC remain reachable.
+	if n.Config.NetworkConfig.Transport == network.QUICTransport {
+		quicListener, err := quic.Listen(listener.Addr().String(), tlsConfig, n.Config.NetworkConfig.ReadHandshakeTimeout)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+		listener = quic.NewFallbackListener(quicListener, listener)
+		peerDialer = dialer.NewFallbackDialer(
+			dialer.NewQUICDialer(tlsConfig, n.Config.NetworkConfig.DialerConfig, n.Log),
+			peerDialer,
+			n.Log,
+		)
+	}
 	// Wrap listener so it will only accept a certain number of incoming connections per second
 	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
@@ -231,25 +280,26 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		return errInvalidTLSKey
 	}
 
-	if n.Config.NetworkConfig.TLSKeyLogFile != "" {
-		n.tlsKeyLogWriterCloser, err = perms.Create(n.Config.NetworkConfig.TLSKeyLogFile, perms.ReadWrite)
-		if err != nil {
-			return err
-		}
-		n.Log.Warn("TLS key logging is enabled",
-			zap.String("filename", n.Config.NetworkConfig.TLSKeyLogFile),
-		)
+	reputation, err := peer.NewReputation(
+		n.Config.NetworkConfig.ReputationConfig.PenaltyHalflife,
+		n.networkNamespace,
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return err
 	}
 
-	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
-
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
-	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
+	n.Config.BenchlistConfig.Benchable = &penalizingBenchable{
+		Benchable:  n.Config.ConsensusRouter,
+		reputation: reputation,
+	}
 	n.Config.BenchlistConfig.StakingEnabled = n.Config.EnableStaking
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
+	n.subnetTracker = config.NewLockedSubnetTracker()
 
 	consensusRouter := n.Config.ConsensusRouter
 	if !n.Config.EnableStaking {
@@ -316,10 +366,24 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		GossipTracker: gossipTracker,
 	})
 
+	subnetBandwidthConfigs := make(map[ids.ID]throttling.BandwidthThrottlerConfig, len(n.Config.SubnetConfigs))
+	for subnetID, subnetConfig := range n.Config.SubnetConfigs {
+		subnetBandwidthConfigs[subnetID] = subnetConfig.BandwidthThrottlerConfig
+	}
+	n.subnetThrottler, err = throttling.NewSubnetBandwidthThrottler(
+		n.networkNamespace,
+		n.MetricsRegisterer,
+		subnetBandwidthConfigs,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize subnet bandwidth throttler: %w", err)
+	}
+
 	// add node configs to network config
 	n.Config.NetworkConfig.Namespace = n.networkNamespace
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIPPort = n.Config.IPPort
+	n.Config.NetworkConfig.MyIPv6Port = n.Config.IPv6Port
 	n.Config.NetworkConfig.NetworkID = n.Config.NetworkID
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.beacons
@@ -332,6 +396,13 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 	n.Config.NetworkConfig.GossipTracker = gossipTracker
+	n.Config.NetworkConfig.Benchlist = n.benchlistManager
+	n.Config.NetworkConfig.Reputation = reputation
+	n.Config.NetworkConfig.SubnetThrottler = n.subnetThrottler
+	if n.Config.NetworkConfig.AddressBookMaxAge > 0 {
+		n.Config.NetworkConfig.AddressBookDB = prefixdb.New(addressBookDBPrefix, n.DB)
+	}
+	n.Config.NetworkConfig.BansDB = prefixdb.New(bansDBPrefix, n.DB)
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -339,7 +410,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		n.MetricsRegisterer,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		peerDialer,
 		consensusRouter,
 	)
 
@@ -419,13 +490,20 @@ func (n *Node) initDatabase() error {
 	switch n.Config.DatabaseConfig.Name {
 	case leveldb.Name:
 		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+	case pebble.Name:
+		// Pebble databases are kept in their own directory so that switching
+		// --db-type on an existing node never opens a leveldb directory with
+		// pebble.
+		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, pebble.Name)
+		dbManager, err = manager.NewPebbleDB(dbPath, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
 	default:
 		err = fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s}",
+			"db-type was %q but should have been one of {%s, %s, %s}",
 			n.Config.DatabaseConfig.Name,
 			leveldb.Name,
+			pebble.Name,
 			memdb.Name,
 		)
 	}
@@ -713,6 +791,7 @@ func (n *Node) initChainManager(djtxAssetID ids.ID) error {
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
+		SubnetThrottler:                         n.subnetThrottler,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
@@ -753,6 +832,7 @@ func (n *Node) initVMs() error {
 				Chains:                          n.chainManager,
 				Validators:                      vdrs,
 				UptimeLockedCalculator:          n.uptimeCalculator,
+				SubnetTracker:                   n.subnetTracker,
 				StakingEnabled:                  n.Config.EnableStaking,
 				WhitelistedSubnets:              n.Config.WhitelistedSubnets,
 				TxFee:                           n.Config.TxFee,
@@ -775,6 +855,8 @@ func (n *Node) initVMs() error {
 				ApricotPhase3Time:               version.GetApricotPhase3Time(n.Config.NetworkID),
 				ApricotPhase5Time:               version.GetApricotPhase5Time(n.Config.NetworkID),
 				BanffTime:                       version.GetBanffTime(n.Config.NetworkID),
+				DynamicFeesTime:                 n.Config.DynamicFeesTime,
+				DynamicFeeConfig:                n.Config.DynamicFeeConfig,
 				MinPercentConnectedStakeHealthy: n.Config.MinPercentConnectedStakeHealthy,
 				UseCurrentHeight:                n.Config.UseCurrentHeight,
 			},
@@ -899,11 +981,17 @@ func (n *Node) initAdminAPI() error {
 			Log:          n.Log,
 			ChainManager: n.chainManager,
 			HTTPServer:   n.APIServer,
+			Network:      n.Net,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
 			NodeConfig:   n.Config,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+			NetworkID:    n.Config.NetworkID,
+			DB:           n.DB,
+			DBPath:       n.Config.DatabaseConfig.Path,
+			ChainDataDir: n.Config.ChainDataDir,
+			Reloader:     n,
 		},
 	)
 	if err != nil {
@@ -912,6 +1000,189 @@ func (n *Node) initAdminAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "admin", "")
 }
 
+// ReloadConfig re-reads the config of the node and applies the settings that
+// can change while the node is running: the log levels, the network health
+// thresholds, the peer list gossip sizes, the inbound and outbound message
+// throttler limits, the inbound connection upgrade cooldown, the benchlist
+// parameters, the chain aliases and the subnets added to the tracked subnets.
+// Returns the keys whose new values were applied and the keys whose new values
+// require a restart. Subnets can only stop being tracked after a restart.
+//
+// The new settings are applied entirely or not at all. If a setting can't be
+// applied, the settings applied before it are reverted and the node keeps
+// running with its previous config.
+func (n *Node) ReloadConfig() ([]string, []string, error) {
+	if n.Config.ConfigReloader == nil {
+		return nil, nil, errConfigReloadUnsupported
+	}
+
+	n.reloadLock.Lock()
+	defer n.reloadLock.Unlock()
+
+	config, reloadedKeys, restartRequiredKeys, err := n.Config.ConfigReloader.Reload()
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't reload config: %w", err)
+	}
+
+	restoreLogLevels, err := n.reloadLogLevels(config.LoggingConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't reload log levels: %w", err)
+	}
+
+	restoreChainAliases, err := n.reloadChainAliases(config.ChainAliases)
+	if err != nil {
+		restoreLogLevels()
+		return nil, nil, fmt.Errorf("couldn't reload chain aliases: %w", err)
+	}
+
+	if err := n.reloadTrackedSubnets(config); err != nil {
+		restoreChainAliases()
+		restoreLogLevels()
+		return nil, nil, fmt.Errorf("couldn't reload tracked subnets: %w", err)
+	}
+
+	// The remaining settings can't fail to be applied.
+	config.NetworkConfig.WhitelistedSubnets = config.WhitelistedSubnets
+	n.Net.UpdateConfig(&config.NetworkConfig)
+	for subnetID := range config.WhitelistedSubnets {
+		if !n.Config.WhitelistedSubnets.Contains(subnetID) {
+			// Mark this node as connected to the new subnets.
+			n.Config.ConsensusRouter.Connected(n.ID, version.CurrentApp, subnetID)
+		}
+	}
+
+	n.benchlistManager.SetParameters(
+		config.BenchlistConfig.Threshold,
+		config.BenchlistConfig.MinimumFailingDuration,
+		config.BenchlistConfig.Duration,
+	)
+
+	n.Config.LoggingConfig.LogLevel = config.LoggingConfig.LogLevel
+	n.Config.LoggingConfig.DisplayLevel = config.LoggingConfig.DisplayLevel
+	n.Config.ChainAliases = config.ChainAliases
+	n.Config.WhitelistedSubnets = config.WhitelistedSubnets
+	n.Config.SubnetConfigs = config.SubnetConfigs
+	n.Config.ConfigReloader.Commit()
+
+	n.Log.Info("reloaded config",
+		zap.Strings("reloadedKeys", reloadedKeys),
+		zap.Strings("restartRequiredKeys", restartRequiredKeys),
+	)
+	return reloadedKeys, restartRequiredKeys, nil
+}
+
+// reloadLogLevels sets the levels of every logger if they changed. Levels set
+// through the admin API are otherwise kept. The returned function restores the
+// levels the loggers had before. If a level can't be set, the previous levels
+// are restored before the error is returned.
+func (n *Node) reloadLogLevels(config logging.Config) (func(), error) {
+	if config.LogLevel == n.Config.LoggingConfig.LogLevel &&
+		config.DisplayLevel == n.Config.LoggingConfig.DisplayLevel {
+		return func() {}, nil
+	}
+
+	type levels struct {
+		logLevel     logging.Level
+		displayLevel logging.Level
+	}
+	previousLevels := make(map[string]levels)
+	restore := func() {
+		for name, previous := range previousLevels {
+			// These levels were just read from the same loggers, so setting
+			// them again can't fail.
+			_ = n.LogFactory.SetLogLevel(name, previous.logLevel)
+			_ = n.LogFactory.SetDisplayLevel(name, previous.displayLevel)
+		}
+	}
+
+	for _, name := range n.LogFactory.GetLoggerNames() {
+		logLevel, err := n.LogFactory.GetLogLevel(name)
+		if err != nil {
+			restore()
+			return nil, err
+		}
+		displayLevel, err := n.LogFactory.GetDisplayLevel(name)
+		if err != nil {
+			restore()
+			return nil, err
+		}
+		previousLevels[name] = levels{
+			logLevel:     logLevel,
+			displayLevel: displayLevel,
+		}
+
+		if err := n.LogFactory.SetLogLevel(name, config.LogLevel); err != nil {
+			restore()
+			return nil, err
+		}
+		if err := n.LogFactory.SetDisplayLevel(name, config.DisplayLevel); err != nil {
+			restore()
+			return nil, err
+		}
+	}
+	return restore, nil
+}
+
+// reloadChainAliases gives chains the aliases of [chainAliases] they don't
+// already have. Aliases can't be removed while the node is running. The
+// returned function gives the chains back the aliases they had before. If an
+// alias can't be added, the previous aliases are restored before the error is
+// returned.
+func (n *Node) reloadChainAliases(chainAliases map[ids.ID][]string) (func(), error) {
+	previousAliases := make(map[ids.ID][]string, len(chainAliases))
+	restore := func() {
+		for chainID, aliases := range previousAliases {
+			n.chainManager.RemoveAliases(chainID)
+			for _, alias := range aliases {
+				// These aliases were just removed from this chain, so they
+				// can't be used by another chain.
+				_ = n.chainManager.Alias(chainID, alias)
+			}
+		}
+	}
+
+	for chainID, aliases := range chainAliases {
+		currentAliases, err := n.chainManager.Aliases(chainID)
+		if err != nil {
+			restore()
+			return nil, err
+		}
+		previousAliases[chainID] = currentAliases
+		for _, alias := range aliases {
+			if slices.Contains(currentAliases, alias) {
+				continue
+			}
+			if err := n.chainManager.Alias(chainID, alias); err != nil {
+				restore()
+				return nil, err
+			}
+		}
+	}
+	return restore, nil
+}
+
+// reloadTrackedSubnets starts tracking the subnets of [config] that aren't
+// tracked yet. Their bandwidth budgets and subnet configs are set before the
+// P-chain creates their chains. They are kept if the P-chain fails to track
+// the subnets, as they aren't used until a chain of the subnets is created.
+func (n *Node) reloadTrackedSubnets(config *Config) error {
+	newSubnetIDs := []ids.ID{}
+	for subnetID := range config.WhitelistedSubnets {
+		if n.Config.WhitelistedSubnets.Contains(subnetID) {
+			continue
+		}
+		newSubnetIDs = append(newSubnetIDs, subnetID)
+		if subnetConfig, ok := config.SubnetConfigs[subnetID]; ok {
+			n.subnetThrottler.AddSubnet(subnetID, subnetConfig.BandwidthThrottlerConfig)
+			n.chainManager.SetSubnetConfig(subnetID, subnetConfig)
+		}
+	}
+	if len(newSubnetIDs) == 0 {
+		return nil
+	}
+	return n.subnetTracker.TrackSubnets(newSubnetIDs)
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -1258,7 +1529,7 @@ func (n *Node) Initialize(
 	n.msgCreator, err = message.NewCreator(
 		n.MetricsRegisterer,
 		n.networkNamespace,
-		n.Config.NetworkConfig.CompressionEnabled,
+		n.Config.NetworkConfig.CompressionType,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
 	if err != nil {
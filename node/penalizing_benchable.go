@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/network/peer"
+	"github.com/lasthyphen/dijetsnodego/snow/networking/benchlist"
+)
+
+// penalizingBenchable counts every benching of a peer against its reputation.
+type penalizingBenchable struct {
+	benchlist.Benchable
+	reputation peer.Reputation
+}
+
+func (p *penalizingBenchable) Benched(chainID ids.ID, nodeID ids.NodeID) {
+	p.reputation.Penalize(nodeID, peer.BenchedOffense)
+	p.Benchable.Benched(chainID, nodeID)
+}
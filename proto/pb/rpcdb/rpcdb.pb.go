@@ -866,6 +866,116 @@ func (x *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	return 0
 }
 
+type NewIteratorWithRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start   []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End     []byte `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Reverse bool   `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+}
+
+func (x *NewIteratorWithRangeRequest) Reset() {
+	*x = NewIteratorWithRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewIteratorWithRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewIteratorWithRangeRequest) ProtoMessage() {}
+
+func (x *NewIteratorWithRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewIteratorWithRangeRequest.ProtoReflect.Descriptor instead.
+func (*NewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NewIteratorWithRangeRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *NewIteratorWithRangeRequest) GetEnd() []byte {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *NewIteratorWithRangeRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type NewIteratorWithRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *NewIteratorWithRangeResponse) Reset() {
+	*x = NewIteratorWithRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewIteratorWithRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewIteratorWithRangeResponse) ProtoMessage() {}
+
+func (x *NewIteratorWithRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewIteratorWithRangeResponse.ProtoReflect.Descriptor instead.
+func (*NewIteratorWithRangeResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NewIteratorWithRangeResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type IteratorNextRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -877,20 +987,507 @@ type IteratorNextRequest struct {
 func (x *IteratorNextRequest) Reset() {
 	*x = IteratorNextRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorNextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorNextRequest) ProtoMessage() {}
+
+func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorNextRequest.ProtoReflect.Descriptor instead.
+func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *IteratorNextRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type IteratorNextResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *IteratorNextResponse) Reset() {
+	*x = IteratorNextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorNextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorNextResponse) ProtoMessage() {}
+
+func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
+func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IteratorNextResponse) GetData() []*PutRequest {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type IteratorErrorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *IteratorErrorRequest) Reset() {
+	*x = IteratorErrorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorErrorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorErrorRequest) ProtoMessage() {}
+
+func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorErrorRequest.ProtoReflect.Descriptor instead.
+func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IteratorErrorRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type IteratorErrorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (x *IteratorErrorResponse) Reset() {
+	*x = IteratorErrorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorErrorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorErrorResponse) ProtoMessage() {}
+
+func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorErrorResponse.ProtoReflect.Descriptor instead.
+func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IteratorErrorResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type IteratorReleaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *IteratorReleaseRequest) Reset() {
+	*x = IteratorReleaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorReleaseRequest) ProtoMessage() {}
+
+func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorReleaseRequest.ProtoReflect.Descriptor instead.
+func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IteratorReleaseRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type IteratorReleaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (x *IteratorReleaseResponse) Reset() {
+	*x = IteratorReleaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorReleaseResponse) ProtoMessage() {}
+
+func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorReleaseResponse.ProtoReflect.Descriptor instead.
+func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *IteratorReleaseResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type NewSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NewSnapshotRequest) Reset() {
+	*x = NewSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewSnapshotRequest) ProtoMessage() {}
+
+func (x *NewSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{25}
+}
+
+type NewSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Err uint32 `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (x *NewSnapshotResponse) Reset() {
+	*x = NewSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewSnapshotResponse) ProtoMessage() {}
+
+func (x *NewSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *NewSnapshotResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NewSnapshotResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type SnapshotHasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *SnapshotHasRequest) Reset() {
+	*x = SnapshotHasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotHasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotHasRequest) ProtoMessage() {}
+
+func (x *SnapshotHasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotHasRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotHasRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SnapshotHasRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnapshotHasRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type SnapshotGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *SnapshotGetRequest) Reset() {
+	*x = SnapshotGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotGetRequest) ProtoMessage() {}
+
+func (x *SnapshotGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotGetRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotGetRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SnapshotGetRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnapshotGetRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithStartAndPrefixRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start  []byte `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Prefix []byte `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *SnapshotNewIteratorWithStartAndPrefixRequest) Reset() {
+	*x = SnapshotNewIteratorWithStartAndPrefixRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorNextRequest) String() string {
+func (x *SnapshotNewIteratorWithStartAndPrefixRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorNextRequest) ProtoMessage() {}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) ProtoMessage() {}
 
-func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+func (x *SnapshotNewIteratorWithStartAndPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,43 +1498,58 @@ func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorNextRequest.ProtoReflect.Descriptor instead.
-func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use SnapshotNewIteratorWithStartAndPrefixRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *IteratorNextRequest) GetId() uint64 {
+func (x *SnapshotNewIteratorWithStartAndPrefixRequest) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type IteratorNextResponse struct {
+func (x *SnapshotNewIteratorWithStartAndPrefixRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *SnapshotNewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithStartAndPrefixResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Err uint32 `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
 }
 
-func (x *IteratorNextResponse) Reset() {
-	*x = IteratorNextResponse{}
+func (x *SnapshotNewIteratorWithStartAndPrefixResponse) Reset() {
+	*x = SnapshotNewIteratorWithStartAndPrefixResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorNextResponse) String() string {
+func (x *SnapshotNewIteratorWithStartAndPrefixResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorNextResponse) ProtoMessage() {}
+func (*SnapshotNewIteratorWithStartAndPrefixResponse) ProtoMessage() {}
 
-func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+func (x *SnapshotNewIteratorWithStartAndPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,43 +1560,53 @@ func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
-func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use SnapshotNewIteratorWithStartAndPrefixResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotNewIteratorWithStartAndPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *IteratorNextResponse) GetData() []*PutRequest {
+func (x *SnapshotNewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	if x != nil {
-		return x.Data
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type IteratorErrorRequest struct {
+func (x *SnapshotNewIteratorWithStartAndPrefixResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type SnapshotNewIteratorWithRangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start   []byte `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End     []byte `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Reverse bool   `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"`
 }
 
-func (x *IteratorErrorRequest) Reset() {
-	*x = IteratorErrorRequest{}
+func (x *SnapshotNewIteratorWithRangeRequest) Reset() {
+	*x = SnapshotNewIteratorWithRangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorErrorRequest) String() string {
+func (x *SnapshotNewIteratorWithRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorErrorRequest) ProtoMessage() {}
+func (*SnapshotNewIteratorWithRangeRequest) ProtoMessage() {}
 
-func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+func (x *SnapshotNewIteratorWithRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -995,43 +1617,65 @@ func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorErrorRequest.ProtoReflect.Descriptor instead.
-func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SnapshotNewIteratorWithRangeRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotNewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *IteratorErrorRequest) GetId() uint64 {
+func (x *SnapshotNewIteratorWithRangeRequest) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type IteratorErrorResponse struct {
+func (x *SnapshotNewIteratorWithRangeRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *SnapshotNewIteratorWithRangeRequest) GetEnd() []byte {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *SnapshotNewIteratorWithRangeRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type SnapshotNewIteratorWithRangeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Err uint32 `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
 }
 
-func (x *IteratorErrorResponse) Reset() {
-	*x = IteratorErrorResponse{}
+func (x *SnapshotNewIteratorWithRangeResponse) Reset() {
+	*x = SnapshotNewIteratorWithRangeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorErrorResponse) String() string {
+func (x *SnapshotNewIteratorWithRangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorErrorResponse) ProtoMessage() {}
+func (*SnapshotNewIteratorWithRangeResponse) ProtoMessage() {}
 
-func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+func (x *SnapshotNewIteratorWithRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1042,19 +1686,26 @@ func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorErrorResponse.ProtoReflect.Descriptor instead.
-func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SnapshotNewIteratorWithRangeResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotNewIteratorWithRangeResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *IteratorErrorResponse) GetErr() uint32 {
+func (x *SnapshotNewIteratorWithRangeResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnapshotNewIteratorWithRangeResponse) GetErr() uint32 {
 	if x != nil {
 		return x.Err
 	}
 	return 0
 }
 
-type IteratorReleaseRequest struct {
+type SnapshotReleaseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1062,23 +1713,23 @@ type IteratorReleaseRequest struct {
 	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *IteratorReleaseRequest) Reset() {
-	*x = IteratorReleaseRequest{}
+func (x *SnapshotReleaseRequest) Reset() {
+	*x = SnapshotReleaseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorReleaseRequest) String() string {
+func (x *SnapshotReleaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorReleaseRequest) ProtoMessage() {}
+func (*SnapshotReleaseRequest) ProtoMessage() {}
 
-func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+func (x *SnapshotReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1089,19 +1740,19 @@ func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorReleaseRequest.ProtoReflect.Descriptor instead.
-func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SnapshotReleaseRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *IteratorReleaseRequest) GetId() uint64 {
+func (x *SnapshotReleaseRequest) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type IteratorReleaseResponse struct {
+type SnapshotReleaseResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1109,23 +1760,23 @@ type IteratorReleaseResponse struct {
 	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
 }
 
-func (x *IteratorReleaseResponse) Reset() {
-	*x = IteratorReleaseResponse{}
+func (x *SnapshotReleaseResponse) Reset() {
+	*x = SnapshotReleaseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorReleaseResponse) String() string {
+func (x *SnapshotReleaseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorReleaseResponse) ProtoMessage() {}
+func (*SnapshotReleaseResponse) ProtoMessage() {}
 
-func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+func (x *SnapshotReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1136,12 +1787,12 @@ func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorReleaseResponse.ProtoReflect.Descriptor instead.
-func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SnapshotReleaseResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *IteratorReleaseResponse) GetErr() uint32 {
+func (x *SnapshotReleaseResponse) GetErr() uint32 {
 	if x != nil {
 		return x.Err
 	}
@@ -1159,7 +1810,7 @@ type HealthCheckResponse struct {
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1172,7 +1823,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,7 +1836,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *HealthCheckResponse) GetDetails() []byte {
@@ -1253,81 +1904,172 @@ var file_rpcdb_rpcdb_proto_rawDesc = []byte{
 	0x65, 0x66, 0x69, 0x78, 0x22, 0x37, 0x0a, 0x25, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61,
 	0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50,
 	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x25, 0x0a,
-	0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
-	0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63,
-	0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x15, 0x49,
-	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0x2b, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65,
-	0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a,
-	0x13, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x32, 0xa2,
-	0x06, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48,
-	0x61, 0x73, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74,
-	0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
-	0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12,
-	0x13, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f,
-	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65,
-	0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
-	0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x7a, 0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57,
-	0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69,
-	0x78, 0x12, 0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e,
-	0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x5f, 0x0a,
+	0x1b, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x22, 0x2e,
+	0x0a, 0x1c, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x25,
+	0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x15,
+	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x2b, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x14,
+	0x0a, 0x12, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a, 0x13, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x36, 0x0a,
+	0x12, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x36, 0x0a, 0x12, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x6c, 0x0a,
+	0x2c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x51, 0x0a, 0x2d, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
 	0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c,
-	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72,
-	0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
-	0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49,
-	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x50, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61,
-	0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f,
-	0x72, 0x70, 0x63, 0x64, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x77,
+	0x0a, 0x23, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x22, 0x48, 0x0a, 0x24, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69,
+	0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72,
+	0x72, 0x22, 0x28, 0x0a, 0x16, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x17, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x32, 0xa5, 0x0b, 0x0a, 0x08, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48, 0x61, 0x73, 0x12, 0x11, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x35, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a, 0x0a, 0x1d, 0x4e,
+	0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x2b, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x14, 0x4e, 0x65, 0x77, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x22, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
+	0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a,
+	0x0f, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x44, 0x0a, 0x0b, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x19,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x48, 0x61, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x47,
+	0x65, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x92, 0x01, 0x0a, 0x25, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4e, 0x65,
+	0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x33, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x34, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x77, 0x0a, 0x1c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69,
+	0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x50, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6c, 0x61, 0x73, 0x74, 0x68, 0x79, 0x70, 0x68, 0x65, 0x6e, 0x2f, 0x64, 0x69, 0x6a, 0x65, 0x74,
+	0x73, 0x6e, 0x6f, 0x64, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62,
+	0x2f, 0x72, 0x70, 0x63, 0x64, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1342,33 +2084,45 @@ func file_rpcdb_rpcdb_proto_rawDescGZIP() []byte {
 	return file_rpcdb_rpcdb_proto_rawDescData
 }
 
-var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_rpcdb_rpcdb_proto_goTypes = []interface{}{
-	(*HasRequest)(nil),                            // 0: rpcdb.HasRequest
-	(*HasResponse)(nil),                           // 1: rpcdb.HasResponse
-	(*GetRequest)(nil),                            // 2: rpcdb.GetRequest
-	(*GetResponse)(nil),                           // 3: rpcdb.GetResponse
-	(*PutRequest)(nil),                            // 4: rpcdb.PutRequest
-	(*PutResponse)(nil),                           // 5: rpcdb.PutResponse
-	(*DeleteRequest)(nil),                         // 6: rpcdb.DeleteRequest
-	(*DeleteResponse)(nil),                        // 7: rpcdb.DeleteResponse
-	(*CompactRequest)(nil),                        // 8: rpcdb.CompactRequest
-	(*CompactResponse)(nil),                       // 9: rpcdb.CompactResponse
-	(*CloseRequest)(nil),                          // 10: rpcdb.CloseRequest
-	(*CloseResponse)(nil),                         // 11: rpcdb.CloseResponse
-	(*WriteBatchRequest)(nil),                     // 12: rpcdb.WriteBatchRequest
-	(*WriteBatchResponse)(nil),                    // 13: rpcdb.WriteBatchResponse
-	(*NewIteratorRequest)(nil),                    // 14: rpcdb.NewIteratorRequest
-	(*NewIteratorWithStartAndPrefixRequest)(nil),  // 15: rpcdb.NewIteratorWithStartAndPrefixRequest
-	(*NewIteratorWithStartAndPrefixResponse)(nil), // 16: rpcdb.NewIteratorWithStartAndPrefixResponse
-	(*IteratorNextRequest)(nil),                   // 17: rpcdb.IteratorNextRequest
-	(*IteratorNextResponse)(nil),                  // 18: rpcdb.IteratorNextResponse
-	(*IteratorErrorRequest)(nil),                  // 19: rpcdb.IteratorErrorRequest
-	(*IteratorErrorResponse)(nil),                 // 20: rpcdb.IteratorErrorResponse
-	(*IteratorReleaseRequest)(nil),                // 21: rpcdb.IteratorReleaseRequest
-	(*IteratorReleaseResponse)(nil),               // 22: rpcdb.IteratorReleaseResponse
-	(*HealthCheckResponse)(nil),                   // 23: rpcdb.HealthCheckResponse
-	(*emptypb.Empty)(nil),                         // 24: google.protobuf.Empty
+	(*HasRequest)(nil),                                    // 0: rpcdb.HasRequest
+	(*HasResponse)(nil),                                   // 1: rpcdb.HasResponse
+	(*GetRequest)(nil),                                    // 2: rpcdb.GetRequest
+	(*GetResponse)(nil),                                   // 3: rpcdb.GetResponse
+	(*PutRequest)(nil),                                    // 4: rpcdb.PutRequest
+	(*PutResponse)(nil),                                   // 5: rpcdb.PutResponse
+	(*DeleteRequest)(nil),                                 // 6: rpcdb.DeleteRequest
+	(*DeleteResponse)(nil),                                // 7: rpcdb.DeleteResponse
+	(*CompactRequest)(nil),                                // 8: rpcdb.CompactRequest
+	(*CompactResponse)(nil),                               // 9: rpcdb.CompactResponse
+	(*CloseRequest)(nil),                                  // 10: rpcdb.CloseRequest
+	(*CloseResponse)(nil),                                 // 11: rpcdb.CloseResponse
+	(*WriteBatchRequest)(nil),                             // 12: rpcdb.WriteBatchRequest
+	(*WriteBatchResponse)(nil),                            // 13: rpcdb.WriteBatchResponse
+	(*NewIteratorRequest)(nil),                            // 14: rpcdb.NewIteratorRequest
+	(*NewIteratorWithStartAndPrefixRequest)(nil),          // 15: rpcdb.NewIteratorWithStartAndPrefixRequest
+	(*NewIteratorWithStartAndPrefixResponse)(nil),         // 16: rpcdb.NewIteratorWithStartAndPrefixResponse
+	(*NewIteratorWithRangeRequest)(nil),                   // 17: rpcdb.NewIteratorWithRangeRequest
+	(*NewIteratorWithRangeResponse)(nil),                  // 18: rpcdb.NewIteratorWithRangeResponse
+	(*IteratorNextRequest)(nil),                           // 19: rpcdb.IteratorNextRequest
+	(*IteratorNextResponse)(nil),                          // 20: rpcdb.IteratorNextResponse
+	(*IteratorErrorRequest)(nil),                          // 21: rpcdb.IteratorErrorRequest
+	(*IteratorErrorResponse)(nil),                         // 22: rpcdb.IteratorErrorResponse
+	(*IteratorReleaseRequest)(nil),                        // 23: rpcdb.IteratorReleaseRequest
+	(*IteratorReleaseResponse)(nil),                       // 24: rpcdb.IteratorReleaseResponse
+	(*NewSnapshotRequest)(nil),                            // 25: rpcdb.NewSnapshotRequest
+	(*NewSnapshotResponse)(nil),                           // 26: rpcdb.NewSnapshotResponse
+	(*SnapshotHasRequest)(nil),                            // 27: rpcdb.SnapshotHasRequest
+	(*SnapshotGetRequest)(nil),                            // 28: rpcdb.SnapshotGetRequest
+	(*SnapshotNewIteratorWithStartAndPrefixRequest)(nil),  // 29: rpcdb.SnapshotNewIteratorWithStartAndPrefixRequest
+	(*SnapshotNewIteratorWithStartAndPrefixResponse)(nil), // 30: rpcdb.SnapshotNewIteratorWithStartAndPrefixResponse
+	(*SnapshotNewIteratorWithRangeRequest)(nil),           // 31: rpcdb.SnapshotNewIteratorWithRangeRequest
+	(*SnapshotNewIteratorWithRangeResponse)(nil),          // 32: rpcdb.SnapshotNewIteratorWithRangeResponse
+	(*SnapshotReleaseRequest)(nil),                        // 33: rpcdb.SnapshotReleaseRequest
+	(*SnapshotReleaseResponse)(nil),                       // 34: rpcdb.SnapshotReleaseResponse
+	(*HealthCheckResponse)(nil),                           // 35: rpcdb.HealthCheckResponse
+	(*emptypb.Empty)(nil),                                 // 36: google.protobuf.Empty
 }
 var file_rpcdb_rpcdb_proto_depIdxs = []int32{
 	4,  // 0: rpcdb.WriteBatchRequest.puts:type_name -> rpcdb.PutRequest
@@ -1380,26 +2134,40 @@ var file_rpcdb_rpcdb_proto_depIdxs = []int32{
 	6,  // 6: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
 	8,  // 7: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
 	10, // 8: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
-	24, // 9: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
+	36, // 9: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
 	12, // 10: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
 	15, // 11: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
-	17, // 12: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
-	19, // 13: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
-	21, // 14: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
-	1,  // 15: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
-	3,  // 16: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
-	5,  // 17: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
-	7,  // 18: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
-	9,  // 19: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
-	11, // 20: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
-	23, // 21: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
-	13, // 22: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
-	16, // 23: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
-	18, // 24: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
-	20, // 25: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
-	22, // 26: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
-	15, // [15:27] is the sub-list for method output_type
-	3,  // [3:15] is the sub-list for method input_type
+	17, // 12: rpcdb.Database.NewIteratorWithRange:input_type -> rpcdb.NewIteratorWithRangeRequest
+	19, // 13: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
+	21, // 14: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
+	23, // 15: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
+	25, // 16: rpcdb.Database.NewSnapshot:input_type -> rpcdb.NewSnapshotRequest
+	27, // 17: rpcdb.Database.SnapshotHas:input_type -> rpcdb.SnapshotHasRequest
+	28, // 18: rpcdb.Database.SnapshotGet:input_type -> rpcdb.SnapshotGetRequest
+	29, // 19: rpcdb.Database.SnapshotNewIteratorWithStartAndPrefix:input_type -> rpcdb.SnapshotNewIteratorWithStartAndPrefixRequest
+	31, // 20: rpcdb.Database.SnapshotNewIteratorWithRange:input_type -> rpcdb.SnapshotNewIteratorWithRangeRequest
+	33, // 21: rpcdb.Database.SnapshotRelease:input_type -> rpcdb.SnapshotReleaseRequest
+	1,  // 22: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
+	3,  // 23: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
+	5,  // 24: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
+	7,  // 25: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
+	9,  // 26: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
+	11, // 27: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
+	35, // 28: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
+	13, // 29: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
+	16, // 30: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
+	18, // 31: rpcdb.Database.NewIteratorWithRange:output_type -> rpcdb.NewIteratorWithRangeResponse
+	20, // 32: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
+	22, // 33: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
+	24, // 34: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
+	26, // 35: rpcdb.Database.NewSnapshot:output_type -> rpcdb.NewSnapshotResponse
+	1,  // 36: rpcdb.Database.SnapshotHas:output_type -> rpcdb.HasResponse
+	3,  // 37: rpcdb.Database.SnapshotGet:output_type -> rpcdb.GetResponse
+	30, // 38: rpcdb.Database.SnapshotNewIteratorWithStartAndPrefix:output_type -> rpcdb.SnapshotNewIteratorWithStartAndPrefixResponse
+	32, // 39: rpcdb.Database.SnapshotNewIteratorWithRange:output_type -> rpcdb.SnapshotNewIteratorWithRangeResponse
+	34, // 40: rpcdb.Database.SnapshotRelease:output_type -> rpcdb.SnapshotReleaseResponse
+	22, // [22:41] is the sub-list for method output_type
+	3,  // [3:22] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -1616,7 +2384,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextRequest); i {
+			switch v := v.(*NewIteratorWithRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1628,7 +2396,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextResponse); i {
+			switch v := v.(*NewIteratorWithRangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1640,7 +2408,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorRequest); i {
+			switch v := v.(*IteratorNextRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1652,7 +2420,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorResponse); i {
+			switch v := v.(*IteratorNextResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1664,7 +2432,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseRequest); i {
+			switch v := v.(*IteratorErrorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1676,7 +2444,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseResponse); i {
+			switch v := v.(*IteratorErrorResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1688,6 +2456,150 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotHasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotNewIteratorWithStartAndPrefixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotNewIteratorWithStartAndPrefixResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotNewIteratorWithRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotNewIteratorWithRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HealthCheckResponse); i {
 			case 0:
 				return &v.state
@@ -1706,7 +2618,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpcdb_rpcdb_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
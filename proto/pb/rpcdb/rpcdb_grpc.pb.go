@@ -32,9 +32,16 @@ type DatabaseClient interface {
 	HealthCheck(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithRangeResponse, error)
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
+	NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error)
+	SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*SnapshotNewIteratorWithStartAndPrefixResponse, error)
+	SnapshotNewIteratorWithRange(ctx context.Context, in *SnapshotNewIteratorWithRangeRequest, opts ...grpc.CallOption) (*SnapshotNewIteratorWithRangeResponse, error)
+	SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error)
 }
 
 type databaseClient struct {
@@ -126,6 +133,15 @@ func (c *databaseClient) NewIteratorWithStartAndPrefix(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *databaseClient) NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithRangeResponse, error) {
+	out := new(NewIteratorWithRangeResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/NewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error) {
 	out := new(IteratorNextResponse)
 	err := c.cc.Invoke(ctx, "/rpcdb.Database/IteratorNext", in, out, opts...)
@@ -153,6 +169,60 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 	return out, nil
 }
 
+func (c *databaseClient) NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error) {
+	out := new(NewSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/NewSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	out := new(HasResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/SnapshotHas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/SnapshotGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*SnapshotNewIteratorWithStartAndPrefixResponse, error) {
+	out := new(SnapshotNewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/SnapshotNewIteratorWithStartAndPrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithRange(ctx context.Context, in *SnapshotNewIteratorWithRangeRequest, opts ...grpc.CallOption) (*SnapshotNewIteratorWithRangeResponse, error) {
+	out := new(SnapshotNewIteratorWithRangeResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/SnapshotNewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error) {
+	out := new(SnapshotReleaseResponse)
+	err := c.cc.Invoke(ctx, "/rpcdb.Database/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 // All implementations must embed UnimplementedDatabaseServer
 // for forward compatibility
@@ -166,9 +236,16 @@ type DatabaseServer interface {
 	HealthCheck(context.Context, *emptypb.Empty) (*HealthCheckResponse, error)
 	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(context.Context, *NewIteratorWithRangeRequest) (*NewIteratorWithRangeResponse, error)
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
+	NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error)
+	SnapshotHas(context.Context, *SnapshotHasRequest) (*HasResponse, error)
+	SnapshotGet(context.Context, *SnapshotGetRequest) (*GetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(context.Context, *SnapshotNewIteratorWithStartAndPrefixRequest) (*SnapshotNewIteratorWithStartAndPrefixResponse, error)
+	SnapshotNewIteratorWithRange(context.Context, *SnapshotNewIteratorWithRangeRequest) (*SnapshotNewIteratorWithRangeResponse, error)
+	SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error)
 	mustEmbedUnimplementedDatabaseServer()
 }
 
@@ -203,6 +280,9 @@ func (UnimplementedDatabaseServer) WriteBatch(context.Context, *WriteBatchReques
 func (UnimplementedDatabaseServer) NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithStartAndPrefix not implemented")
 }
+func (UnimplementedDatabaseServer) NewIteratorWithRange(context.Context, *NewIteratorWithRangeRequest) (*NewIteratorWithRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithRange not implemented")
+}
 func (UnimplementedDatabaseServer) IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorNext not implemented")
 }
@@ -212,6 +292,24 @@ func (UnimplementedDatabaseServer) IteratorError(context.Context, *IteratorError
 func (UnimplementedDatabaseServer) IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorRelease not implemented")
 }
+func (UnimplementedDatabaseServer) NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSnapshot not implemented")
+}
+func (UnimplementedDatabaseServer) SnapshotHas(context.Context, *SnapshotHasRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotHas not implemented")
+}
+func (UnimplementedDatabaseServer) SnapshotGet(context.Context, *SnapshotGetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotGet not implemented")
+}
+func (UnimplementedDatabaseServer) SnapshotNewIteratorWithStartAndPrefix(context.Context, *SnapshotNewIteratorWithStartAndPrefixRequest) (*SnapshotNewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithStartAndPrefix not implemented")
+}
+func (UnimplementedDatabaseServer) SnapshotNewIteratorWithRange(context.Context, *SnapshotNewIteratorWithRangeRequest) (*SnapshotNewIteratorWithRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithRange not implemented")
+}
+func (UnimplementedDatabaseServer) SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
 func (UnimplementedDatabaseServer) mustEmbedUnimplementedDatabaseServer() {}
 
 // UnsafeDatabaseServer may be embedded to opt out of forward compatibility for this service.
@@ -387,6 +485,24 @@ func _Database_NewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/NewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, req.(*NewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorNextRequest)
 	if err := dec(in); err != nil {
@@ -441,6 +557,114 @@ func _Database_IteratorRelease_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/NewSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewSnapshot(ctx, req.(*NewSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotHas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotHasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotHas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/SnapshotHas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotHas(ctx, req.(*SnapshotHasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/SnapshotGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotGet(ctx, req.(*SnapshotGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithStartAndPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/SnapshotNewIteratorWithStartAndPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, req.(*SnapshotNewIteratorWithStartAndPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/SnapshotNewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithRange(ctx, req.(*SnapshotNewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdb.Database/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, req.(*SnapshotReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Database_ServiceDesc is the grpc.ServiceDesc for Database service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -484,6 +708,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NewIteratorWithStartAndPrefix",
 			Handler:    _Database_NewIteratorWithStartAndPrefix_Handler,
 		},
+		{
+			MethodName: "NewIteratorWithRange",
+			Handler:    _Database_NewIteratorWithRange_Handler,
+		},
 		{
 			MethodName: "IteratorNext",
 			Handler:    _Database_IteratorNext_Handler,
@@ -496,6 +724,30 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IteratorRelease",
 			Handler:    _Database_IteratorRelease_Handler,
 		},
+		{
+			MethodName: "NewSnapshot",
+			Handler:    _Database_NewSnapshot_Handler,
+		},
+		{
+			MethodName: "SnapshotHas",
+			Handler:    _Database_SnapshotHas_Handler,
+		},
+		{
+			MethodName: "SnapshotGet",
+			Handler:    _Database_SnapshotGet_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithStartAndPrefix",
+			Handler:    _Database_SnapshotNewIteratorWithStartAndPrefix_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithRange",
+			Handler:    _Database_SnapshotNewIteratorWithRange_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Database_SnapshotRelease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpcdb/rpcdb.proto",
@@ -30,7 +30,7 @@ done
 # Dockerfile
 # README.md
 # go.mod
-go_version_minimum="1.18.1"
+go_version_minimum="1.22.0"
 
 go_version() {
     go version | sed -nE -e 's/[^0-9.]+([0-9.]+).+/\1/p'
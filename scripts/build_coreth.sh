@@ -58,4 +58,4 @@ go build $build_args -ldflags "-X github.com/lasthyphen/coreth/plugin/evm.Versio
 cd "$AVALANCHE_PATH"
 
 # Building coreth + using go get can mess with the go.mod file.
-go mod tidy -compat=1.18
+go mod tidy -compat=1.22
@@ -18,6 +18,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
 )
 
 type SubnetLookup interface {
@@ -59,6 +60,9 @@ type Context struct {
 	StakingCertLeaf   *x509.Certificate // block certificate
 	StakingBLSKey     *bls.SecretKey    // bls signer
 
+	// Warp attributes
+	WarpSigner warp.Signer // signs outbound Warp messages with the bls key
+
 	// Chain-specific directory where arbitrary data can be written
 	ChainDataDir string
 }
@@ -53,7 +53,7 @@ type SerializerConfig struct {
 
 func NewSerializer(config SerializerConfig) vertex.Manager {
 	versionDB := versiondb.New(config.DB)
-	dbCache := &cache.LRU{Size: dbCacheSize}
+	dbCache := cache.NewLRU[ids.ID, interface{}](dbCacheSize)
 	s := Serializer{
 		SerializerConfig: config,
 		versionDB:        versionDB,
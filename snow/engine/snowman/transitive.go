@@ -64,7 +64,7 @@ type Transitive struct {
 	// A block is put into this cache if it was not able to be issued. A block
 	// fails to be issued if verification on the block or one of its ancestors
 	// occurs.
-	nonVerifiedCache cache.Cacher
+	nonVerifiedCache cache.Cacher[ids.ID, snowman.Block]
 
 	// operations that are blocked on a block being issued. This could be
 	// issuing another block, responding to a query, or applying votes to consensus
@@ -84,7 +84,7 @@ func newTransitive(config Config) (*Transitive, error) {
 	nonVerifiedCache, err := metercacher.New(
 		"non_verified_cache",
 		config.Ctx.Registerer,
-		&cache.LRU{Size: nonVerifiedCacheSize},
+		cache.NewLRU[ids.ID, snowman.Block](nonVerifiedCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -457,7 +457,7 @@ func (t *Transitive) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Block,
 		return blk, nil
 	}
 	if blk, ok := t.nonVerifiedCache.Get(blkID); ok {
-		return blk.(snowman.Block), nil
+		return blk, nil
 	}
 
 	return t.VM.GetBlock(ctx, blkID)
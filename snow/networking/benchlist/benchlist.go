@@ -43,6 +43,9 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// SetParameters updates when validators are benched and for how long.
+	// Validators that are already benched aren't affected.
+	SetParameters(threshold int, minimumFailingDuration, duration time.Duration)
 }
 
 // Data about a validator who is benched
@@ -252,6 +255,15 @@ func (b *benchlist) isBenched(nodeID ids.NodeID) bool {
 	return false
 }
 
+func (b *benchlist) SetParameters(threshold int, minimumFailingDuration, duration time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.threshold = threshold
+	b.minimumFailingDuration = minimumFailingDuration
+	b.duration = duration
+}
+
 // RegisterResponse notes that we received a response from validator [validatorID]
 func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 	b.streaklock.Lock()
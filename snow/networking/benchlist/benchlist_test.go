@@ -414,3 +414,55 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(t, 3, count)
 }
+
+// Test that updated parameters are used for subsequent failures
+func TestBenchlistSetParameters(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID, nil, ids.Empty, 50))
+	require.NoError(vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 50))
+
+	benchable := &TestBenchable{T: t}
+	benchable.Default(true)
+
+	benchIntf, err := NewBenchlist(
+		ids.Empty,
+		logging.NoLog{},
+		benchable,
+		vdrs,
+		10,
+		minimumFailingDuration,
+		time.Minute,
+		0.5,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+	now := time.Now()
+	b.clock.Set(now)
+
+	threshold := 2
+	duration := time.Hour
+	b.SetParameters(threshold, 0, duration)
+
+	benched := false
+	benchable.BenchedF = func(ids.ID, ids.NodeID) {
+		benched = true
+	}
+
+	b.RegisterFailure(vdrID)
+	b.clock.Set(now.Add(time.Second))
+	b.RegisterFailure(vdrID)
+
+	// The validator is benched after the new threshold is reached, for the new
+	// duration.
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	require.True(benched)
+	require.True(b.isBenched(vdrID))
+	next := b.benchedQueue[0]
+	require.False(next.benchedUntil.Before(now.Add(duration / 2)))
+}
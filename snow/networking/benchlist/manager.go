@@ -31,6 +31,10 @@ type Manager interface {
 	// RegisterFailure registers that a request to [nodeID] regarding
 	// [chainID] timed out
 	RegisterFailure(chainID ids.ID, nodeID ids.NodeID)
+	// RegisterNodeFailure registers that [nodeID] misbehaved in a way that
+	// isn't specific to a chain, such as sending an oversized message. It is
+	// registered as a failure on every chain.
+	RegisterNodeFailure(nodeID ids.NodeID)
 	// RegisterChain registers a new chain with metrics under [namespace]
 	RegisterChain(ctx *snow.ConsensusContext) error
 	// IsBenched returns true if messages to [nodeID] regarding chain [chainID]
@@ -41,6 +45,9 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// SetParameters updates when validators are benched and for how long on
+	// every chain, including the chains registered afterwards.
+	SetParameters(threshold int, minimumFailingDuration, duration time.Duration)
 }
 
 // Config defines the configuration for a benchlist
@@ -170,6 +177,27 @@ func (m *manager) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
 	benchlist.RegisterFailure(nodeID)
 }
 
+func (m *manager) RegisterNodeFailure(nodeID ids.NodeID) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, benchlist := range m.chainBenchlists {
+		benchlist.RegisterFailure(nodeID)
+	}
+}
+
+func (m *manager) SetParameters(threshold int, minimumFailingDuration, duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.config.Threshold = threshold
+	m.config.MinimumFailingDuration = minimumFailingDuration
+	m.config.Duration = duration
+	for _, benchlist := range m.chainBenchlists {
+		benchlist.SetParameters(threshold, minimumFailingDuration, duration)
+	}
+}
+
 type noBenchlist struct{}
 
 // NewNoBenchlist returns an empty benchlist that will never stop any queries
@@ -185,6 +213,8 @@ func (noBenchlist) RegisterResponse(ids.ID, ids.NodeID) {}
 
 func (noBenchlist) RegisterFailure(ids.ID, ids.NodeID) {}
 
+func (noBenchlist) RegisterNodeFailure(ids.NodeID) {}
+
 func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 	return false
 }
@@ -192,3 +222,5 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) SetParameters(int, time.Duration, time.Duration) {}
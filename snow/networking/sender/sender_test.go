@@ -27,6 +27,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/networking/timeout"
 	"github.com/lasthyphen/dijetsnodego/snow/networking/tracker"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/math/meter"
 	"github.com/lasthyphen/dijetsnodego/utils/resource"
@@ -69,7 +70,7 @@ func TestTimeout(t *testing.T) {
 	mc, err := message.NewCreator(
 		metrics,
 		"dummyNamespace",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -324,7 +325,7 @@ func TestReliableMessages(t *testing.T) {
 	mc, err := message.NewCreator(
 		metrics,
 		"dummyNamespace",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -455,7 +456,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 	mc, err := message.NewCreator(
 		metrics,
 		"dummyNamespace",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -3,10 +3,43 @@
 
 package compression
 
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDecompressedMsgTooLarge is returned when decompressing a message would
+// produce more bytes than allowed.
+var ErrDecompressedMsgTooLarge = errors.New("decompressed msg too large")
+
 // Compressor compresss and decompresses messages.
 // Decompress is the inverse of Compress.
 // Decompress(Compress(msg)) == msg.
 type Compressor interface {
 	Compress([]byte) ([]byte, error)
 	Decompress([]byte) ([]byte, error)
+	// DecompressWithLimit decompresses [msg] without ever holding more than
+	// [limit] decompressed bytes in memory. If [msg] decompresses to more than
+	// [limit] bytes, an error wrapping [ErrDecompressedMsgTooLarge] is
+	// returned. The compressor's own max size still applies if it is lower
+	// than [limit].
+	DecompressWithLimit(msg []byte, limit int64) ([]byte, error)
+}
+
+// readAllWithLimit reads [r] until EOF. If [r] contains more than [limit]
+// bytes, reading stops as soon as the limit is exceeded and an error wrapping
+// [ErrDecompressedMsgTooLarge] is returned.
+func readAllWithLimit(r io.Reader, limit int64) ([]byte, error) {
+	// We allow [io.LimitReader] to read up to [limit + 1] bytes, so that if the
+	// decompressed payload is greater than [limit], this function will return
+	// the appropriate error instead of an incomplete byte slice.
+	decompressed, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > limit {
+		return nil, fmt.Errorf("%w: msg length > maximum msg length (%d)", ErrDecompressedMsgTooLarge, limit)
+	}
+	return decompressed, nil
 }
@@ -8,7 +8,6 @@ import (
 	"compress/gzip"
 	"errors"
 	"fmt"
-	"io"
 	"math"
 	"sync"
 
@@ -58,6 +57,16 @@ func (g *gzipCompressor) Compress(msg []byte) ([]byte, error) {
 
 // Decompress decompresses [msg].
 func (g *gzipCompressor) Decompress(msg []byte) ([]byte, error) {
+	return g.DecompressWithLimit(msg, g.maxSize)
+}
+
+// DecompressWithLimit decompresses [msg], streaming the output so that no more
+// than [limit] bytes are ever held in memory.
+func (g *gzipCompressor) DecompressWithLimit(msg []byte, limit int64) ([]byte, error) {
+	if limit > g.maxSize {
+		limit = g.maxSize
+	}
+
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
@@ -66,18 +75,10 @@ func (g *gzipCompressor) Decompress(msg []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	// We allow [io.LimitReader] to read up to [g.maxSize + 1] bytes, so that if
-	// the decompressed payload is greater than the maximum size, this function
-	// will return the appropriate error instead of an incomplete byte slice.
-	limitedReader := io.LimitReader(g.gzipReader, g.maxSize+1)
-
-	decompressed, err := io.ReadAll(limitedReader)
+	decompressed, err := readAllWithLimit(g.gzipReader, limit)
 	if err != nil {
 		return nil, err
 	}
-	if int64(len(decompressed)) > g.maxSize {
-		return nil, fmt.Errorf("msg length > maximum msg length (%d)", g.maxSize)
-	}
 	return decompressed, g.gzipReader.Close()
 }
 
@@ -97,3 +97,30 @@ func FuzzGzipCompressor(f *testing.F) {
 		require.Equal(data, decompressed)
 	})
 }
+
+func TestGzipDecompressWithLimit(t *testing.T) {
+	require := require.New(t)
+
+	compressor, err := NewGzipCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	// Zeros compress extremely well, so a small payload decompresses into a
+	// large one.
+	data := make([]byte, units.MiB)
+	dataCompressed, err := compressor.Compress(data)
+	require.NoError(err)
+	require.Less(len(dataCompressed), 4*units.KiB)
+
+	_, err = compressor.DecompressWithLimit(dataCompressed, units.MiB-1)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+
+	dataDecompressed, err := compressor.DecompressWithLimit(dataCompressed, units.MiB)
+	require.NoError(err)
+	require.Equal(data, dataDecompressed)
+
+	// The compressor's max size applies if it is lower than the limit.
+	smallCompressor, err := NewGzipCompressor(units.KiB)
+	require.NoError(err)
+	_, err = smallCompressor.DecompressWithLimit(dataCompressed, units.MiB)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+}
@@ -3,6 +3,8 @@
 
 package compression
 
+import "fmt"
+
 var _ Compressor = (*noCompressor)(nil)
 
 type noCompressor struct{}
@@ -17,6 +19,14 @@ func (*noCompressor) Decompress(msg []byte) ([]byte, error) {
 	return msg, nil
 }
 
+// DecompressWithLimit returns [msg] if it is no longer than [limit].
+func (*noCompressor) DecompressWithLimit(msg []byte, limit int64) ([]byte, error) {
+	if int64(len(msg)) > limit {
+		return nil, fmt.Errorf("%w: msg length (%d) > maximum msg length (%d)", ErrDecompressedMsgTooLarge, len(msg), limit)
+	}
+	return msg, nil
+}
+
 // NewNoCompressor returns a Compressor that does nothing
 func NewNoCompressor() Compressor {
 	return &noCompressor{}
@@ -20,3 +20,17 @@ func TestNoCompressor(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, data, decompressedBytes)
 }
+
+func TestNoCompressorDecompressWithLimit(t *testing.T) {
+	require := require.New(t)
+
+	data := []byte{1, 2, 3}
+	compressor := NewNoCompressor()
+
+	decompressedBytes, err := compressor.DecompressWithLimit(data, 3)
+	require.NoError(err)
+	require.Equal(data, decompressedBytes)
+
+	_, err = compressor.DecompressWithLimit(data, 2)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+}
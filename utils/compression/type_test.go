@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeFromString(t *testing.T) {
+	require := require.New(t)
+
+	for _, typ := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		parsed, err := TypeFromString(typ.String())
+		require.NoError(err)
+		require.Equal(typ, parsed)
+	}
+
+	_, err := TypeFromString("snappy")
+	require.ErrorIs(err, errUnknownCompressionType)
+}
+
+func TestTypeMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	b, err := TypeZstd.MarshalJSON()
+	require.NoError(err)
+	require.Equal(`"zstd"`, string(b))
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	_ Compressor = (*zstdCompressor)(nil)
+
+	ErrInvalidMaxSizeZstdCompressor = errors.New("invalid zstd compressor max size")
+)
+
+type zstdCompressor struct {
+	maxSize int64
+
+	// [encoder] is safe for concurrent use when only EncodeAll is called.
+	encoder *zstd.Encoder
+
+	// [decoders] pools *zstdDecoders, so that messages can be decompressed
+	// concurrently without allocating a new decoder for every message.
+	decoders sync.Pool
+}
+
+// zstdDecoder is used as a stream decoder, so that decompression can stop as
+// soon as the size limit is exceeded.
+type zstdDecoder struct {
+	bytesReader *bytes.Reader
+	decoder     *zstd.Decoder
+}
+
+func newZstdDecoder(maxSize int64) (*zstdDecoder, error) {
+	decoder, err := zstd.NewReader(
+		nil,
+		zstd.WithDecoderMaxMemory(uint64(maxSize)),
+		// A concurrency of 1 decodes streams synchronously, so pooled
+		// decoders don't hold any goroutines.
+		zstd.WithDecoderConcurrency(1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &zstdDecoder{
+		bytesReader: &bytes.Reader{},
+		decoder:     decoder,
+	}, nil
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (z *zstdCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("msg length (%d) > maximum msg length (%d)", len(msg), z.maxSize)
+	}
+	return z.encoder.EncodeAll(msg, nil), nil
+}
+
+// Decompress decompresses [msg].
+func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
+	return z.DecompressWithLimit(msg, z.maxSize)
+}
+
+// DecompressWithLimit decompresses [msg], streaming the output so that no more
+// than [limit] bytes are ever held in memory.
+func (z *zstdCompressor) DecompressWithLimit(msg []byte, limit int64) ([]byte, error) {
+	if limit > z.maxSize {
+		limit = z.maxSize
+	}
+
+	d, ok := z.decoders.Get().(*zstdDecoder)
+	if !ok {
+		var err error
+		d, err = newZstdDecoder(z.maxSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		// Release the reference to [msg] before returning [d] to the pool.
+		d.bytesReader.Reset(nil)
+		_ = d.decoder.Reset(nil)
+		z.decoders.Put(d)
+	}()
+
+	d.bytesReader.Reset(msg)
+	if err := d.decoder.Reset(d.bytesReader); err != nil {
+		return nil, err
+	}
+	decompressed, err := readAllWithLimit(d.decoder, limit)
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		// The decoder refused to allocate more than [z.maxSize] bytes.
+		return nil, fmt.Errorf("%w: %s", ErrDecompressedMsgTooLarge, err)
+	}
+	return decompressed, err
+}
+
+// NewZstdCompressor returns a new zstd Compressor that compresses
+func NewZstdCompressor(maxSize int64) (Compressor, error) {
+	if maxSize <= 0 || maxSize == math.MaxInt64 {
+		return nil, ErrInvalidMaxSizeZstdCompressor
+	}
+
+	// The encoder's concurrency defaults to GOMAXPROCS, which bounds the
+	// number of concurrent EncodeAll calls.
+	encoder, err := zstd.NewWriter(
+		nil,
+		zstd.WithEncoderLevel(zstd.SpeedDefault),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Creating the first decoder eagerly reports invalid options.
+	decoder, err := newZstdDecoder(maxSize)
+	if err != nil {
+		return nil, err
+	}
+	z := &zstdCompressor{
+		maxSize: maxSize,
+		encoder: encoder,
+	}
+	z.decoders.Put(decoder)
+	return z, nil
+}
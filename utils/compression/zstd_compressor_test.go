@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+func TestZstdCompressDecompress(t *testing.T) {
+	require := require.New(t)
+
+	data := make([]byte, 4096)
+	for i := 0; i < len(data); i++ {
+		data[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+
+	data2 := make([]byte, 4096)
+	for i := 0; i < len(data); i++ {
+		data2[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	dataCompressed, err := compressor.Compress(data)
+	require.NoError(err)
+
+	data2Compressed, err := compressor.Compress(data2)
+	require.NoError(err)
+
+	dataDecompressed, err := compressor.Decompress(dataCompressed)
+	require.NoError(err)
+	require.Equal(data, dataDecompressed)
+
+	data2Decompressed, err := compressor.Decompress(data2Compressed)
+	require.NoError(err)
+	require.Equal(data2, data2Decompressed)
+
+	nonZstdData := []byte{1, 2, 3}
+	_, err = compressor.Decompress(nonZstdData)
+	require.Error(err)
+}
+
+func TestZstdSizeLimiting(t *testing.T) {
+	require := require.New(t)
+
+	data := make([]byte, 3*units.MiB)
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	_, err = compressor.Compress(data) // should be too large
+	require.Error(err)
+
+	compressor2, err := NewZstdCompressor(4 * units.MiB)
+	require.NoError(err)
+
+	dataCompressed, err := compressor2.Compress(data)
+	require.NoError(err)
+
+	_, err = compressor.Decompress(dataCompressed) // should be too large
+	require.Error(err)
+}
+
+func TestNewZstdCompressorWithInvalidLimit(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewZstdCompressor(math.MaxInt64)
+	require.ErrorIs(err, ErrInvalidMaxSizeZstdCompressor)
+
+	_, err = NewZstdCompressor(0)
+	require.ErrorIs(err, ErrInvalidMaxSizeZstdCompressor)
+}
+
+func FuzzZstdCompressor(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		require := require.New(t)
+
+		if len(data) > 2*units.MiB {
+			t.SkipNow()
+		}
+
+		compressor, err := NewZstdCompressor(2 * units.MiB)
+		require.NoError(err)
+
+		compressed, err := compressor.Compress(data)
+		require.NoError(err)
+
+		decompressed, err := compressor.Decompress(compressed)
+		require.NoError(err)
+
+		require.Equal(data, decompressed)
+	})
+}
+
+func TestZstdDecompressWithLimit(t *testing.T) {
+	require := require.New(t)
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	// Zeros compress extremely well, so a small payload decompresses into a
+	// large one.
+	data := make([]byte, units.MiB)
+	dataCompressed, err := compressor.Compress(data)
+	require.NoError(err)
+	require.Less(len(dataCompressed), 4*units.KiB)
+
+	_, err = compressor.DecompressWithLimit(dataCompressed, units.MiB-1)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+
+	dataDecompressed, err := compressor.DecompressWithLimit(dataCompressed, units.MiB)
+	require.NoError(err)
+	require.Equal(data, dataDecompressed)
+
+	// The compressor's max size applies if it is lower than the limit.
+	smallCompressor, err := NewZstdCompressor(units.KiB)
+	require.NoError(err)
+	_, err = smallCompressor.DecompressWithLimit(dataCompressed, units.MiB)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+}
+
+func TestZstdConcurrentDecompress(t *testing.T) {
+	require := require.New(t)
+
+	compressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(err)
+
+	data := make([]byte, 64*units.KiB)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	dataCompressed, err := compressor.Compress(data)
+	require.NoError(err)
+
+	var (
+		eg    errgroup.Group
+		start = make(chan struct{})
+	)
+	for i := 0; i < 16; i++ {
+		eg.Go(func() error {
+			<-start
+			for j := 0; j < 16; j++ {
+				decompressed, err := compressor.Decompress(dataCompressed)
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(data, decompressed) {
+					return errors.New("unexpected decompressed bytes")
+				}
+			}
+			return nil
+		})
+	}
+	close(start)
+	require.NoError(eg.Wait())
+}
@@ -56,7 +56,15 @@ var (
 	_ PrivateKey         = (*PrivateKeySECP256K1R)(nil)
 )
 
-type FactorySECP256K1R struct{ Cache cache.LRU }
+type FactorySECP256K1R struct {
+	// Caches the public keys recovered from signatures. If nil, [Cache] is
+	// used instead.
+	PublicKeyCache cache.Cacher[ids.ID, *PublicKeySECP256K1R]
+
+	// Deprecated: Cache is only kept so that VMs which still set it, such as
+	// coreth, keep building. Use PublicKeyCache instead.
+	Cache cache.LRU
+}
 
 func (*FactorySECP256K1R) NewPrivateKey() (PrivateKey, error) {
 	k, err := secp256k1.GeneratePrivateKey()
@@ -89,9 +97,9 @@ func (f *FactorySECP256K1R) RecoverHashPublicKey(hash, sig []byte) (PublicKey, e
 	cacheBytes := make([]byte, len(hash)+len(sig))
 	copy(cacheBytes, hash)
 	copy(cacheBytes[len(hash):], sig)
-	id := hashing.ComputeHash256Array(cacheBytes)
-	if cachedPublicKey, ok := f.Cache.Get(id); ok {
-		return cachedPublicKey.(*PublicKeySECP256K1R), nil
+	id := ids.ID(hashing.ComputeHash256Array(cacheBytes))
+	if cachedPublicKey, ok := f.getCachedPublicKey(id); ok {
+		return cachedPublicKey, nil
 	}
 
 	if err := verifySECP256K1RSignatureFormat(sig); err != nil {
@@ -113,10 +121,29 @@ func (f *FactorySECP256K1R) RecoverHashPublicKey(hash, sig []byte) (PublicKey, e
 	}
 
 	pubkey := &PublicKeySECP256K1R{pk: rawPubkey}
-	f.Cache.Put(id, pubkey)
+	f.cachePublicKey(id, pubkey)
 	return pubkey, nil
 }
 
+func (f *FactorySECP256K1R) getCachedPublicKey(id ids.ID) (*PublicKeySECP256K1R, bool) {
+	if f.PublicKeyCache != nil {
+		return f.PublicKeyCache.Get(id)
+	}
+	cachedPublicKey, ok := f.Cache.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return cachedPublicKey.(*PublicKeySECP256K1R), true
+}
+
+func (f *FactorySECP256K1R) cachePublicKey(id ids.ID, pubkey *PublicKeySECP256K1R) {
+	if f.PublicKeyCache != nil {
+		f.PublicKeyCache.Put(id, pubkey)
+		return
+	}
+	f.Cache.Put(id, pubkey)
+}
+
 type PublicKeySECP256K1R struct {
 	pk    *secp256k1.PublicKey
 	addr  ids.ShortID
@@ -4,6 +4,7 @@
 package dynamicip
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -15,11 +16,33 @@ var _ Resolver = (*ifConfigResolver)(nil)
 
 // ifConfigResolver resolves our public IP using ifconfig's format.
 type ifConfigResolver struct {
-	url string
+	url    string
+	client *http.Client
+}
+
+// newIFConfigResolver returns a resolver that queries [url] over [network],
+// which is one of "tcp", "tcp4" or "tcp6". Services that support both IP
+// versions reply with the IP of the version the request was made over.
+func newIFConfigResolver(url string, network string) Resolver {
+	dialer := &net.Dialer{
+		Timeout: ipResolutionTimeout,
+	}
+	return &ifConfigResolver{
+		url: url,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+					return dialer.DialContext(ctx, network, addr)
+				},
+			},
+			Timeout: ipResolutionTimeout,
+		},
+	}
 }
 
 func (r *ifConfigResolver) Resolve() (net.IP, error) {
-	resp, err := http.Get(r.url)
+	resp, err := r.client.Get(r.url)
 	if err != nil {
 		return nil, err
 	}
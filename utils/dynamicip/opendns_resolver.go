@@ -24,24 +24,34 @@ var (
 // IFConfigResolves resolves our public IP using openDNS
 type openDNSResolver struct {
 	resolver *net.Resolver
+	// Either "ip" or "ip6"
+	ipNetwork string
 }
 
-func newOpenDNSResolver() Resolver {
+// newOpenDNSResolver returns a resolver that queries openDNS over [network],
+// which is either "udp" or "udp6". If [network] is "udp6", our public IPv6 IP
+// is resolved.
+func newOpenDNSResolver(network string) Resolver {
+	ipNetwork := "ip"
+	if network == "udp6" {
+		ipNetwork = "ip6"
+	}
 	return &openDNSResolver{
+		ipNetwork: ipNetwork,
 		resolver: &net.Resolver{
 			PreferGo: true,
 			Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
 				d := net.Dialer{
 					Timeout: ipResolutionTimeout,
 				}
-				return d.DialContext(ctx, "udp", openDNSUrl)
+				return d.DialContext(ctx, network, openDNSUrl)
 			},
 		},
 	}
 }
 
 func (r *openDNSResolver) Resolve() (net.IP, error) {
-	ips, err := r.resolver.LookupIP(context.TODO(), "ip", "myip.opendns.com")
+	ips, err := r.resolver.LookupIP(context.TODO(), r.ipNetwork, "myip.opendns.com")
 	if err != nil {
 		return nil, err
 	}
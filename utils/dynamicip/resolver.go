@@ -4,6 +4,7 @@
 package dynamicip
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -22,6 +23,12 @@ const (
 	IFConfigMeName = "ifconfigme"
 )
 
+var (
+	errNotIPv6 = errors.New("resolved IP isn't an IPv6 IP")
+
+	_ Resolver = (*ipv6Resolver)(nil)
+)
+
 // Resolver resolves our public IP
 type Resolver interface {
 	// Resolve and return our public IP.
@@ -34,14 +41,51 @@ type Resolver interface {
 // [OpenDNSName], [IFConfigName], [IFConfigCoName], [IFConfigMeName].
 // If [resolverService] isn't one of the above, returns an error
 func NewResolver(resolverName string) (Resolver, error) {
+	return newResolver(resolverName, "tcp", "udp")
+}
+
+// NewIPv6Resolver returns a new Resolver that uses the given service to
+// resolve our public IPv6 IP. The service is queried over IPv6, so resolution
+// fails if this node can't reach it over IPv6.
+// [resolverName] must be one of the names accepted by NewResolver.
+func NewIPv6Resolver(resolverName string) (Resolver, error) {
+	resolver, err := newResolver(resolverName, "tcp6", "udp6")
+	if err != nil {
+		return nil, err
+	}
+	return &ipv6Resolver{
+		resolver: resolver,
+	}, nil
+}
+
+// newResolver returns the resolver named [resolverName]. HTTP based services
+// are queried over [tcpNetwork] and DNS based services over [udpNetwork].
+func newResolver(resolverName string, tcpNetwork string, udpNetwork string) (Resolver, error) {
 	switch strings.ToLower(resolverName) {
 	case OpenDNSName:
-		return newOpenDNSResolver(), nil
+		return newOpenDNSResolver(udpNetwork), nil
 	case IFConfigName, IFConfigCoName:
-		return &ifConfigResolver{url: ifConfigCoURL}, nil
+		return newIFConfigResolver(ifConfigCoURL, tcpNetwork), nil
 	case IFConfigMeName:
-		return &ifConfigResolver{url: ifConfigMeURL}, nil
+		return newIFConfigResolver(ifConfigMeURL, tcpNetwork), nil
 	default:
 		return nil, fmt.Errorf("got unknown resolver: %s", resolverName)
 	}
 }
+
+// ipv6Resolver only returns the IPs resolved by [resolver] if they are IPv6
+// IPs.
+type ipv6Resolver struct {
+	resolver Resolver
+}
+
+func (r *ipv6Resolver) Resolve() (net.IP, error) {
+	ip, err := r.resolver.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	if ip.To4() != nil {
+		return nil, fmt.Errorf("%w: %s", errNotIPv6, ip)
+	}
+	return ip, nil
+}
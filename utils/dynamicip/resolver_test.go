@@ -4,6 +4,7 @@
 package dynamicip
 
 import (
+	"net"
 	"strings"
 	"testing"
 
@@ -50,6 +51,49 @@ func TestNewResolver(t *testing.T) {
 			} else {
 				require.Error(err)
 			}
+
+			_, err = NewIPv6Resolver(tt.service)
+			if tt.validService {
+				require.NoError(err)
+			} else {
+				require.Error(err)
+			}
+		})
+	}
+}
+
+func TestIPv6Resolver(t *testing.T) {
+	tests := []struct {
+		name        string
+		resolvedIP  net.IP
+		expectedErr error
+	}{
+		{
+			name:       "ipv6",
+			resolvedIP: net.ParseIP("2001:db8::1"),
+		},
+		{
+			name:        "ipv4",
+			resolvedIP:  net.IPv4(1, 2, 3, 4),
+			expectedErr: errNotIPv6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			resolver := &ipv6Resolver{
+				resolver: &mockResolver{
+					onResolve: func() (net.IP, error) {
+						return tt.resolvedIP, nil
+					},
+				},
+			}
+			ip, err := resolver.Resolve()
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Equal(tt.resolvedIP, ip)
+			}
 		})
 	}
 }
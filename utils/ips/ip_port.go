@@ -28,6 +28,12 @@ func (ipPort IPPort) String() string {
 	return net.JoinHostPort(ipPort.IP.String(), fmt.Sprintf("%d", ipPort.Port))
 }
 
+// IsIPv6 returns true if the IP is an IPv6 address. IPv4-mapped IPv6 addresses
+// are considered to be IPv4 addresses.
+func (ipPort IPPort) IsIPv6() bool {
+	return len(ipPort.IP) == net.IPv6len && ipPort.IP.To4() == nil
+}
+
 // IsZero returns if the IP or port is zeroed out
 func (ipPort IPPort) IsZero() bool {
 	ip := ipPort.IP
@@ -62,3 +68,13 @@ func PackIP(p *wrappers.Packer, ip IPPort) {
 	p.PackFixedBytes(ip.IP.To16())
 	p.PackShort(ip.Port)
 }
+
+// UnpackIP unpacks an ip port pair from the byte array
+func UnpackIP(p *wrappers.Packer) IPPort {
+	ip := p.UnpackFixedBytes(net.IPv6len)
+	port := p.UnpackShort()
+	return IPPort{
+		IP:   net.IP(ip),
+		Port: port,
+	}
+}
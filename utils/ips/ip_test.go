@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"net"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 )
 
 func TestIPPortEqual(t *testing.T) {
@@ -134,3 +138,27 @@ func TestToIPPort(t *testing.T) {
 		})
 	}
 }
+
+func TestPackUnpackIP(t *testing.T) {
+	require := require.New(t)
+
+	for _, ip := range []IPPort{
+		{
+			IP:   net.IPv4(127, 0, 0, 1),
+			Port: 9651,
+		},
+		{
+			IP:   net.IPv6loopback,
+			Port: 9651,
+		},
+	} {
+		p := wrappers.Packer{MaxSize: wrappers.IPLen}
+		PackIP(&p, ip)
+		require.NoError(p.Err)
+
+		p = wrappers.Packer{Bytes: p.Bytes}
+		unpackedIP := UnpackIP(&p)
+		require.NoError(p.Err)
+		require.True(ip.Equal(unpackedIP))
+	}
+}
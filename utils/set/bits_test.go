@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBits(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBits(0, 3, 3, 70)
+	require.True(b.Contains(0))
+	require.False(b.Contains(1))
+	require.True(b.Contains(3))
+	require.True(b.Contains(70))
+	require.Equal(3, b.Len())
+	require.Equal(71, b.BitLen())
+
+	b.Remove(70)
+	require.False(b.Contains(70))
+	require.Equal(2, b.Len())
+	require.Equal(4, b.BitLen())
+
+	b.Remove(5)
+	require.Equal(2, b.Len())
+}
+
+func TestBitsBytes(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBits(1, 9, 64)
+	parsed := BitsFromBytes(b.Bytes())
+	require.Equal(b.Bytes(), parsed.Bytes())
+	require.True(parsed.Contains(1))
+	require.True(parsed.Contains(9))
+	require.True(parsed.Contains(64))
+	require.Equal(3, parsed.Len())
+
+	// Leading zero bytes are ignored, so the bitset can only be parsed in
+	// its canonical form by checking the round trip.
+	padded := BitsFromBytes(append([]byte{0}, b.Bytes()...))
+	require.Equal(b.Bytes(), padded.Bytes())
+
+	require.Empty(NewBits().Bytes())
+}
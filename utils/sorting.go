@@ -21,17 +21,24 @@ type Sortable[T any] interface {
 
 // Sorts the elements of [s].
 func Sort[T Sortable[T]](s []T) {
-	slices.SortFunc(s, func(i, j T) bool {
-		return i.Less(j)
+	slices.SortFunc(s, func(i, j T) int {
+		switch {
+		case i.Less(j):
+			return -1
+		case j.Less(i):
+			return 1
+		default:
+			return 0
+		}
 	})
 }
 
 // Sorts the elements of [s] based on their hashes.
 func SortByHash[T ~[]byte](s []T) {
-	slices.SortFunc(s, func(i, j T) bool {
+	slices.SortFunc(s, func(i, j T) int {
 		iHash := hashing.ComputeHash256(i)
 		jHash := hashing.ComputeHash256(j)
-		return bytes.Compare(iHash, jHash) == -1
+		return bytes.Compare(iHash, jHash)
 	})
 }
 
@@ -39,8 +46,8 @@ func SortByHash[T ~[]byte](s []T) {
 // Each byte slice is not sorted internally; the byte slices are sorted relative
 // to one another.
 func SortBytes[T ~[]byte](arr []T) {
-	slices.SortFunc(arr, func(i, j T) bool {
-		return bytes.Compare(i, j) == -1
+	slices.SortFunc(arr, func(i, j T) int {
+		return bytes.Compare(i, j)
 	})
 }
 
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testType struct{}
+
+func TestTypeName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("testType", TypeName(testType{}))
+	require.Equal("testType", TypeName(&testType{}))
+	require.Equal("", TypeName(nil))
+}
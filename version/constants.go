@@ -14,7 +14,7 @@ import (
 
 // RPCChainVMProtocol should be bumped anytime changes are made which require
 // the plugin vm to upgrade to latest avalanchego release to be compatible.
-const RPCChainVMProtocol uint = 20
+const RPCChainVMProtocol uint = 21
 
 // These are globals that describe network upgrades and node versions
 var (
@@ -101,6 +101,14 @@ var (
 		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
 	XChainMigrationDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// Dynamic fees are opt-in, so they aren't activated on any network by
+	// default.
+	DynamicFeesTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.TahoeID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	DynamicFeesDefaultTime = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
 )
 
 func init() {
@@ -173,6 +181,13 @@ func GetXChainMigrationTime(networkID uint32) time.Time {
 	return XChainMigrationDefaultTime
 }
 
+func GetDynamicFeesTime(networkID uint32) time.Time {
+	if upgradeTime, exists := DynamicFeesTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return DynamicFeesDefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
@@ -27,6 +27,9 @@ type Client interface {
 	WalletClient
 	// GetTxStatus returns the status of [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
+	// GetMempool returns up to [pageSize] txs, starting at [cursor], that were
+	// issued to this node but haven't been passed to consensus yet.
+	GetMempool(ctx context.Context, cursor uint64, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error)
 	// ConfirmTx attempts to confirm [txID] by repeatedly checking its status.
 	// Note: ConfirmTx will block until either the context is done or the client
 	//       returns a decided status.
@@ -212,6 +215,15 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res.Status, err
 }
 
+func (c *client) GetMempool(ctx context.Context, cursor uint64, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempool", &GetMempoolArgs{
+		Cursor:   cjson.Uint64(cursor),
+		PageSize: cjson.Uint64(pageSize),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -179,6 +180,75 @@ func (s *Service) GetTxStatus(_ *http.Request, args *api.JSONTxID, reply *GetTxS
 	return nil
 }
 
+// GetMempoolArgs are the arguments for GetMempool
+type GetMempoolArgs struct {
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of txs per page
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// MempoolTx is a tx that was issued to this node
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Name of the type of the tx, such as "BaseTx"
+	Type          string      `json:"type"`
+	Size          json.Uint64 `json:"size"`
+	AddedTime     time.Time   `json:"addedTime"`
+	TimeInMempool string      `json:"timeInMempool"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	Txs []MempoolTx `json:"txs"`
+	// Total number of txs in the mempool
+	NumTxs json.Uint64 `json:"numTxs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetMempool returns a page of the txs that were issued to this node but
+// haven't been passed to consensus yet, in the order they were issued.
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, reply *GetMempoolReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("AVM: GetMempool called",
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	numTxs := uint64(len(s.vm.txs))
+	start := safemath.Min(cursor, numTxs)
+	end := safemath.Min(start+pageSize, numTxs)
+
+	now := s.vm.clock.Time()
+	reply.Txs = make([]MempoolTx, 0, end-start)
+	for _, tx := range s.vm.txs[start:end] {
+		txID := tx.ID()
+		addedTime := s.vm.txIssuedTimes[txID]
+		// Issued txs were parsed, so their Tx is already populated.
+		var txType string
+		if uniqueTx, ok := tx.(*UniqueTx); ok {
+			txType = utils.TypeName(uniqueTx.Unsigned)
+		}
+		reply.Txs = append(reply.Txs, MempoolTx{
+			TxID:          txID,
+			Type:          txType,
+			Size:          json.Uint64(len(tx.Bytes())),
+			AddedTime:     addedTime,
+			TimeInMempool: now.Sub(addedTime).String(),
+		})
+	}
+	reply.NumTxs = json.Uint64(numTxs)
+	reply.Cursor = json.Uint64(end)
+	return nil
+}
+
 // GetTx returns the specified transaction
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, reply *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("AVM: GetTx called",
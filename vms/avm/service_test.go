@@ -218,6 +218,52 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceGetMempool(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, vm, s, _, _ := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	reply := &GetMempoolReply{}
+	require.NoError(s.GetMempool(nil, &GetMempoolArgs{}, reply))
+	require.Empty(reply.Txs)
+
+	issuedTime := time.Unix(1000, 0)
+	vm.clock.Set(issuedTime)
+
+	tx := NewTx(t, genesisBytes, vm)
+	txID, err := vm.IssueTx(tx.Bytes())
+	require.NoError(err)
+
+	vm.clock.Set(issuedTime.Add(time.Second))
+
+	reply = &GetMempoolReply{}
+	require.NoError(s.GetMempool(nil, &GetMempoolArgs{}, reply))
+	require.Equal([]MempoolTx{{
+		TxID:          txID,
+		Type:          "BaseTx",
+		Size:          json.Uint64(len(tx.Bytes())),
+		AddedTime:     issuedTime,
+		TimeInMempool: "1s",
+	}}, reply.Txs)
+	require.Equal(json.Uint64(1), reply.NumTxs)
+	require.Equal(json.Uint64(1), reply.Cursor)
+
+	err = s.GetMempool(nil, &GetMempoolArgs{PageSize: json.Uint64(maxPageSize + 1)}, reply)
+	require.Error(err)
+
+	// Txs are removed from the mempool once they are passed to consensus.
+	require.Len(vm.PendingTxs(context.Background()), 1)
+
+	reply = &GetMempoolReply{}
+	require.NoError(s.GetMempool(nil, &GetMempoolArgs{}, reply))
+	require.Empty(reply.Txs)
+	require.Zero(reply.NumTxs)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _, _ := setup(t, true)
 	defer func() {
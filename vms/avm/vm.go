@@ -93,13 +93,16 @@ type VM struct {
 	feeAssetID ids.ID
 
 	// Asset ID --> Bit set with fx IDs the asset supports
-	assetToFxCache *cache.LRU
+	assetToFxCache cache.Cacher[ids.ID, ids.BitSet64]
 
 	// Transaction issuing
 	timer        *timer.Timer
 	batchTimeout time.Duration
 	txs          []snowstorm.Tx
-	toEngine     chan<- common.Message
+	// Key: Tx ID
+	// Value: Time the tx was issued
+	txIssuedTimes map[ids.ID]time.Time
+	toEngine      chan<- common.Message
 
 	baseDB database.Database
 	db     *versiondb.Database
@@ -111,7 +114,7 @@ type VM struct {
 
 	addressTxsIndexer index.AddressTxsIndexer
 
-	uniqueTxs cache.Deduplicator
+	uniqueTxs cache.Deduplicator[ids.ID, *UniqueTx]
 }
 
 func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
@@ -171,7 +174,7 @@ func (vm *VM) Initialize(
 	vm.toEngine = toEngine
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
-	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
+	vm.assetToFxCache = cache.NewLRU[ids.ID, ids.BitSet64](assetToFxCacheSize)
 
 	vm.pubsub = pubsub.New(ctx.Log)
 
@@ -224,8 +227,9 @@ func (vm *VM) Initialize(
 	})
 	go ctx.Log.RecoverAndPanic(vm.timer.Dispatch)
 	vm.batchTimeout = batchTimeout
+	vm.txIssuedTimes = make(map[ids.ID]time.Time)
 
-	vm.uniqueTxs = &cache.EvictableLRU{
+	vm.uniqueTxs = &cache.EvictableLRU[ids.ID, *UniqueTx]{
 		Size: txDeduplicatorSize,
 	}
 	vm.walletService.vm = vm
@@ -344,6 +348,7 @@ func (vm *VM) PendingTxs(context.Context) []snowstorm.Tx {
 
 	txs := vm.txs
 	vm.txs = nil
+	vm.txIssuedTimes = make(map[ids.ID]time.Time)
 	return txs
 }
 
@@ -524,6 +529,7 @@ func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
 
 func (vm *VM) issueTx(tx snowstorm.Tx) {
 	vm.txs = append(vm.txs, tx)
+	vm.txIssuedTimes[tx.ID()] = vm.clock.Time()
 	switch {
 	case len(vm.txs) == batchSize:
 		vm.FlushTxs()
@@ -569,9 +575,9 @@ func (vm *VM) getFx(val interface{}) (int, error) {
 
 func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	// Check cache to see whether this asset supports this fx
-	fxIDsIntf, assetInCache := vm.assetToFxCache.Get(assetID)
+	fxIDs, assetInCache := vm.assetToFxCache.Get(assetID)
 	if assetInCache {
-		return fxIDsIntf.(ids.BitSet64).Contains(uint(fxID))
+		return fxIDs.Contains(uint(fxID))
 	}
 	// Caches doesn't say whether this asset support this fx.
 	// Get the tx that created the asset and check.
@@ -587,7 +593,7 @@ func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 		// This transaction was not an asset creation tx
 		return false
 	}
-	fxIDs := ids.BitSet64(0)
+	fxIDs = ids.BitSet64(0)
 	for _, state := range createAssetTx.States {
 		if state.FxIndex == uint32(fxID) {
 			// Cache that this asset supports this fx
@@ -1083,5 +1089,5 @@ func (*VM) AppGossip(context.Context, ids.NodeID, []byte) error {
 
 // UniqueTx de-duplicates the transaction.
 func (vm *VM) DeduplicateTx(tx *UniqueTx) *UniqueTx {
-	return vm.uniqueTxs.Deduplicate(tx).(*UniqueTx)
+	return vm.uniqueTxs.Deduplicate(tx)
 }
@@ -16,8 +16,14 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/consensus/snowman"
 	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
 )
 
+// DefaultCacheBytes is the number of bytes that each block cache may hold if
+// its byte bound isn't configured.
+const DefaultCacheBytes = 64 * units.MiB
+
 // State implements an efficient caching layer used to wrap a VM
 // implementation.
 type State struct {
@@ -41,18 +47,17 @@ type State struct {
 	// verifiedBlocks is a map of blocks that have been verified and are
 	// therefore currently in consensus.
 	verifiedBlocks map[ids.ID]*BlockWrapper
-	// decidedBlocks is an LRU cache of decided blocks.
-	// Every value in [decidedBlocks] is a (*BlockWrapper)
-	decidedBlocks cache.Cacher
+	// decidedBlocks is an LRU cache of decided blocks, bounded by both the
+	// number and the size of the blocks.
+	decidedBlocks cache.Cacher[ids.ID, *BlockWrapper]
 	// unverifiedBlocks is an LRU cache of blocks with status processing
-	// that have not yet passed verification.
-	// Every value in [unverifiedBlocks] is a (*BlockWrapper)
-	unverifiedBlocks cache.Cacher
+	// that have not yet passed verification, bounded by both the number and
+	// the size of the blocks.
+	unverifiedBlocks cache.Cacher[ids.ID, *BlockWrapper]
 	// missingBlocks is an LRU cache of missing blocks
-	// Every value in [missingBlocks] is an empty struct.
-	missingBlocks cache.Cacher
+	missingBlocks cache.Cacher[ids.ID, struct{}]
 	// string([byte repr. of block]) --> the block's ID
-	bytesToIDCache    cache.Cacher
+	bytesToIDCache    cache.Cacher[string, ids.ID]
 	lastAcceptedBlock *BlockWrapper
 }
 
@@ -61,6 +66,11 @@ type Config struct {
 	// Cache configuration:
 	DecidedCacheSize, MissingCacheSize, UnverifiedCacheSize, BytesToIDCacheSize int
 
+	// DecidedCacheBytes, UnverifiedCacheBytes, and BytesToIDCacheBytes bound
+	// the number of bytes held by the corresponding caches, in addition to
+	// the number of entries. If 0, DefaultCacheBytes is used.
+	DecidedCacheBytes, UnverifiedCacheBytes, BytesToIDCacheBytes int
+
 	LastAcceptedBlock     snowman.Block
 	GetBlock              func(context.Context, ids.ID) (snowman.Block, error)
 	UnmarshalBlock        func(context.Context, []byte) (snowman.Block, error)
@@ -115,6 +125,26 @@ func produceGetStatus(s *State, getBlockIDAtHeight func(context.Context, uint64)
 	}
 }
 
+// cacheBytes returns the byte bound of a cache configured with [bytes].
+func cacheBytes(bytes int) int {
+	if bytes == 0 {
+		return DefaultCacheBytes
+	}
+	return bytes
+}
+
+// cachedBlockSize returns the number of bytes charged against a block cache
+// for caching [blk].
+func cachedBlockSize(_ ids.ID, blk *BlockWrapper) int {
+	return hashing.HashLen + len(blk.Bytes())
+}
+
+// cachedBlockBytesSize returns the number of bytes charged against the
+// bytesToIDCache for caching the mapping of [blkBytes] to its block ID.
+func cachedBlockBytesSize(blkBytes string, _ ids.ID) int {
+	return len(blkBytes) + hashing.HashLen
+}
+
 func (s *State) initialize(config *Config) {
 	s.verifiedBlocks = make(map[ids.ID]*BlockWrapper)
 	s.getBlock = config.GetBlock
@@ -138,10 +168,10 @@ func (s *State) initialize(config *Config) {
 func NewState(config *Config) *State {
 	c := &State{
 		verifiedBlocks:   make(map[ids.ID]*BlockWrapper),
-		decidedBlocks:    &cache.LRU{Size: config.DecidedCacheSize},
-		missingBlocks:    &cache.LRU{Size: config.MissingCacheSize},
-		unverifiedBlocks: &cache.LRU{Size: config.UnverifiedCacheSize},
-		bytesToIDCache:   &cache.LRU{Size: config.BytesToIDCacheSize},
+		decidedBlocks:    cache.NewBoundedLRU(config.DecidedCacheSize, cacheBytes(config.DecidedCacheBytes), cachedBlockSize),
+		missingBlocks:    cache.NewLRU[ids.ID, struct{}](config.MissingCacheSize),
+		unverifiedBlocks: cache.NewBoundedLRU(config.UnverifiedCacheSize, cacheBytes(config.UnverifiedCacheBytes), cachedBlockSize),
+		bytesToIDCache:   cache.NewBoundedLRU(config.BytesToIDCacheSize, cacheBytes(config.BytesToIDCacheBytes), cachedBlockBytesSize),
 	}
 	c.initialize(config)
 	return c
@@ -154,7 +184,7 @@ func NewMeteredState(
 	decidedCache, err := metercacher.New(
 		"decided_cache",
 		registerer,
-		&cache.LRU{Size: config.DecidedCacheSize},
+		cache.NewBoundedLRU(config.DecidedCacheSize, cacheBytes(config.DecidedCacheBytes), cachedBlockSize),
 	)
 	if err != nil {
 		return nil, err
@@ -162,7 +192,7 @@ func NewMeteredState(
 	missingCache, err := metercacher.New(
 		"missing_cache",
 		registerer,
-		&cache.LRU{Size: config.MissingCacheSize},
+		cache.NewLRU[ids.ID, struct{}](config.MissingCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -170,7 +200,7 @@ func NewMeteredState(
 	unverifiedCache, err := metercacher.New(
 		"unverified_cache",
 		registerer,
-		&cache.LRU{Size: config.UnverifiedCacheSize},
+		cache.NewBoundedLRU(config.UnverifiedCacheSize, cacheBytes(config.UnverifiedCacheBytes), cachedBlockSize),
 	)
 	if err != nil {
 		return nil, err
@@ -178,7 +208,7 @@ func NewMeteredState(
 	bytesToIDCache, err := metercacher.New(
 		"bytes_to_id_cache",
 		registerer,
-		&cache.LRU{Size: config.BytesToIDCacheSize},
+		cache.NewBoundedLRU(config.BytesToIDCacheSize, cacheBytes(config.BytesToIDCacheBytes), cachedBlockBytesSize),
 	)
 	if err != nil {
 		return nil, err
@@ -262,11 +292,11 @@ func (s *State) getCachedBlock(blkID ids.ID) (snowman.Block, bool) {
 	}
 
 	if blk, ok := s.decidedBlocks.Get(blkID); ok {
-		return blk.(snowman.Block), true
+		return blk, true
 	}
 
 	if blk, ok := s.unverifiedBlocks.Get(blkID); ok {
-		return blk.(snowman.Block), true
+		return blk, true
 	}
 
 	return nil, false
@@ -286,9 +316,8 @@ func (s *State) GetBlockInternal(ctx context.Context, blkID ids.ID) (snowman.Blo
 // caching layer if successful.
 func (s *State) ParseBlock(ctx context.Context, b []byte) (snowman.Block, error) {
 	// See if we've cached this block's ID by its byte repr.
-	blkIDIntf, blkIDCached := s.bytesToIDCache.Get(string(b))
+	blkID, blkIDCached := s.bytesToIDCache.Get(string(b))
 	if blkIDCached {
-		blkID := blkIDIntf.(ids.ID)
 		// See if we have this block cached
 		if cachedBlk, ok := s.getCachedBlock(blkID); ok {
 			return cachedBlk, nil
@@ -301,7 +330,7 @@ func (s *State) ParseBlock(ctx context.Context, b []byte) (snowman.Block, error)
 	if err != nil {
 		return nil, err
 	}
-	blkID := blk.ID()
+	blkID = blk.ID()
 	s.bytesToIDCache.Put(string(b), blkID)
 
 	// Only check the caches if we didn't do so above
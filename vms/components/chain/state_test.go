@@ -724,6 +724,43 @@ func TestStateBytesToIDCache(t *testing.T) {
 	require.False(t, ok)
 }
 
+// TestStateBytesToIDCacheBytes ensures the bytesToIDCache is bounded by the
+// number of bytes it holds, even if more entries would be allowed.
+func TestStateBytesToIDCacheBytes(t *testing.T) {
+	testBlks := NewTestBlocks(3)
+	genesisBlock := testBlks[0]
+	genesisBlock.SetStatus(choices.Accepted)
+	blk1 := testBlks[1]
+	blk2 := testBlks[2]
+
+	getBlock, parseBlock, getCanonicalBlockID := createInternalBlockFuncs(t, testBlks)
+	chainState := NewState(&Config{
+		DecidedCacheSize:    2,
+		MissingCacheSize:    2,
+		UnverifiedCacheSize: 2,
+		BytesToIDCacheSize:  2,
+		BytesToIDCacheBytes: len(blk1.Bytes()) + hashing.HashLen,
+		LastAcceptedBlock:   genesisBlock,
+		GetBlock:            getBlock,
+		UnmarshalBlock:      parseBlock,
+		BuildBlock:          cantBuildBlock,
+		GetBlockIDAtHeight:  getCanonicalBlockID,
+	})
+
+	_, err := chainState.ParseBlock(context.Background(), blk1.Bytes())
+	require.NoError(t, err)
+	_, ok := chainState.bytesToIDCache.Get(string(blk1.Bytes()))
+	require.True(t, ok)
+
+	// Only one block fits in the cache
+	_, err = chainState.ParseBlock(context.Background(), blk2.Bytes())
+	require.NoError(t, err)
+	_, ok = chainState.bytesToIDCache.Get(string(blk2.Bytes()))
+	require.True(t, ok)
+	_, ok = chainState.bytesToIDCache.Get(string(blk1.Bytes()))
+	require.False(t, ok)
+}
+
 // TestSetLastAcceptedBlock ensures chainState's last accepted block
 // can be updated by calling [SetLastAcceptedBlock].
 func TestSetLastAcceptedBlock(t *testing.T) {
@@ -180,7 +180,7 @@ func newEnvironment(t *testing.T) *environment {
 		panic(fmt.Errorf("failed to create metrics: %w", err))
 	}
 
-	res.mempool, err = mempool.NewMempool("mempool", registerer, res)
+	res.mempool, err = mempool.NewMempool("mempool", registerer, res, res.ctx.DJTXAssetID, res.clk)
 	if err != nil {
 		panic(fmt.Errorf("failed to create mempool: %w", err))
 	}
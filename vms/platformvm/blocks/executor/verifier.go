@@ -11,6 +11,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -443,6 +444,12 @@ func (v *verifier) standardBlock(
 		return err
 	}
 
+	if v.txExecutorBackend.Config.IsDynamicFeesActivated(blkState.timestamp) {
+		if err := v.updateFeeState(b, onAcceptState); err != nil {
+			return err
+		}
+	}
+
 	if numFuncs := len(funcs); numFuncs == 1 {
 		blkState.onAcceptFunc = funcs[0]
 	} else if numFuncs > 1 {
@@ -460,6 +467,26 @@ func (v *verifier) standardBlock(
 	return nil
 }
 
+// updateFeeState moves the prices of [onAcceptState] based on the number of
+// units consumed by the transactions in [b].
+func (v *verifier) updateFeeState(b *blocks.ApricotStandardBlock, onAcceptState state.Diff) error {
+	consumed := fees.Dimensions{}
+	for _, tx := range b.Transactions {
+		var err error
+		consumed, err = consumed.Add(fees.TxUnits(tx))
+		if err != nil {
+			return err
+		}
+	}
+
+	feeState, err := onAcceptState.GetFeeState()
+	if err != nil {
+		return err
+	}
+	onAcceptState.SetFeeState(feeState.Update(v.txExecutorBackend.Config.DynamicFeeConfig, consumed))
+	return nil
+}
+
 // verifyUniqueInputs verifies that the inputs of the given block are not
 // duplicated in any of the parent blocks pinned in memory.
 func (v *verifier) verifyUniqueInputs(block blocks.Block, inputs set.Set[ids.ID]) error {
@@ -23,6 +23,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -240,6 +241,7 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 			Config: &config.Config{
 				ApricotPhase5Time: time.Now().Add(time.Hour),
 				BanffTime:         mockable.MaxTime, // banff is not activated
+				DynamicFeesTime:   mockable.MaxTime,
 			},
 			Clk: &mockable.Clock{},
 		},
@@ -312,6 +314,103 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestVerifierVisitStandardBlockUpdatesFeeState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Create mocked dependencies.
+	s := state.NewMockState(ctrl)
+	mempool := mempool.NewMockMempool(ctrl)
+	parentID := ids.GenerateTestID()
+	parentStatelessBlk := blocks.NewMockBlock(ctrl)
+	parentState := state.NewMockDiff(ctrl)
+
+	backend := &backend{
+		blkIDToState: map[ids.ID]*blockState{
+			parentID: {
+				statelessBlock: parentStatelessBlk,
+				onAcceptState:  parentState,
+			},
+		},
+		Mempool: mempool,
+		state:   s,
+		ctx: &snow.Context{
+			Log: logging.NoLog{},
+		},
+	}
+	feeConfig := fees.Config{
+		MinPrices:         fees.Dimensions{1, 1, 1, 1},
+		TargetUnits:       fees.Dimensions{1, 1, 1, 1},
+		ChangeDenominator: 1,
+	}
+	verifier := &verifier{
+		txExecutorBackend: &executor.Backend{
+			Config: &config.Config{
+				ApricotPhase5Time: time.Now().Add(time.Hour),
+				BanffTime:         mockable.MaxTime, // banff is not activated
+				DynamicFeesTime:   time.Time{},      // dynamic fees are activated
+				DynamicFeeConfig:  feeConfig,
+			},
+			Clk: &mockable.Clock{},
+		},
+		backend: backend,
+	}
+	manager := &manager{
+		backend:  backend,
+		verifier: verifier,
+	}
+
+	blkTx := txs.NewMockUnsignedTx(ctrl)
+	blkTx.EXPECT().Visit(gomock.AssignableToTypeOf(&executor.StandardTxExecutor{})).DoAndReturn(
+		func(e *executor.StandardTxExecutor) error {
+			e.Inputs = set.Set[ids.ID]{}
+			return nil
+		},
+	).Times(1)
+	blkTx.EXPECT().InputIDs().Return(set.Set[ids.ID]{}).Times(1)
+	blkTx.EXPECT().Outputs().Return(nil).Times(1)
+
+	// We can't serialize [blkTx] because it isn't
+	// registered with the blocks.Codec.
+	// Serialize this block with a dummy tx
+	// and replace it after creation with the mock tx.
+	apricotBlk, err := blocks.NewApricotStandardBlock(
+		parentID,
+		2, /*height*/
+		[]*txs.Tx{
+			{
+				Unsigned: &txs.AdvanceTimeTx{},
+				Creds:    []verify.Verifiable{},
+			},
+		},
+	)
+	require.NoError(err)
+	apricotBlk.Transactions[0].Unsigned = blkTx
+	units := fees.TxUnits(&txs.Tx{
+		Unsigned: &txs.AdvanceTimeTx{},
+		Creds:    apricotBlk.Transactions[0].Creds,
+	})
+	units[fees.Bandwidth] = uint64(len(apricotBlk.Transactions[0].Bytes()))
+
+	// Set expectations for dependencies.
+	parentFeeState := fees.State{Prices: fees.Dimensions{10, 10, 10, 10}}
+	parentState.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
+	parentState.EXPECT().GetFeeState().Return(parentFeeState, nil).Times(1)
+	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
+	mempool.EXPECT().Remove(apricotBlk.Txs()).Times(1)
+
+	blk := manager.NewBlock(apricotBlk)
+	require.NoError(blk.Verify(context.Background()))
+
+	// The prices are updated based on the units consumed by the block.
+	gotBlkState := verifier.backend.blkIDToState[apricotBlk.ID()]
+	feeState, err := gotBlkState.onAcceptState.GetFeeState()
+	require.NoError(err)
+	require.Equal(parentFeeState.Update(feeConfig, units), feeState)
+	require.NotEqual(parentFeeState, feeState)
+}
+
 func TestVerifierVisitCommitBlock(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
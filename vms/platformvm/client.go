@@ -200,6 +200,13 @@ type Client interface {
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetMempool returns up to [pageSize] txs in the mempool, starting at
+	// [cursor], in the order they would be included in a block. The recently
+	// dropped txs and the cursor of the next page are returned as well.
+	GetMempool(ctx context.Context, cursor uint64, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error)
+	// GetFeeState returns the prices that a tx issued now would pay once
+	// dynamic fees are activated
+	GetFeeState(ctx context.Context, options ...rpc.Option) (*GetFeeStateReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error)
@@ -788,6 +795,21 @@ func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.
 	return res.Timestamp, err
 }
 
+func (c *client) GetMempool(ctx context.Context, cursor uint64, pageSize uint64, options ...rpc.Option) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", &GetMempoolArgs{
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (*GetFeeStateReply, error) {
+	res := &GetFeeStateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeState", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error) {
 	res := &GetValidatorsAtReply{}
 	err := c.requester.SendRequest(ctx, "platform.getValidatorsAt", &GetValidatorsAtArgs{
@@ -12,6 +12,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 )
@@ -32,10 +33,15 @@ type Config struct {
 	// Provides access to the uptime manager as a thread safe data structure
 	UptimeLockedCalculator uptime.LockedCalculator
 
+	// Starts tracking subnets while the node is running as a thread safe data
+	// structure
+	SubnetTracker LockedSubnetTracker
+
 	// True if the node is being run with staking enabled
 	StakingEnabled bool
 
-	// Set of subnets that this node is validating
+	// Set of subnets that this node is validating. It's replaced, rather than
+	// modified, when subnets start being tracked.
 	WhitelistedSubnets set.Set[ids.ID]
 
 	// Fee that is burned by every non-state creating transaction
@@ -98,6 +104,13 @@ type Config struct {
 	// Time of the Banff network upgrade
 	BanffTime time.Time
 
+	// Time at which fees switch from the static fees above to dynamic fees.
+	// The static fees remain the minimum fee of each transaction.
+	DynamicFeesTime time.Time
+
+	// Pricing of transactions once dynamic fees are activated
+	DynamicFeeConfig fees.Config
+
 	// Subnet ID --> Minimum portion of the subnet's stake this node must be
 	// connected to in order to report healthy.
 	// [constants.PrimaryNetworkID] is always a key in this map.
@@ -129,6 +142,10 @@ func (c *Config) IsBanffActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.BanffTime)
 }
 
+func (c *Config) IsDynamicFeesActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.DynamicFeesTime)
+}
+
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
 	if c.IsApricotPhase3Activated(timestamp) {
 		return c.CreateBlockchainTxFee
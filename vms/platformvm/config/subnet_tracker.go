@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+var (
+	errSubnetTrackerNotReady = errors.New("subnet tracker isn't ready")
+
+	_ LockedSubnetTracker = (*lockedSubnetTracker)(nil)
+)
+
+// SubnetTracker starts tracking subnets while the node is running
+type SubnetTracker interface {
+	// TrackSubnets starts tracking the subnets of [subnetIDs] that aren't
+	// tracked yet. Subnets can't stop being tracked.
+	TrackSubnets(subnetIDs []ids.ID) error
+}
+
+type LockedSubnetTracker interface {
+	SubnetTracker
+
+	SetTracker(lock sync.Locker, newT SubnetTracker)
+}
+
+type lockedSubnetTracker struct {
+	lock        sync.RWMutex
+	trackerLock sync.Locker
+	t           SubnetTracker
+}
+
+func NewLockedSubnetTracker() LockedSubnetTracker {
+	return &lockedSubnetTracker{}
+}
+
+func (t *lockedSubnetTracker) TrackSubnets(subnetIDs []ids.ID) error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.t == nil {
+		return errSubnetTrackerNotReady
+	}
+
+	t.trackerLock.Lock()
+	defer t.trackerLock.Unlock()
+
+	return t.t.TrackSubnets(subnetIDs)
+}
+
+func (t *lockedSubnetTracker) SetTracker(lock sync.Locker, newT SubnetTracker) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.trackerLock = lock
+	t.t = newT
+}
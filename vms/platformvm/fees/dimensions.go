@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"fmt"
+
+	stdjson "encoding/json"
+
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	safemath "github.com/lasthyphen/dijetsnodego/utils/math"
+)
+
+const (
+	// Bandwidth is the number of bytes of a transaction.
+	Bandwidth Dimension = iota
+	// DBRead is the number of database entries read by a transaction.
+	DBRead
+	// DBWrite is the number of database entries written by a transaction.
+	DBWrite
+	// Compute is the cost of the signatures verified by a transaction, in
+	// secp256k1 signature verifications.
+	Compute
+
+	NumDimensions = iota
+)
+
+// Dimension is a resource that is consumed by transactions and priced
+// independently of the other resources.
+type Dimension int
+
+func (d Dimension) String() string {
+	switch d {
+	case Bandwidth:
+		return "bandwidth"
+	case DBRead:
+		return "dbRead"
+	case DBWrite:
+		return "dbWrite"
+	case Compute:
+		return "compute"
+	default:
+		return "unknown"
+	}
+}
+
+// Dimensions holds one value per [Dimension].
+type Dimensions [NumDimensions]uint64
+
+// Add returns the per-dimension sum of [d] and [other].
+func (d Dimensions) Add(other Dimensions) (Dimensions, error) {
+	var (
+		sum Dimensions
+		err error
+	)
+	for i := range d {
+		sum[i], err = safemath.Add64(d[i], other[i])
+		if err != nil {
+			return Dimensions{}, err
+		}
+	}
+	return sum, nil
+}
+
+// MarshalJSON marshals [d] as an object keyed by the names of the dimensions.
+func (d Dimensions) MarshalJSON() ([]byte, error) {
+	values := make(map[string]json.Uint64, NumDimensions)
+	for i, value := range d {
+		values[Dimension(i).String()] = json.Uint64(value)
+	}
+	return stdjson.Marshal(values)
+}
+
+func (d *Dimensions) UnmarshalJSON(b []byte) error {
+	values := map[string]json.Uint64{}
+	if err := stdjson.Unmarshal(b, &values); err != nil {
+		return err
+	}
+
+	*d = Dimensions{}
+	for name, value := range values {
+		found := false
+		for i := range d {
+			if Dimension(i).String() == name {
+				d[i] = uint64(value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown dimension %q", name)
+		}
+	}
+	return nil
+}
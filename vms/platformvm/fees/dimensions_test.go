@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDimensionsAdd(t *testing.T) {
+	require := require.New(t)
+
+	sum, err := Dimensions{1, 2, 3, 4}.Add(Dimensions{10, 20, 30, 40})
+	require.NoError(err)
+	require.Equal(Dimensions{11, 22, 33, 44}, sum)
+
+	_, err = Dimensions{Compute: math.MaxUint64}.Add(Dimensions{Compute: 1})
+	require.Error(err)
+}
+
+func TestDimensionsJSON(t *testing.T) {
+	require := require.New(t)
+
+	d := Dimensions{1, 2, 3, math.MaxUint64}
+	b, err := json.Marshal(d)
+	require.NoError(err)
+	require.JSONEq(`{"bandwidth":"1","dbRead":"2","dbWrite":"3","compute":"18446744073709551615"}`, string(b))
+
+	var parsed Dimensions
+	require.NoError(json.Unmarshal(b, &parsed))
+	require.Equal(d, parsed)
+
+	require.NoError(json.Unmarshal([]byte(`{"dbWrite":"5"}`), &parsed))
+	require.Equal(Dimensions{DBWrite: 5}, parsed)
+
+	require.Error(json.Unmarshal([]byte(`{"storage":"5"}`), &parsed))
+}
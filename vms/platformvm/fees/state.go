@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"math"
+	"math/bits"
+
+	safemath "github.com/lasthyphen/dijetsnodego/utils/math"
+)
+
+type Config struct {
+	// MinPrices is the price, in nDJTX, of a unit of each dimension when the
+	// chain is not congested. Prices never fall below these values.
+	MinPrices Dimensions `json:"minPrices"`
+
+	// TargetUnits is the number of units of each dimension that a block is
+	// expected to consume. Blocks consuming more than this increase the price
+	// of the dimension and blocks consuming less decrease it. If the target of
+	// a dimension is 0, its price is fixed at its minimum.
+	TargetUnits Dimensions `json:"targetUnits"`
+
+	// ChangeDenominator bounds the change of a price in a single block. A
+	// block consuming twice the target moves the price up by
+	// 1/[ChangeDenominator] and an empty block moves it down by the same
+	// fraction. If 0, all prices are fixed at their minimums.
+	ChangeDenominator uint64 `json:"changeDenominator"`
+}
+
+// State is the pricing of each dimension after a block has been accepted.
+type State struct {
+	Prices Dimensions `serialize:"true" json:"prices"`
+}
+
+// NewState returns the state of a chain that has not consumed any units yet.
+func NewState(cfg Config) State {
+	return State{
+		Prices: cfg.MinPrices,
+	}
+}
+
+// Cost returns the fee, in nDJTX, of consuming [units].
+func (s State) Cost(units Dimensions) (uint64, error) {
+	cost := uint64(0)
+	for i, price := range s.Prices {
+		dimensionCost, err := safemath.Mul64(price, units[i])
+		if err != nil {
+			return 0, err
+		}
+		cost, err = safemath.Add64(cost, dimensionCost)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return cost, nil
+}
+
+// Update returns the state after a block that consumed [consumed] units has
+// been accepted. Each price moves toward the value at which blocks consume
+// the target number of units of its dimension.
+func (s State) Update(cfg Config, consumed Dimensions) State {
+	next := State{}
+	for i, price := range s.Prices {
+		target := cfg.TargetUnits[i]
+		minPrice := cfg.MinPrices[i]
+		if target == 0 || cfg.ChangeDenominator == 0 {
+			next.Prices[i] = minPrice
+			continue
+		}
+
+		var newPrice uint64
+		switch units := consumed[i]; {
+		case units > target:
+			// Cap the excess so that a single block can't move the price by
+			// more than 1/[ChangeDenominator].
+			excess := safemath.Min(units-target, target)
+			delta := safemath.Max(mulDiv(price, excess, target)/cfg.ChangeDenominator, 1)
+			var err error
+			newPrice, err = safemath.Add64(price, delta)
+			if err != nil {
+				newPrice = math.MaxUint64
+			}
+		case units < target:
+			delta := safemath.Max(mulDiv(price, target-units, target)/cfg.ChangeDenominator, 1)
+			newPrice, _ = safemath.Sub(price, delta)
+		default:
+			newPrice = price
+		}
+		next.Prices[i] = safemath.Max(newPrice, minPrice)
+	}
+	return next
+}
+
+// mulDiv returns a*b/c without overflowing the intermediate product. If the
+// result doesn't fit in a uint64, MaxUint64 is returned.
+//
+// Invariant: c > 0
+func mulDiv(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= c {
+		return math.MaxUint64
+	}
+	quo, _ := bits.Div64(hi, lo, c)
+	return quo
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	MinPrices:         Dimensions{1, 10, 100, 1000},
+	TargetUnits:       Dimensions{1000, 100, 0, 100},
+	ChangeDenominator: 8,
+}
+
+func TestStateCost(t *testing.T) {
+	require := require.New(t)
+
+	s := NewState(testConfig)
+	cost, err := s.Cost(Dimensions{500, 2, 3, 1})
+	require.NoError(err)
+	require.Equal(uint64(500+20+300+1000), cost)
+
+	s.Prices[Compute] = math.MaxUint64
+	_, err = s.Cost(Dimensions{0, 0, 0, 2})
+	require.Error(err)
+}
+
+func TestStateUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		prices   Dimensions
+		consumed Dimensions
+		expected Dimensions
+	}{
+		{
+			name:     "at target",
+			prices:   Dimensions{800, 800, 800, 8000},
+			consumed: Dimensions{1000, 100, 0, 100},
+			expected: Dimensions{800, 800, 100, 8000},
+		},
+		{
+			name:     "above target",
+			prices:   Dimensions{800, 800, 800, 8000},
+			consumed: Dimensions{1500, 200, 1, 1000},
+			// The excess is capped at the target.
+			expected: Dimensions{850, 900, 100, 9000},
+		},
+		{
+			name:     "below target",
+			prices:   Dimensions{800, 800, 800, 8000},
+			consumed: Dimensions{500, 0, 0, 50},
+			expected: Dimensions{750, 700, 100, 7500},
+		},
+		{
+			name:     "moves by at least one",
+			prices:   Dimensions{2, 11, 100, 1001},
+			consumed: Dimensions{1001, 99, 0, 0},
+			expected: Dimensions{3, 10, 100, 1000},
+		},
+		{
+			name:     "clamped to min prices",
+			prices:   Dimensions{1, 10, 100, 1000},
+			consumed: Dimensions{0, 0, 0, 0},
+			expected: Dimensions{1, 10, 100, 1000},
+		},
+		{
+			name:     "saturates",
+			prices:   Dimensions{math.MaxUint64, math.MaxUint64, 100, math.MaxUint64},
+			consumed: Dimensions{2000, 200, 0, 200},
+			expected: Dimensions{math.MaxUint64, math.MaxUint64, 100, math.MaxUint64},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := State{Prices: test.prices}
+			require.Equal(t, test.expected, s.Update(testConfig, test.consumed).Prices)
+		})
+	}
+}
+
+func TestStateUpdateFixedPrices(t *testing.T) {
+	cfg := testConfig
+	cfg.ChangeDenominator = 0
+
+	s := State{Prices: Dimensions{800, 800, 800, 800}}
+	require.Equal(t, cfg.MinPrices, s.Update(cfg, Dimensions{5000, 500, 500, 500}).Prices)
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+// ProofOfPossessionCompute is the Compute cost of verifying a BLS proof of
+// possession. The pairing check takes about as long as verifying 20 secp256k1
+// signatures.
+const ProofOfPossessionCompute = 20
+
+// TxUnits returns the number of units of each dimension that are consumed by
+// [tx]:
+//   - Bandwidth is the size of [tx].
+//   - DBRead is the number of UTXOs consumed by [tx].
+//   - DBWrite is the number of UTXOs consumed and produced by [tx], plus one
+//     for [tx] itself.
+//   - Compute is the number of signatures in the credentials of [tx], plus
+//     [ProofOfPossessionCompute] if [tx] registers a BLS key.
+//
+// Precondition: [tx] has been initialized.
+func TxUnits(tx *txs.Tx) Dimensions {
+	var (
+		units      Dimensions
+		numInputs  = uint64(tx.Unsigned.InputIDs().Len())
+		numOutputs = uint64(len(tx.Unsigned.Outputs()))
+	)
+	if exportTx, ok := tx.Unsigned.(*txs.ExportTx); ok {
+		numOutputs += uint64(len(exportTx.ExportedOutputs))
+	}
+
+	units[Bandwidth] = uint64(len(tx.Bytes()))
+	units[DBRead] = numInputs
+	units[DBWrite] = numInputs + numOutputs + 1
+	for _, cred := range tx.Creds {
+		if cred, ok := cred.(*secp256k1fx.Credential); ok {
+			units[Compute] += uint64(len(cred.Sigs))
+		}
+	}
+	if validatorTx, ok := tx.Unsigned.(*txs.AddPermissionlessValidatorTx); ok {
+		if _, ok := validatorTx.Signer.(*signer.ProofOfPossession); ok {
+			units[Compute] += ProofOfPossessionCompute
+		}
+	}
+	return units
+}
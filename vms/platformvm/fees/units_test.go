@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestTxUnits(t *testing.T) {
+	require := require.New(t)
+
+	keys := crypto.BuildTestKeys()
+	assetID := ids.GenerateTestID()
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+	}
+	utx := &txs.ExportTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    10,
+			BlockchainID: ids.GenerateTestID(),
+			Ins: []*djtx.TransferableInput{
+				{
+					UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  djtx.Asset{ID: assetID},
+					In: &secp256k1fx.TransferInput{
+						Amt:   1000,
+						Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+					},
+				},
+				{
+					UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+					Asset:  djtx.Asset{ID: assetID},
+					In: &secp256k1fx.TransferInput{
+						Amt:   1000,
+						Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+					},
+				},
+			},
+			Outs: []*djtx.TransferableOutput{{
+				Asset: djtx.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          500,
+					OutputOwners: owners,
+				},
+			}},
+		}},
+		DestinationChain: ids.GenerateTestID(),
+		ExportedOutputs: []*djtx.TransferableOutput{{
+			Asset: djtx.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          500,
+				OutputOwners: owners,
+			},
+		}},
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{
+		{keys[0]},
+		{keys[0], keys[1]},
+	})
+	require.NoError(err)
+
+	units := TxUnits(tx)
+	require.Equal(uint64(len(tx.Bytes())), units[Bandwidth])
+	require.Equal(uint64(2), units[DBRead])
+	require.Equal(uint64(2+2+1), units[DBWrite])
+	require.Equal(uint64(3), units[Compute])
+}
+
+func TestTxUnitsProofOfPossession(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	tests := []struct {
+		signer          signer.Signer
+		expectedCompute uint64
+	}{
+		{
+			signer:          &signer.Empty{},
+			expectedCompute: 0,
+		},
+		{
+			signer:          signer.NewProofOfPossession(sk),
+			expectedCompute: ProofOfPossessionCompute,
+		},
+	}
+	for _, test := range tests {
+		tx := &txs.Tx{
+			Unsigned: &txs.AddPermissionlessValidatorTx{
+				Signer: test.signer,
+			},
+		}
+		require.Equal(test.expectedCompute, TxUnits(tx)[Compute])
+	}
+}
@@ -35,6 +35,11 @@ type Metrics interface {
 	// Mark that we computed a validator diff at a height with the given
 	// difference from the top.
 	AddValidatorSetsHeightDiff(uint64)
+	// Mark that a validator set was created from a checkpoint.
+	IncValidatorSetsCheckpointed()
+	// Mark that we applied the given number of diffs to compute a validator
+	// set.
+	AddValidatorSetsLookupDepth(uint64)
 	// Mark that this much stake is staked on the node.
 	SetLocalStake(uint64)
 	// Mark that this much stake is staked in the network.
@@ -120,6 +125,16 @@ func New(
 		validatorSetsHeightDiff: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "validator_sets_height_diff_sum",
+			Help:      "Total difference between the last accepted height and the heights of generated validator sets",
+		}),
+		validatorSetsCheckpointed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validator_sets_checkpointed",
+			Help:      "Total number of validator sets created from a checkpoint",
+		}),
+		validatorSetsLookupDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "validator_sets_lookup_depth_sum",
 			Help:      "Total number of validator sets diffs applied for generating validator sets",
 		}),
 		validatorSetsDuration: prometheus.NewGauge(prometheus.GaugeOpts{
@@ -148,6 +163,8 @@ func New(
 		registerer.Register(m.validatorSetsCreated),
 		registerer.Register(m.validatorSetsCached),
 		registerer.Register(m.validatorSetsHeightDiff),
+		registerer.Register(m.validatorSetsCheckpointed),
+		registerer.Register(m.validatorSetsLookupDepth),
 		registerer.Register(m.validatorSetsDuration),
 	)
 
@@ -173,10 +190,12 @@ type metrics struct {
 
 	numVotesWon, numVotesLost prometheus.Counter
 
-	validatorSetsCached     prometheus.Counter
-	validatorSetsCreated    prometheus.Counter
-	validatorSetsHeightDiff prometheus.Gauge
-	validatorSetsDuration   prometheus.Gauge
+	validatorSetsCached       prometheus.Counter
+	validatorSetsCreated      prometheus.Counter
+	validatorSetsHeightDiff   prometheus.Gauge
+	validatorSetsCheckpointed prometheus.Counter
+	validatorSetsLookupDepth  prometheus.Gauge
+	validatorSetsDuration     prometheus.Gauge
 }
 
 func (m *metrics) MarkOptionVoteWon() {
@@ -207,6 +226,14 @@ func (m *metrics) AddValidatorSetsHeightDiff(d uint64) {
 	m.validatorSetsHeightDiff.Add(float64(d))
 }
 
+func (m *metrics) IncValidatorSetsCheckpointed() {
+	m.validatorSetsCheckpointed.Inc()
+}
+
+func (m *metrics) AddValidatorSetsLookupDepth(d uint64) {
+	m.validatorSetsLookupDepth.Add(float64(d))
+}
+
 func (m *metrics) SetLocalStake(s uint64) {
 	m.localStake.Set(float64(s))
 }
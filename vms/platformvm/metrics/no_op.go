@@ -39,6 +39,10 @@ func (noopMetrics) AddValidatorSetsDuration(time.Duration) {}
 
 func (noopMetrics) AddValidatorSetsHeightDiff(uint64) {}
 
+func (noopMetrics) IncValidatorSetsCheckpointed() {}
+
+func (noopMetrics) AddValidatorSetsLookupDepth(uint64) {}
+
 func (noopMetrics) SetLocalStake(uint64) {}
 
 func (noopMetrics) SetTotalStake(uint64) {}
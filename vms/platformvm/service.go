@@ -28,6 +28,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/components/keystore"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/signer"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/stakeable"
@@ -36,6 +37,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/builder"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/executor"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/mempool"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 
 	platformapi "github.com/lasthyphen/dijetsnodego/vms/platformvm/api"
@@ -48,6 +50,9 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of txs that can be returned by GetMempool
+	maxGetMempoolPageSize = 1024
+
 	// Minimum amount of delay to allow a transaction to be issued through the
 	// API
 	minAddStakerDelay = 2 * executor.SyncBound
@@ -2294,6 +2299,145 @@ func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestamp
 	return nil
 }
 
+// GetMempoolArgs are the arguments for GetMempool
+type GetMempoolArgs struct {
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of txs per page
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// MempoolTx is a tx in the mempool
+type MempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Name of the type of the tx, such as "AddValidatorTx"
+	Type string      `json:"type"`
+	Size json.Uint64 `json:"size"`
+	// Amount of nDJTX that the tx burns per byte
+	FeeRate       json.Uint64 `json:"feeRate"`
+	AddedTime     time.Time   `json:"addedTime"`
+	TimeInMempool string      `json:"timeInMempool"`
+}
+
+// DroppedTx is a tx that was recently dropped from the mempool
+type DroppedTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+}
+
+// GetMempoolReply is the response from GetMempool
+type GetMempoolReply struct {
+	Txs []MempoolTx `json:"txs"`
+	// Total number of txs in the mempool
+	NumTxs json.Uint64 `json:"numTxs"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// The most recently dropped txs, starting with the most recently dropped
+	// tx
+	DroppedTxs []DroppedTx `json:"droppedTxs"`
+}
+
+// GetMempool returns a page of the txs in the mempool in the order they would
+// be included in a block. Decision txs are ordered by the fee rate they pay.
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, reply *GetMempoolReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("Platform: GetMempool called",
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+	if pageSize > maxGetMempoolPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxGetMempoolPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxGetMempoolPageSize
+	}
+
+	mempoolTxs := s.vm.Builder.PeekTxs(stdmath.MaxInt)
+	numTxs := uint64(len(mempoolTxs))
+	start := math.Min(cursor, numTxs)
+	end := math.Min(start+pageSize, numTxs)
+
+	now := s.vm.clock.Time()
+	reply.Txs = make([]MempoolTx, 0, end-start)
+	for _, tx := range mempoolTxs[start:end] {
+		txID := tx.ID()
+		feeRate, err := mempool.FeeRate(tx, s.vm.ctx.DJTXAssetID)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate the fee rate of tx %s: %w", txID, err)
+		}
+		addedTime, _ := s.vm.Builder.GetAddedTime(txID)
+		reply.Txs = append(reply.Txs, MempoolTx{
+			TxID:          txID,
+			Type:          utils.TypeName(tx.Unsigned),
+			Size:          json.Uint64(len(tx.Bytes())),
+			FeeRate:       json.Uint64(feeRate),
+			AddedTime:     addedTime,
+			TimeInMempool: now.Sub(addedTime).String(),
+		})
+	}
+	reply.NumTxs = json.Uint64(numTxs)
+	reply.Cursor = json.Uint64(end)
+
+	droppedTxIDs := s.vm.Builder.GetDroppedTxIDs()
+	reply.DroppedTxs = make([]DroppedTx, 0, len(droppedTxIDs))
+	for _, txID := range droppedTxIDs {
+		reason, ok := s.vm.Builder.GetDropReason(txID)
+		if !ok {
+			continue
+		}
+		reply.DroppedTxs = append(reply.DroppedTxs, DroppedTx{
+			TxID:   txID,
+			Reason: reason,
+		})
+	}
+	return nil
+}
+
+// GetFeeStateReply is the response from GetFeeState
+type GetFeeStateReply struct {
+	// True if the fees of transactions issued now are dynamic
+	Active bool `json:"active"`
+	// Time at which dynamic fees are activated
+	ActivationTime time.Time `json:"activationTime"`
+	// Price, in nDJTX, of a unit of each dimension
+	Prices fees.Dimensions `json:"prices"`
+	// Lowest price, in nDJTX, of a unit of each dimension
+	MinPrices fees.Dimensions `json:"minPrices"`
+	// Number of units of each dimension that blocks are expected to consume
+	TargetUnits fees.Dimensions `json:"targetUnits"`
+}
+
+// GetFeeState returns the prices that a transaction issued now would pay once
+// dynamic fees are activated. The fee of a transaction is the sum, over each
+// dimension, of the units it consumes times the price of the dimension, or its
+// static fee if that is higher.
+func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetFeeState called")
+
+	// The next block is built on top of the preferred block, so transactions
+	// issued now pay the prices of the preferred block.
+	preferredBlk, err := s.vm.Preferred()
+	if err != nil {
+		return err
+	}
+	preferredID := preferredBlk.ID()
+	preferredState, ok := s.vm.manager.GetState(preferredID)
+	if !ok {
+		return fmt.Errorf("could not retrieve state for block %s", preferredID)
+	}
+	feeState, err := preferredState.GetFeeState()
+	if err != nil {
+		return err
+	}
+
+	reply.Active = s.vm.Config.IsDynamicFeesActivated(preferredState.GetTimestamp())
+	reply.ActivationTime = s.vm.Config.DynamicFeesTime
+	reply.Prices = feeState.Prices
+	reply.MinPrices = s.vm.Config.DynamicFeeConfig.MinPrices
+	reply.TargetUnits = s.vm.Config.DynamicFeeConfig.TargetUnits
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   json.Uint64 `json:"height"`
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -31,9 +32,11 @@ import (
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/mempool"
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 
 	vmkeystore "github.com/lasthyphen/dijetsnodego/vms/components/keystore"
@@ -774,6 +777,136 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{}, &reply))
+	require.Empty(reply.Txs)
+	require.Empty(reply.DroppedTxs)
+
+	addedTime := service.vm.clock.Time()
+	mempoolTxs := make([]*txs.Tx, 2)
+	for i := range mempoolTxs {
+		tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+			1,
+			[]ids.ShortID{keys[i].PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{keys[i]},
+			keys[i].PublicKey().Address(),
+		)
+		require.NoError(err)
+		require.NoError(service.vm.Builder.Add(tx))
+		mempoolTxs[i] = tx
+	}
+	mempoolTxs = service.vm.Builder.PeekTxs(math.MaxInt)
+
+	droppedTxID := ids.GenerateTestID()
+	service.vm.Builder.MarkDropped(droppedTxID, "dropped for testing")
+
+	service.vm.clock.Set(addedTime.Add(time.Minute))
+
+	expectedTxs := make([]MempoolTx, len(mempoolTxs))
+	for i, tx := range mempoolTxs {
+		feeRate, err := mempool.FeeRate(tx, service.vm.ctx.DJTXAssetID)
+		require.NoError(err)
+		expectedTxs[i] = MempoolTx{
+			TxID:          tx.ID(),
+			Type:          "CreateSubnetTx",
+			Size:          json.Uint64(len(tx.Bytes())),
+			FeeRate:       json.Uint64(feeRate),
+			AddedTime:     addedTime,
+			TimeInMempool: "1m0s",
+		}
+	}
+	expectedDroppedTxs := []DroppedTx{{
+		TxID:   droppedTxID,
+		Reason: "dropped for testing",
+	}}
+
+	// The txs are paginated.
+	reply = GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{PageSize: 1}, &reply))
+	require.Equal(expectedTxs[:1], reply.Txs)
+	require.Equal(json.Uint64(2), reply.NumTxs)
+	require.Equal(json.Uint64(1), reply.Cursor)
+	require.Equal(expectedDroppedTxs, reply.DroppedTxs)
+
+	reply = GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{Cursor: 1, PageSize: 2}, &reply))
+	require.Equal(expectedTxs[1:], reply.Txs)
+	require.Equal(json.Uint64(2), reply.Cursor)
+
+	reply = GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{Cursor: 5}, &reply))
+	require.Empty(reply.Txs)
+	require.Equal(json.Uint64(2), reply.Cursor)
+
+	err := service.GetMempool(nil, &GetMempoolArgs{PageSize: maxGetMempoolPageSize + 1}, &reply)
+	require.Error(err)
+}
+
+func TestGetFeeState(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	reply := GetFeeStateReply{}
+	require.NoError(service.GetFeeState(nil, nil, &reply))
+	require.True(reply.Active)
+	require.Equal(fees.Dimensions{}, reply.Prices)
+
+	service.vm.Config.DynamicFeeConfig = fees.Config{
+		MinPrices:         fees.Dimensions{1, 2, 3, 4},
+		TargetUnits:       fees.Dimensions{1000, 10, 10, 10},
+		ChangeDenominator: 8,
+	}
+
+	// Accepting a block moves the prices up to their minimums.
+	tx, err := service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+	blk, err := service.vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(service.vm.SetPreference(context.Background(), blk.ID()))
+
+	reply = GetFeeStateReply{}
+	require.NoError(service.GetFeeState(nil, nil, &reply))
+	require.True(reply.Active)
+	require.Equal(service.vm.Config.DynamicFeesTime, reply.ActivationTime)
+	require.Equal(fees.Dimensions{1, 2, 3, 4}, reply.Prices)
+	require.Equal(fees.Dimensions{1, 2, 3, 4}, reply.MinPrices)
+	require.Equal(fees.Dimensions{1000, 10, 10, 10}, reply.TargetUnits)
+
+	// The static fee of this tx is 0, so it is only rejected because it
+	// doesn't pay the dynamic fee.
+	tx, err = service.vm.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{keys[1].PublicKey().Address()},
+		[]*crypto.PrivateKeySECP256K1R{keys[1]},
+		keys[1].PublicKey().Address(),
+	)
+	require.NoError(err)
+	require.Zero(service.vm.Config.GetCreateSubnetTxFee(service.vm.state.GetTimestamp()))
+	require.Error(service.vm.Builder.AddUnverifiedTx(tx))
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -11,6 +11,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 )
@@ -36,6 +37,9 @@ type diff struct {
 	// Subnet ID --> supply of native asset of the subnet
 	currentSupply map[ids.ID]uint64
 
+	// nil if the fee state wasn't modified in this diff
+	feeState *fees.State
+
 	currentStakerDiffs diffStakers
 	pendingStakerDiffs diffStakers
 
@@ -109,6 +113,23 @@ func (d *diff) SetCurrentSupply(subnetID ids.ID, currentSupply uint64) {
 	}
 }
 
+func (d *diff) GetFeeState() (fees.State, error) {
+	if d.feeState != nil {
+		return *d.feeState, nil
+	}
+
+	// If the fee state wasn't modified in this diff, ask the parent state.
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return fees.State{}, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetFeeState()
+}
+
+func (d *diff) SetFeeState(feeState fees.State) {
+	d.feeState = &feeState
+}
+
 func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	// If the validator was modified in this diff, return the modified
 	// validator.
@@ -449,6 +470,9 @@ func (d *diff) Apply(baseState State) {
 	for subnetID, supply := range d.currentSupply {
 		baseState.SetCurrentSupply(subnetID, supply)
 	}
+	if d.feeState != nil {
+		baseState.SetFeeState(*d.feeState)
+	}
 	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			if validatorDiff.validatorModified {
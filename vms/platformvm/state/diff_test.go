@@ -16,6 +16,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 )
@@ -77,6 +78,39 @@ func TestDiffCurrentSupply(t *testing.T) {
 	require.Equal(initialCurrentSupply, returnedBaseCurrentSupply)
 }
 
+func TestDiffFeeState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lastAcceptedID := ids.GenerateTestID()
+	state, _ := newInitializedState(require)
+	versions := NewMockVersions(ctrl)
+	versions.EXPECT().GetState(lastAcceptedID).AnyTimes().Return(state, true)
+
+	d, err := NewDiff(lastAcceptedID, versions)
+	require.NoError(err)
+
+	initialFeeState, err := d.GetFeeState()
+	require.NoError(err)
+
+	newFeeState := fees.State{Prices: fees.Dimensions{1, 2, 3, 4}}
+	d.SetFeeState(newFeeState)
+
+	returnedNewFeeState, err := d.GetFeeState()
+	require.NoError(err)
+	require.Equal(newFeeState, returnedNewFeeState)
+
+	returnedBaseFeeState, err := state.GetFeeState()
+	require.NoError(err)
+	require.Equal(initialFeeState, returnedBaseFeeState)
+
+	d.Apply(state)
+	returnedBaseFeeState, err = state.GetFeeState()
+	require.NoError(err)
+	require.Equal(newFeeState, returnedBaseFeeState)
+}
+
 func TestDiffCurrentValidator(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -13,6 +13,7 @@ import (
 
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	djtx "github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	fees "github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	status "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -248,6 +249,21 @@ func (mr *MockChainMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockChain)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetFeeState mocks base method.
+func (m *MockChain) GetFeeState() (fees.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(fees.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockChainMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockChain)(nil).GetFeeState))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +459,18 @@ func (mr *MockChainMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockChain)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetFeeState mocks base method.
+func (m *MockChain) SetFeeState(arg0 fees.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", arg0)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockChainMockRecorder) SetFeeState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockChain)(nil).SetFeeState), arg0)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	djtx "github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	fees "github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	status "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -260,6 +261,21 @@ func (mr *MockDiffMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockDiff)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetFeeState mocks base method.
+func (m *MockDiff) GetFeeState() (fees.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(fees.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockDiffMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockDiff)(nil).GetFeeState))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -455,6 +471,18 @@ func (mr *MockDiffMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockDiff)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetFeeState mocks base method.
+func (m *MockDiff) SetFeeState(arg0 fees.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", arg0)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockDiffMockRecorder) SetFeeState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockDiff)(nil).SetFeeState), arg0)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
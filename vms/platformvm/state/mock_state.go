@@ -14,8 +14,10 @@ import (
 	database "github.com/lasthyphen/dijetsnodego/database"
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	choices "github.com/lasthyphen/dijetsnodego/snow/choices"
+	validators "github.com/lasthyphen/dijetsnodego/snow/validators"
 	bls "github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
 	djtx "github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	fees "github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	blocks "github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
 	status "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -319,6 +321,21 @@ func (mr *MockStateMockRecorder) GetCurrentValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockState)(nil).GetCurrentValidator), arg0, arg1)
 }
 
+// GetFeeState mocks base method.
+func (m *MockState) GetFeeState() (fees.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeState")
+	ret0, _ := ret[0].(fees.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeState indicates an expected call of GetFeeState.
+func (mr *MockStateMockRecorder) GetFeeState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeState", reflect.TypeOf((*MockState)(nil).GetFeeState))
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -530,6 +547,21 @@ func (mr *MockStateMockRecorder) GetValidatorPublicKeyDiffs(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorPublicKeyDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorPublicKeyDiffs), arg0)
 }
 
+// GetValidatorSetCheckpoint mocks base method.
+func (m *MockState) GetValidatorSetCheckpoint(arg0 uint64, arg1 ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorSetCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(map[ids.NodeID]*validators.GetValidatorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorSetCheckpoint indicates an expected call of GetValidatorSetCheckpoint.
+func (mr *MockStateMockRecorder) GetValidatorSetCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorSetCheckpoint", reflect.TypeOf((*MockState)(nil).GetValidatorSetCheckpoint), arg0, arg1)
+}
+
 // GetValidatorWeightDiffs mocks base method.
 func (m *MockState) GetValidatorWeightDiffs(arg0 uint64, arg1 ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error) {
 	m.ctrl.T.Helper()
@@ -605,6 +637,18 @@ func (mr *MockStateMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockState)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetFeeState mocks base method.
+func (m *MockState) SetFeeState(arg0 fees.State) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeState", arg0)
+}
+
+// SetFeeState indicates an expected call of SetFeeState.
+func (mr *MockStateMockRecorder) SetFeeState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeState", reflect.TypeOf((*MockState)(nil).SetFeeState), arg0)
+}
+
 // SetHeight mocks base method.
 func (m *MockState) SetHeight(arg0 uint64) {
 	m.ctrl.T.Helper()
@@ -669,3 +713,18 @@ func (mr *MockStateMockRecorder) UTXOIDs(arg0, arg1, arg2 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockState)(nil).UTXOIDs), arg0, arg1, arg2)
 }
+
+// ValidatorSet mocks base method.
+func (m *MockState) ValidatorSet(arg0 ids.ID) (validators.Set, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorSet", arg0)
+	ret0, _ := ret[0].(validators.Set)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidatorSet indicates an expected call of ValidatorSet.
+func (mr *MockStateMockRecorder) ValidatorSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorSet", reflect.TypeOf((*MockState)(nil).ValidatorSet), arg0)
+}
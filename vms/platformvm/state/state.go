@@ -27,10 +27,12 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
 	"github.com/lasthyphen/dijetsnodego/utils/hashing"
 	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/genesis"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
@@ -39,12 +41,18 @@ import (
 )
 
 const (
-	validatorDiffsCacheSize = 2048
-	blockCacheSize          = 2048
-	txCacheSize             = 2048
-	rewardUTXOsCacheSize    = 2048
-	chainCacheSize          = 2048
-	chainDBCacheSize        = 2048
+	validatorDiffsCacheSize          = 2048
+	validatorSetCheckpointsCacheSize = 64
+	blockCacheSize                   = 64 * units.MiB
+	txCacheSize                      = 2048
+	rewardUTXOsCacheSize             = 2048
+	chainCacheSize                   = 2048
+	chainDBCacheSize                 = 2048
+
+	// ValidatorSetCheckpointInterval is the number of blocks between two
+	// checkpoints of the validator sets. The validator set at any height can
+	// be computed by applying at most this many diffs to the next checkpoint.
+	ValidatorSetCheckpointInterval = 1024
 )
 
 var (
@@ -65,6 +73,7 @@ var (
 	subnetDelegatorPrefix         = []byte("subnetDelegator")
 	validatorWeightDiffsPrefix    = []byte("validatorDiffs")
 	validatorPublicKeyDiffsPrefix = []byte("publicKeyDiffs")
+	validatorSetCheckpointsPrefix = []byte("validatorSetCheckpoints")
 	txPrefix                      = []byte("tx")
 	rewardUTXOsPrefix             = []byte("rewardUTXOs")
 	utxoPrefix                    = []byte("utxo")
@@ -76,6 +85,7 @@ var (
 
 	timestampKey     = []byte("timestamp")
 	currentSupplyKey = []byte("current supply")
+	feeStateKey      = []byte("fee state")
 	lastAcceptedKey  = []byte("last accepted")
 	initializedKey   = []byte("initialized")
 )
@@ -94,6 +104,11 @@ type Chain interface {
 	GetCurrentSupply(subnetID ids.ID) (uint64, error)
 	SetCurrentSupply(subnetID ids.ID, cs uint64)
 
+	// GetFeeState returns the prices that transactions pay once dynamic fees
+	// are activated.
+	GetFeeState() (fees.State, error)
+	SetFeeState(feeState fees.State)
+
 	GetRewardUTXOs(txID ids.ID) ([]*djtx.UTXO, error)
 	AddRewardUTXO(txID ids.ID, utxo *djtx.UTXO)
 
@@ -133,6 +148,16 @@ type State interface {
 	// that left the Primary Network validator set.
 	GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error)
 
+	// Returns the validator set of [subnetID] that was checkpointed at
+	// [height]. Checkpoints are only taken of the Primary Network and of
+	// whitelisted subnets, every [ValidatorSetCheckpointInterval] blocks.
+	// Returns database.ErrNotFound if there is no such checkpoint.
+	GetValidatorSetCheckpoint(height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+
+	// Returns a new validator set of the current validators of [subnetID].
+	// The returned set isn't updated as the validators change.
+	ValidatorSet(subnetID ids.ID) (validators.Set, error)
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -154,6 +179,15 @@ type stateBlk struct {
 	Status choices.Status `serialize:"true"`
 }
 
+// cachedBlockSize returns the number of bytes charged against the block cache
+// for caching [blk]. A nil [blk] marks a block that isn't in the database.
+func cachedBlockSize(_ ids.ID, blk *stateBlk) int {
+	if blk == nil {
+		return hashing.HashLen
+	}
+	return hashing.HashLen + len(blk.Bytes)
+}
+
 /*
  * VMDB
  * |-. validators
@@ -212,6 +246,7 @@ type stateBlk struct {
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
+ *   |-- feeStateKey -> feeState
  *   '-- lastAcceptedKey -> lastAccepted
  */
 type state struct {
@@ -229,8 +264,8 @@ type state struct {
 
 	currentHeight uint64
 
-	addedBlocks map[ids.ID]stateBlk // map of blockID -> Block
-	blockCache  cache.Cacher        // cache of blockID -> Block, if the entry is nil, it is not in the database
+	addedBlocks map[ids.ID]stateBlk             // map of blockID -> Block
+	blockCache  cache.Cacher[ids.ID, *stateBlk] // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
 
 	validatorsDB                 database.Database
@@ -253,18 +288,21 @@ type state struct {
 	pendingSubnetDelegatorBaseDB database.Database
 	pendingSubnetDelegatorList   linkeddb.LinkedDB
 
-	validatorWeightDiffsCache cache.Cacher // cache of heightWithSubnet -> map[ids.NodeID]*ValidatorWeightDiff
+	validatorWeightDiffsCache cache.Cacher[string, map[ids.NodeID]*ValidatorWeightDiff] // cache of heightWithSubnet -> map[ids.NodeID]*ValidatorWeightDiff
 	validatorWeightDiffsDB    database.Database
 
-	validatorPublicKeyDiffsCache cache.Cacher // cache of height -> map[ids.NodeID]*bls.PublicKey
+	validatorPublicKeyDiffsCache cache.Cacher[uint64, map[ids.NodeID]*bls.PublicKey] // cache of height -> map[ids.NodeID]*bls.PublicKey
 	validatorPublicKeyDiffsDB    database.Database
 
-	addedTxs map[ids.ID]*txAndStatus // map of txID -> {*txs.Tx, Status}
-	txCache  cache.Cacher            // cache of txID -> {*txs.Tx, Status} if the entry is nil, it is not in the database
+	validatorSetCheckpointsCache cache.Cacher[string, map[ids.NodeID]*validators.GetValidatorOutput] // cache of heightWithSubnet -> map[ids.NodeID]*validators.GetValidatorOutput
+	validatorSetCheckpointsDB    database.Database
+
+	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
+	txCache  cache.Cacher[ids.ID, *txAndStatus] // cache of txID -> {*txs.Tx, Status} if the entry is nil, it is not in the database
 	txDB     database.Database
 
-	addedRewardUTXOs map[ids.ID][]*djtx.UTXO // map of txID -> []*UTXO
-	rewardUTXOsCache cache.Cacher            // cache of txID -> []*UTXO
+	addedRewardUTXOs map[ids.ID][]*djtx.UTXO            // map of txID -> []*UTXO
+	rewardUTXOsCache cache.Cacher[ids.ID, []*djtx.UTXO] // cache of txID -> []*UTXO
 	rewardUTXODB     database.Database
 
 	modifiedUTXOs map[ids.ID]*djtx.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
@@ -276,22 +314,23 @@ type state struct {
 	subnetBaseDB  database.Database
 	subnetDB      linkeddb.LinkedDB
 
-	transformedSubnets     map[ids.ID]*txs.Tx // map of subnetID -> transformSubnetTx
-	transformedSubnetCache cache.Cacher       // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
+	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
+	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
 
-	modifiedSupplies map[ids.ID]uint64 // map of subnetID -> current supply
-	supplyCache      cache.Cacher      // cache of subnetID -> current supply if the entry is nil, it is not in the database
+	modifiedSupplies map[ids.ID]uint64             // map of subnetID -> current supply
+	supplyCache      cache.Cacher[ids.ID, *uint64] // cache of subnetID -> current supply if the entry is nil, it is not in the database
 	supplyDB         database.Database
 
-	addedChains  map[ids.ID][]*txs.Tx // maps subnetID -> the newly added chains to the subnet
-	chainCache   cache.Cacher         // cache of subnetID -> the chains after all local modifications []*txs.Tx
-	chainDBCache cache.Cacher         // cache of subnetID -> linkedDB
+	addedChains  map[ids.ID][]*txs.Tx                    // maps subnetID -> the newly added chains to the subnet
+	chainCache   cache.Cacher[ids.ID, []*txs.Tx]         // cache of subnetID -> the chains after all local modifications []*txs.Tx
+	chainDBCache cache.Cacher[ids.ID, linkeddb.LinkedDB] // cache of subnetID -> linkedDB
 	chainDB      database.Database
 
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	currentSupply, persistedCurrentSupply uint64
+	feeState, persistedFeeState           fees.State
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	singletonDB                         database.Database
@@ -323,6 +362,17 @@ type heightWithSubnet struct {
 	SubnetID ids.ID `serialize:"true"`
 }
 
+type validatorSetCheckpoint struct {
+	Validators []checkpointedValidator `serialize:"true"`
+}
+
+type checkpointedValidator struct {
+	NodeID ids.NodeID `serialize:"true"`
+	// PublicKey is empty if the validator didn't register a BLS key.
+	PublicKey []byte `serialize:"true"`
+	Weight    uint64 `serialize:"true"`
+}
+
 type txBytesAndStatus struct {
 	Tx     []byte        `serialize:"true"`
 	Status status.Status `serialize:"true"`
@@ -375,7 +425,7 @@ func new(
 	blockCache, err := metercacher.New(
 		"block_cache",
 		metricsReg,
-		&cache.LRU{Size: blockCacheSize},
+		cache.NewSizedLRU(blockCacheSize, cachedBlockSize),
 	)
 	if err != nil {
 		return nil, err
@@ -401,7 +451,7 @@ func new(
 	validatorWeightDiffsCache, err := metercacher.New(
 		"validator_weight_diffs_cache",
 		metricsReg,
-		&cache.LRU{Size: validatorDiffsCacheSize},
+		cache.NewLRU[string, map[ids.NodeID]*ValidatorWeightDiff](validatorDiffsCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -411,7 +461,17 @@ func new(
 	validatorPublicKeyDiffsCache, err := metercacher.New(
 		"validator_pub_key_diffs_cache",
 		metricsReg,
-		&cache.LRU{Size: validatorDiffsCacheSize},
+		cache.NewLRU[uint64, map[ids.NodeID]*bls.PublicKey](validatorDiffsCacheSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorSetCheckpointsDB := prefixdb.New(validatorSetCheckpointsPrefix, validatorsDB)
+	validatorSetCheckpointsCache, err := metercacher.New(
+		"validator_set_checkpoints_cache",
+		metricsReg,
+		cache.NewLRU[string, map[ids.NodeID]*validators.GetValidatorOutput](validatorSetCheckpointsCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -420,7 +480,7 @@ func new(
 	txCache, err := metercacher.New(
 		"tx_cache",
 		metricsReg,
-		&cache.LRU{Size: txCacheSize},
+		cache.NewLRU[ids.ID, *txAndStatus](txCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -430,7 +490,7 @@ func new(
 	rewardUTXOsCache, err := metercacher.New(
 		"reward_utxos_cache",
 		metricsReg,
-		&cache.LRU{Size: rewardUTXOsCacheSize},
+		cache.NewLRU[ids.ID, []*djtx.UTXO](rewardUTXOsCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -447,7 +507,7 @@ func new(
 	transformedSubnetCache, err := metercacher.New(
 		"transformed_subnet_cache",
 		metricsReg,
-		&cache.LRU{Size: chainCacheSize},
+		cache.NewLRU[ids.ID, *txs.Tx](chainCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -456,7 +516,7 @@ func new(
 	supplyCache, err := metercacher.New(
 		"supply_cache",
 		metricsReg,
-		&cache.LRU{Size: chainCacheSize},
+		cache.NewLRU[ids.ID, *uint64](chainCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -465,7 +525,7 @@ func new(
 	chainCache, err := metercacher.New(
 		"chain_cache",
 		metricsReg,
-		&cache.LRU{Size: chainCacheSize},
+		cache.NewLRU[ids.ID, []*txs.Tx](chainCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -474,7 +534,7 @@ func new(
 	chainDBCache, err := metercacher.New(
 		"chain_db_cache",
 		metricsReg,
-		&cache.LRU{Size: chainDBCacheSize},
+		cache.NewLRU[ids.ID, linkeddb.LinkedDB](chainDBCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -519,6 +579,8 @@ func new(
 		validatorWeightDiffsCache:    validatorWeightDiffsCache,
 		validatorPublicKeyDiffsCache: validatorPublicKeyDiffsCache,
 		validatorPublicKeyDiffsDB:    validatorPublicKeyDiffsDB,
+		validatorSetCheckpointsCache: validatorSetCheckpointsCache,
+		validatorSetCheckpointsDB:    validatorSetCheckpointsDB,
 
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(txPrefix, baseDB),
@@ -658,11 +720,11 @@ func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 		return tx, nil
 	}
 
-	if txIntf, cached := s.transformedSubnetCache.Get(subnetID); cached {
-		if txIntf == nil {
+	if tx, cached := s.transformedSubnetCache.Get(subnetID); cached {
+		if tx == nil {
 			return nil, database.ErrNotFound
 		}
-		return txIntf.(*txs.Tx), nil
+		return tx, nil
 	}
 
 	transformSubnetTxID, err := database.GetID(s.transformedSubnetDB, subnetID[:])
@@ -688,8 +750,8 @@ func (s *state) AddSubnetTransformation(transformSubnetTxIntf *txs.Tx) {
 }
 
 func (s *state) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
-	if chainsIntf, cached := s.chainCache.Get(subnetID); cached {
-		return chainsIntf.([]*txs.Tx), nil
+	if chains, cached := s.chainCache.Get(subnetID); cached {
+		return chains, nil
 	}
 	chainDB := s.getChainDB(subnetID)
 	chainDBIt := chainDB.NewIterator()
@@ -720,16 +782,15 @@ func (s *state) AddChain(createChainTxIntf *txs.Tx) {
 	createChainTx := createChainTxIntf.Unsigned.(*txs.CreateChainTx)
 	subnetID := createChainTx.SubnetID
 	s.addedChains[subnetID] = append(s.addedChains[subnetID], createChainTxIntf)
-	if chainsIntf, cached := s.chainCache.Get(subnetID); cached {
-		chains := chainsIntf.([]*txs.Tx)
+	if chains, cached := s.chainCache.Get(subnetID); cached {
 		chains = append(chains, createChainTxIntf)
 		s.chainCache.Put(subnetID, chains)
 	}
 }
 
 func (s *state) getChainDB(subnetID ids.ID) linkeddb.LinkedDB {
-	if chainDBIntf, cached := s.chainDBCache.Get(subnetID); cached {
-		return chainDBIntf.(linkeddb.LinkedDB)
+	if chainDB, cached := s.chainDBCache.Get(subnetID); cached {
+		return chainDB
 	}
 	rawChainDB := prefixdb.New(subnetID[:], s.chainDB)
 	chainDB := linkeddb.NewDefault(rawChainDB)
@@ -741,11 +802,10 @@ func (s *state) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
 	if tx, exists := s.addedTxs[txID]; exists {
 		return tx.tx, tx.status, nil
 	}
-	if txIntf, cached := s.txCache.Get(txID); cached {
-		if txIntf == nil {
+	if tx, cached := s.txCache.Get(txID); cached {
+		if tx == nil {
 			return nil, status.Unknown, database.ErrNotFound
 		}
-		tx := txIntf.(*txAndStatus)
 		return tx.tx, tx.status, nil
 	}
 	txBytes, err := s.txDB.Get(txID[:])
@@ -787,7 +847,7 @@ func (s *state) GetRewardUTXOs(txID ids.ID) ([]*djtx.UTXO, error) {
 		return utxos, nil
 	}
 	if utxos, exists := s.rewardUTXOsCache.Get(txID); exists {
-		return utxos.([]*djtx.UTXO), nil
+		return utxos, nil
 	}
 
 	rawTxDB := prefixdb.New(txID[:], s.rewardUTXODB)
@@ -853,6 +913,14 @@ func (s *state) SetTimestamp(tm time.Time) {
 	s.timestamp = tm
 }
 
+func (s *state) GetFeeState() (fees.State, error) {
+	return s.feeState, nil
+}
+
+func (s *state) SetFeeState(feeState fees.State) {
+	s.feeState = feeState
+}
+
 func (s *state) GetLastAccepted() ids.ID {
 	return s.lastAccepted
 }
@@ -871,12 +939,12 @@ func (s *state) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
 		return supply, nil
 	}
 
-	supplyIntf, ok := s.supplyCache.Get(subnetID)
+	cachedSupply, ok := s.supplyCache.Get(subnetID)
 	if ok {
-		if supplyIntf == nil {
+		if cachedSupply == nil {
 			return 0, database.ErrNotFound
 		}
-		return supplyIntf.(uint64), nil
+		return *cachedSupply, nil
 	}
 
 	supply, err := database.GetUInt64(s.supplyDB, subnetID[:])
@@ -888,7 +956,7 @@ func (s *state) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
 		return 0, err
 	}
 
-	s.supplyCache.Put(subnetID, supply)
+	s.supplyCache.Put(subnetID, &supply)
 	return supply, nil
 }
 
@@ -911,8 +979,8 @@ func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids
 	}
 	prefixStr := string(prefixBytes)
 
-	if weightDiffsIntf, ok := s.validatorWeightDiffsCache.Get(prefixStr); ok {
-		return weightDiffsIntf.(map[ids.NodeID]*ValidatorWeightDiff), nil
+	if weightDiffs, ok := s.validatorWeightDiffsCache.Get(prefixStr); ok {
+		return weightDiffs, nil
 	}
 
 	rawDiffDB := prefixdb.New(prefixBytes, s.validatorWeightDiffsDB)
@@ -941,8 +1009,8 @@ func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids
 }
 
 func (s *state) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error) {
-	if publicKeyDiffsIntf, ok := s.validatorPublicKeyDiffsCache.Get(height); ok {
-		return publicKeyDiffsIntf.(map[ids.NodeID]*bls.PublicKey), nil
+	if publicKeyDiffs, ok := s.validatorPublicKeyDiffsCache.Get(height); ok {
+		return publicKeyDiffs, nil
 	}
 
 	heightBytes := database.PackUInt64(height)
@@ -970,11 +1038,70 @@ func (s *state) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.P
 	return pkDiffs, diffIter.Error()
 }
 
+func (s *state) GetValidatorSetCheckpoint(height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	key, err := blocks.GenesisCodec.Marshal(blocks.Version, heightWithSubnet{
+		Height:   height,
+		SubnetID: subnetID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keyStr := string(key)
+
+	vdrSet, ok := s.validatorSetCheckpointsCache.Get(keyStr)
+	if !ok {
+		vdrSet, err = s.loadValidatorSetCheckpoint(key)
+		if err != nil {
+			return nil, err
+		}
+		s.validatorSetCheckpointsCache.Put(keyStr, vdrSet)
+	}
+
+	// The caller applies diffs to the returned validator set, so the cached
+	// validator set must not be returned.
+	vdrSetCopy := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrSet))
+	for nodeID, vdr := range vdrSet {
+		vdrCopy := *vdr
+		vdrSetCopy[nodeID] = &vdrCopy
+	}
+	return vdrSetCopy, nil
+}
+
+func (s *state) loadValidatorSetCheckpoint(key []byte) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	checkpointBytes, err := s.validatorSetCheckpointsDB.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := validatorSetCheckpoint{}
+	if _, err := blocks.GenesisCodec.Unmarshal(checkpointBytes, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(checkpoint.Validators))
+	for _, vdr := range checkpoint.Validators {
+		var pk *bls.PublicKey
+		if len(vdr.PublicKey) > 0 {
+			pk, err = bls.PublicKeyFromBytes(vdr.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+		vdrSet[vdr.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.NodeID,
+			PublicKey: pk,
+			Weight:    vdr.Weight,
+		}
+	}
+	return vdrSet, nil
+}
+
 func (s *state) syncGenesis(genesisBlk blocks.Block, genesis *genesis.State) error {
 	genesisBlkID := genesisBlk.ID()
 	s.SetLastAccepted(genesisBlkID)
 	s.SetTimestamp(time.Unix(int64(genesis.Timestamp), 0))
 	s.SetCurrentSupply(constants.PrimaryNetworkID, genesis.InitialSupply)
+	s.SetFeeState(fees.NewState(s.cfg.DynamicFeeConfig))
 	s.AddStatelessBlock(genesisBlk, choices.Accepted)
 
 	// Persist UTXOs that exist at genesis
@@ -1065,12 +1192,37 @@ func (s *state) loadMetadata() error {
 	s.persistedCurrentSupply = currentSupply
 	s.SetCurrentSupply(constants.PrimaryNetworkID, currentSupply)
 
+	// The fee state was added after the other singletons, so it may not have
+	// been written yet.
+	feeState := fees.NewState(s.cfg.DynamicFeeConfig)
+	feeStateBytes, err := s.singletonDB.Get(feeStateKey)
+	switch err {
+	case nil:
+		if _, err := blocks.GenesisCodec.Unmarshal(feeStateBytes, &feeState); err != nil {
+			return fmt.Errorf("failed to parse fee state: %w", err)
+		}
+		s.persistedFeeState = feeState
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+	s.SetFeeState(feeState)
+
 	lastAccepted, err := database.GetID(s.singletonDB, lastAcceptedKey)
 	if err != nil {
 		return err
 	}
 	s.persistedLastAccepted = lastAccepted
 	s.lastAccepted = lastAccepted
+
+	// The height is set explicitly when blocks are accepted. Initializing it
+	// here ensures that commits made before then are attributed to the last
+	// accepted height.
+	lastAcceptedBlk, _, err := s.GetStatelessBlock(lastAccepted)
+	if err != nil {
+		return err
+	}
+	s.currentHeight = lastAcceptedBlk.Height()
 	return nil
 }
 
@@ -1315,7 +1467,7 @@ func (s *state) loadPendingValidators() error {
 }
 
 // Invariant: initValidatorSets requires loadCurrentValidators to have already
-//            been called.
+// been called.
 func (s *state) initValidatorSets() error {
 	primaryValidators, ok := s.cfg.Validators.Get(constants.PrimaryNetworkID)
 	if !ok {
@@ -1334,8 +1486,7 @@ func (s *state) initValidatorSets() error {
 	s.metrics.SetTotalStake(primaryValidators.Weight())
 
 	for subnetID := range s.cfg.WhitelistedSubnets {
-		subnetValidators := validators.NewSet()
-		err := s.validatorSet(subnetID, subnetValidators)
+		subnetValidators, err := s.ValidatorSet(subnetID)
 		if err != nil {
 			return err
 		}
@@ -1347,6 +1498,11 @@ func (s *state) initValidatorSets() error {
 	return nil
 }
 
+func (s *state) ValidatorSet(subnetID ids.ID) (validators.Set, error) {
+	vdrs := validators.NewSet()
+	return vdrs, s.validatorSet(subnetID, vdrs)
+}
+
 func (s *state) validatorSet(subnetID ids.ID, vdrs validators.Set) error {
 	for nodeID, validator := range s.currentStakers.validators[subnetID] {
 		staker := validator.validator
@@ -1372,6 +1528,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 	errs.Add(
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height),
+		s.writeValidatorSetCheckpoints(updateValidators, height), // Must be called after writeCurrentStakers
 		s.writePendingStakers(),
 		s.WriteUptimes(s.currentValidatorList, s.currentSubnetValidatorList), // Must be called after writeCurrentStakers
 		s.writeTXs(),
@@ -1515,7 +1672,7 @@ func (s *state) writeBlocks() error {
 		}
 
 		delete(s.addedBlocks, blkID)
-		s.blockCache.Put(blkID, stateBlk)
+		s.blockCache.Put(blkID, &stBlk)
 		if err := s.blockDB.Put(blkID[:], blockBytes); err != nil {
 			return fmt.Errorf("failed to write block %s: %w", blkID, err)
 		}
@@ -1527,12 +1684,10 @@ func (s *state) GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status,
 	if blk, exists := s.addedBlocks[blockID]; exists {
 		return blk.Blk, blk.Status, nil
 	}
-	if blkIntf, cached := s.blockCache.Get(blockID); cached {
-		if blkIntf == nil {
+	if blkState, cached := s.blockCache.Get(blockID); cached {
+		if blkState == nil {
 			return nil, choices.Processing, database.ErrNotFound // status does not matter here
 		}
-
-		blkState := blkIntf.(stateBlk)
 		return blkState.Blk, blkState.Status, nil
 	}
 
@@ -1555,7 +1710,7 @@ func (s *state) GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status,
 		return nil, choices.Processing, err
 	}
 
-	s.blockCache.Put(blockID, blkState)
+	s.blockCache.Put(blockID, &blkState)
 	return blkState.Blk, blkState.Status, nil
 }
 
@@ -1753,6 +1908,62 @@ func writeCurrentDelegatorDiff(
 	return nil
 }
 
+// writeValidatorSetCheckpoints persists the validator sets of the Primary
+// Network and of the whitelisted subnets at [height], if [height] is a
+// checkpoint height.
+func (s *state) writeValidatorSetCheckpoints(updateValidators bool, height uint64) error {
+	// The validator manager is only up to date with [height] if the validators
+	// are being updated.
+	if !updateValidators || height%ValidatorSetCheckpointInterval != 0 {
+		return nil
+	}
+
+	primaryValidators, ok := s.cfg.Validators.Get(constants.PrimaryNetworkID)
+	if !ok {
+		return nil
+	}
+
+	subnetIDs := append([]ids.ID{constants.PrimaryNetworkID}, s.cfg.WhitelistedSubnets.List()...)
+	for _, subnetID := range subnetIDs {
+		vdrs, ok := s.cfg.Validators.Get(subnetID)
+		if !ok {
+			continue
+		}
+
+		vdrList := vdrs.List()
+		checkpoint := validatorSetCheckpoint{
+			Validators: make([]checkpointedValidator, len(vdrList)),
+		}
+		for i, vdr := range vdrList {
+			checkpoint.Validators[i] = checkpointedValidator{
+				NodeID: vdr.NodeID,
+				Weight: vdr.Weight,
+			}
+			// Invariant: Only the Primary Network contains non-nil public
+			//            keys.
+			if primaryVdr, ok := primaryValidators.Get(vdr.NodeID); ok && primaryVdr.PublicKey != nil {
+				checkpoint.Validators[i].PublicKey = bls.PublicKeyToBytes(primaryVdr.PublicKey)
+			}
+		}
+
+		key, err := blocks.GenesisCodec.Marshal(blocks.Version, heightWithSubnet{
+			Height:   height,
+			SubnetID: subnetID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create checkpoint key: %w", err)
+		}
+		checkpointBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, &checkpoint)
+		if err != nil {
+			return fmt.Errorf("failed to serialize validator set checkpoint: %w", err)
+		}
+		if err := s.validatorSetCheckpointsDB.Put(key, checkpointBytes); err != nil {
+			return fmt.Errorf("failed to write validator set checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writePendingStakers() error {
 	for subnetID, subnetValidatorDiffs := range s.pendingStakers.validatorDiffs {
 		delete(s.pendingStakers.validatorDiffs, subnetID)
@@ -1906,7 +2117,8 @@ func (s *state) writeTransformedSubnets() error {
 func (s *state) writeSubnetSupplies() error {
 	for subnetID, supply := range s.modifiedSupplies {
 		delete(s.modifiedSupplies, subnetID)
-		s.supplyCache.Put(subnetID, supply)
+		supply := supply
+		s.supplyCache.Put(subnetID, &supply)
 		if err := database.PutUInt64(s.supplyDB, subnetID[:], supply); err != nil {
 			return fmt.Errorf("failed to write subnet supply: %w", err)
 		}
@@ -1942,6 +2154,16 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedCurrentSupply = s.currentSupply
 	}
+	if s.persistedFeeState != s.feeState {
+		feeStateBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, &s.feeState)
+		if err != nil {
+			return fmt.Errorf("failed to serialize fee state: %w", err)
+		}
+		if err := s.singletonDB.Put(feeStateKey, feeStateBytes); err != nil {
+			return fmt.Errorf("failed to write fee state: %w", err)
+		}
+		s.persistedFeeState = s.feeState
+	}
 	if s.persistedLastAccepted != s.lastAccepted {
 		if err := database.PutID(s.singletonDB, lastAcceptedKey, s.lastAccepted); err != nil {
 			return fmt.Errorf("failed to write last accepted: %w", err)
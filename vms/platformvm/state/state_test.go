@@ -16,6 +16,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/database/memdb"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/snow/choices"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
@@ -24,6 +25,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/genesis"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
@@ -421,6 +423,124 @@ func TestGetValidatorPublicKeyDiffs(t *testing.T) {
 	}
 }
 
+func TestGetValidatorSetCheckpoint(t *testing.T) {
+	require := require.New(t)
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	staker := &Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    ids.GenerateTestNodeID(),
+		PublicKey: pk,
+		SubnetID:  constants.PrimaryNetworkID,
+		Weight:    10,
+	}
+	s.PutCurrentValidator(staker)
+	s.SetHeight(ValidatorSetCheckpointInterval)
+	require.NoError(s.Commit())
+
+	// The validator manager of the test state isn't initialized from genesis,
+	// so only the added validator is checkpointed.
+	expectedVdrSet := map[ids.NodeID]*validators.GetValidatorOutput{
+		staker.NodeID: {
+			NodeID:    staker.NodeID,
+			PublicKey: pk,
+			Weight:    staker.Weight,
+		},
+	}
+	vdrSet, err := s.GetValidatorSetCheckpoint(ValidatorSetCheckpointInterval, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(expectedVdrSet, vdrSet)
+
+	// Modifying a returned validator set doesn't modify the cached checkpoint.
+	vdrSet[staker.NodeID].Weight++
+	delete(vdrSet, staker.NodeID)
+	vdrSet, err = s.GetValidatorSetCheckpoint(ValidatorSetCheckpointInterval, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(expectedVdrSet, vdrSet)
+
+	// Checkpoints aren't taken between checkpoint heights.
+	s.DeleteCurrentValidator(staker)
+	s.SetHeight(ValidatorSetCheckpointInterval + 1)
+	require.NoError(s.Commit())
+
+	_, err = s.GetValidatorSetCheckpoint(ValidatorSetCheckpointInterval+1, constants.PrimaryNetworkID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Checkpoints are only taken of tracked subnets.
+	_, err = s.GetValidatorSetCheckpoint(ValidatorSetCheckpointInterval, ids.GenerateTestID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Checkpoints are persisted.
+	s = newStateFromDB(require, db).(*state)
+	vdrSet, err = s.GetValidatorSetCheckpoint(ValidatorSetCheckpointInterval, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(expectedVdrSet, vdrSet)
+}
+
+func TestFeeState(t *testing.T) {
+	require := require.New(t)
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	feeState, err := s.GetFeeState()
+	require.NoError(err)
+	require.Equal(fees.NewState(s.cfg.DynamicFeeConfig), feeState)
+
+	expectedFeeState := fees.State{Prices: fees.Dimensions{1, 2, 3, 4}}
+	s.SetFeeState(expectedFeeState)
+	require.NoError(s.Commit())
+
+	// The fee state is persisted.
+	s = newStateFromDB(require, db).(*state)
+	require.NoError(s.loadMetadata())
+	feeState, err = s.GetFeeState()
+	require.NoError(err)
+	require.Equal(expectedFeeState, feeState)
+
+	// Databases written before the fee state existed start at the minimum
+	// prices.
+	require.NoError(s.singletonDB.Delete(feeStateKey))
+	require.NoError(s.Commit())
+	s = newStateFromDB(require, db).(*state)
+	require.NoError(s.loadMetadata())
+	feeState, err = s.GetFeeState()
+	require.NoError(err)
+	require.Equal(fees.NewState(s.cfg.DynamicFeeConfig), feeState)
+}
+
+func TestLoadMetadataHeight(t *testing.T) {
+	require := require.New(t)
+	stateIntf, db := newInitializedState(require)
+	s := stateIntf.(*state)
+
+	blk, err := blocks.NewApricotCommitBlock(s.GetLastAccepted(), ValidatorSetCheckpointInterval)
+	require.NoError(err)
+	s.AddStatelessBlock(blk, choices.Accepted)
+	s.SetLastAccepted(blk.ID())
+	// The height isn't set, so the block's validator set isn't checkpointed
+	// yet.
+	require.NoError(s.Commit())
+	_, err = s.GetValidatorSetCheckpoint(blk.Height(), constants.PrimaryNetworkID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The height is loaded from the last accepted block.
+	s = newStateFromDB(require, db).(*state)
+	require.NoError(s.load())
+	require.Equal(blk.Height(), s.currentHeight)
+
+	// Commits made before the next block is accepted are attributed to the
+	// last accepted height.
+	require.NoError(s.Commit())
+	vdrSet, err := s.GetValidatorSetCheckpoint(blk.Height(), constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Contains(vdrSet, initialNodeID)
+}
+
 func newInitializedState(require *require.Assertions) (State, database.Database) {
 	s, db := newUninitializedState(require)
 
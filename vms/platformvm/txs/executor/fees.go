@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+// getTxFee returns the amount of DJTX that [tx] must burn when it is executed
+// on top of [chainState], whose timestamp is [timestamp]. Before dynamic fees
+// are activated, this is [staticFee]. Afterwards, it's the greater of
+// [staticFee] and the cost of the units consumed by [tx] at the current prices
+// of [chainState]. The static fee is kept as a floor so that txs that are cheap
+// to execute but costly to the network, such as CreateSubnetTx, stay priced
+// accordingly.
+func getTxFee(
+	backend *Backend,
+	chainState state.Chain,
+	timestamp time.Time,
+	tx *txs.Tx,
+	staticFee uint64,
+) (uint64, error) {
+	if !backend.Config.IsDynamicFeesActivated(timestamp) {
+		return staticFee, nil
+	}
+
+	feeState, err := chainState.GetFeeState()
+	if err != nil {
+		return 0, err
+	}
+	dynamicFee, err := feeState.Cost(fees.TxUnits(tx))
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(staticFee, dynamicFee), nil
+}
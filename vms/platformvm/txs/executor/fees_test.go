@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fees"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/state"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestGetTxFee(t *testing.T) {
+	activationTime := time.Unix(1000, 0)
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{},
+		},
+		Creds: []verify.Verifiable{},
+	}
+	require.NoError(t, tx.Sign(txs.Codec, nil))
+
+	// Every unit costs 1, so the dynamic fee is the number of units consumed.
+	units := fees.TxUnits(tx)
+	dynamicFee := units[fees.Bandwidth] + units[fees.DBRead] + units[fees.DBWrite] + units[fees.Compute]
+
+	tests := []struct {
+		name        string
+		timestamp   time.Time
+		staticFee   uint64
+		expectedFee uint64
+	}{
+		{
+			name:        "before activation",
+			timestamp:   activationTime.Add(-time.Second),
+			staticFee:   1,
+			expectedFee: 1,
+		},
+		{
+			name:        "static fee is higher",
+			timestamp:   activationTime,
+			staticFee:   dynamicFee + 1,
+			expectedFee: dynamicFee + 1,
+		},
+		{
+			name:        "dynamic fee is higher",
+			timestamp:   activationTime,
+			staticFee:   dynamicFee - 1,
+			expectedFee: dynamicFee,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			backend := &Backend{
+				Config: &config.Config{
+					DynamicFeesTime: activationTime,
+				},
+			}
+
+			chainState := state.NewMockChain(ctrl)
+			chainState.EXPECT().GetFeeState().Return(fees.State{Prices: fees.Dimensions{1, 1, 1, 1}}, nil).AnyTimes()
+
+			fee, err := getTxFee(backend, chainState, test.timestamp, tx, test.staticFee)
+			require.NoError(err)
+			require.Equal(test.expectedFee, fee)
+		})
+	}
+}
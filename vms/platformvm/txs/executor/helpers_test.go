@@ -315,6 +315,7 @@ func defaultConfig(postBanff bool) config.Config {
 		ApricotPhase3Time: defaultValidateEndTime,
 		ApricotPhase5Time: defaultValidateEndTime,
 		BanffTime:         banffTime,
+		DynamicFeesTime:   mockable.MaxTime,
 	}
 }
 
@@ -115,6 +115,11 @@ func verifyAddValidatorTx(
 		)
 	}
 
+	txFee, err := getTxFee(backend, chainState, currentTimestamp, sTx, backend.Config.AddPrimaryNetworkValidatorFee)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -123,7 +128,7 @@ func verifyAddValidatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.DJTXAssetID: backend.Config.AddPrimaryNetworkValidatorFee,
+			backend.Ctx.DJTXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
@@ -214,6 +219,11 @@ func verifyAddSubnetValidatorTx(
 		return err
 	}
 
+	txFee, err := getTxFee(backend, chainState, currentTimestamp, sTx, backend.Config.AddSubnetValidatorFee)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -222,7 +232,7 @@ func verifyAddSubnetValidatorTx(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.DJTXAssetID: backend.Config.AddSubnetValidatorFee,
+			backend.Ctx.DJTXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
@@ -289,6 +299,11 @@ func removeSubnetValidatorValidation(
 		return nil, false, err
 	}
 
+	txFee, err := getTxFee(backend, chainState, chainState.GetTimestamp(), sTx, backend.Config.TxFee)
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -297,7 +312,7 @@ func removeSubnetValidatorValidation(
 		tx.Outs,
 		baseTxCreds,
 		map[ids.ID]uint64{
-			backend.Ctx.DJTXAssetID: backend.Config.TxFee,
+			backend.Ctx.DJTXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, false, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
@@ -390,6 +405,11 @@ func verifyAddDelegatorTx(
 		return nil, errOverDelegated
 	}
 
+	txFee, err := getTxFee(backend, chainState, currentTimestamp, sTx, backend.Config.AddPrimaryNetworkDelegatorFee)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify the flowcheck
 	if err := backend.FlowChecker.VerifySpend(
 		tx,
@@ -398,7 +418,7 @@ func verifyAddDelegatorTx(
 		outs,
 		sTx.Creds,
 		map[ids.ID]uint64{
-			backend.Ctx.DJTXAssetID: backend.Config.AddPrimaryNetworkDelegatorFee,
+			backend.Ctx.DJTXAssetID: txFee,
 		},
 	); err != nil {
 		return nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
@@ -499,7 +519,7 @@ func verifyAddPermissionlessValidatorTx(
 		)
 	}
 
-	var txFee uint64
+	var staticFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
 		primaryNetworkValidator, err := GetValidator(chainState, constants.PrimaryNetworkID, tx.Validator.NodeID)
 		if err != nil {
@@ -516,9 +536,14 @@ func verifyAddPermissionlessValidatorTx(
 			return errValidatorSubset
 		}
 
-		txFee = backend.Config.AddSubnetValidatorFee
+		staticFee = backend.Config.AddSubnetValidatorFee
 	} else {
-		txFee = backend.Config.AddPrimaryNetworkValidatorFee
+		staticFee = backend.Config.AddPrimaryNetworkValidatorFee
+	}
+
+	txFee, err := getTxFee(backend, chainState, currentTimestamp, sTx, staticFee)
+	if err != nil {
+		return err
 	}
 
 	outs := make([]*djtx.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
@@ -688,7 +713,7 @@ func verifyAddPermissionlessDelegatorTx(
 	copy(outs, tx.Outs)
 	copy(outs[len(tx.Outs):], tx.StakeOuts)
 
-	var txFee uint64
+	var staticFee uint64
 	if tx.Subnet != constants.PrimaryNetworkID {
 		// Invariant: Delegators must only be able to reference validator
 		//            transactions that implement [txs.ValidatorTx]. All
@@ -701,9 +726,14 @@ func verifyAddPermissionlessDelegatorTx(
 			return errDelegateToPermissionedValidator
 		}
 
-		txFee = backend.Config.AddSubnetDelegatorFee
+		staticFee = backend.Config.AddSubnetDelegatorFee
 	} else {
-		txFee = backend.Config.AddPrimaryNetworkDelegatorFee
+		staticFee = backend.Config.AddPrimaryNetworkDelegatorFee
+	}
+
+	txFee, err := getTxFee(backend, chainState, currentTimestamp, sTx, staticFee)
+	if err != nil {
+		return err
 	}
 
 	// Verify the flowcheck
@@ -57,7 +57,10 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
-	createBlockchainTxFee := e.Config.GetCreateBlockchainTxFee(timestamp)
+	createBlockchainTxFee, err := getTxFee(e.Backend, e.State, timestamp, e.Tx, e.Config.GetCreateBlockchainTxFee(timestamp))
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -96,7 +99,10 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 
 	// Verify the flowcheck
 	timestamp := e.State.GetTimestamp()
-	createSubnetTxFee := e.Config.GetCreateSubnetTxFee(timestamp)
+	createSubnetTxFee, err := getTxFee(e.Backend, e.State, timestamp, e.Tx, e.Config.GetCreateSubnetTxFee(timestamp))
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -165,6 +171,11 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 		copy(ins, tx.Ins)
 		copy(ins[len(tx.Ins):], tx.ImportedInputs)
 
+		txFee, err := getTxFee(e.Backend, e.State, e.State.GetTimestamp(), e.Tx, e.Config.TxFee)
+		if err != nil {
+			return err
+		}
+
 		if err := e.FlowChecker.VerifySpendUTXOs(
 			tx,
 			utxos,
@@ -172,7 +183,7 @@ func (e *StandardTxExecutor) ImportTx(tx *txs.ImportTx) error {
 			tx.Outs,
 			e.Tx.Creds,
 			map[ids.ID]uint64{
-				e.Ctx.DJTXAssetID: e.Config.TxFee,
+				e.Ctx.DJTXAssetID: txFee,
 			},
 		); err != nil {
 			return err
@@ -209,6 +220,11 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 		}
 	}
 
+	txFee, err := getTxFee(e.Backend, e.State, e.State.GetTimestamp(), e.Tx, e.Config.TxFee)
+	if err != nil {
+		return err
+	}
+
 	// Verify the flowcheck
 	if err := e.FlowChecker.VerifySpend(
 		tx,
@@ -217,7 +233,7 @@ func (e *StandardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 		outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
-			e.Ctx.DJTXAssetID: e.Config.TxFee,
+			e.Ctx.DJTXAssetID: txFee,
 		},
 	); err != nil {
 		return fmt.Errorf("failed verifySpend: %w", err)
@@ -384,6 +400,11 @@ func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error
 		return err
 	}
 
+	transformSubnetTxFee, err := getTxFee(e.Backend, e.State, e.State.GetTimestamp(), e.Tx, e.Config.TransformSubnetTxFee)
+	if err != nil {
+		return err
+	}
+
 	totalRewardAmount := tx.MaximumSupply - tx.InitialSupply
 	if err := e.Backend.FlowChecker.VerifySpend(
 		tx,
@@ -395,7 +416,7 @@ func (e *StandardTxExecutor) TransformSubnetTx(tx *txs.TransformSubnetTx) error
 		//            entry in this map literal from being overwritten by the
 		//            second entry.
 		map[ids.ID]uint64{
-			e.Ctx.DJTXAssetID: e.Config.TransformSubnetTxFee,
+			e.Ctx.DJTXAssetID: transformSubnetTxFee,
 			tx.AssetID:        totalRewardAmount,
 		},
 	); err != nil {
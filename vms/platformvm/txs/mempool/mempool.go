@@ -6,15 +6,18 @@ package mempool
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/lasthyphen/dijetsnodego/cache"
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/linkedhashmap"
 	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs/txheap"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/utxo"
 )
 
 const (
@@ -22,13 +25,15 @@ const (
 	// allowed into the mempool.
 	targetTxSize = 64 * units.KiB
 
-	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to cache
+	// droppedTxIDsCacheSize is the maximum number of dropped txIDs to track
 	droppedTxIDsCacheSize = 64
 
 	initialConsumedUTXOsSize = 512
 
 	// maxMempoolSize is the maximum number of bytes allowed in the mempool
 	maxMempoolSize = 64 * units.MiB
+
+	evictedReason = "evicted by a tx paying a higher fee rate"
 )
 
 var (
@@ -61,6 +66,8 @@ type Mempool interface {
 	HasTxs() bool
 	// PeekTxs returns the next txs for Banff blocks
 	// up to maxTxsBytes without removing them from the mempool.
+	// Decision txs are returned first, starting with the
+	// txs that pay the highest fee rate.
 	PeekTxs(maxTxsBytes int) []*txs.Tx
 
 	HasStakerTx() bool
@@ -75,6 +82,13 @@ type Mempool interface {
 	// reissued.
 	MarkDropped(txID ids.ID, reason string)
 	GetDropReason(txID ids.ID) (string, bool)
+	// GetDroppedTxIDs returns the IDs of the most recently
+	// dropped txs, starting with the most recently dropped tx.
+	GetDroppedTxIDs() []ids.ID
+
+	// GetAddedTime returns the time [txID] was added to the
+	// mempool. It returns false if [txID] isn't in the mempool.
+	GetAddedTime(txID ids.ID) (time.Time, bool)
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -86,12 +100,22 @@ type mempool struct {
 	bytesAvailableMetric prometheus.Gauge
 	bytesAvailable       int
 
+	djtxAssetID ids.ID
+
+	// Decision txs are ordered by the fee rate they pay, so that the lowest
+	// paying txs can be evicted when the mempool is full.
 	unissuedDecisionTxs txheap.Heap
 	unissuedStakerTxs   txheap.Heap
 
 	// Key: Tx ID
 	// Value: String repr. of the verification error
-	droppedTxIDs *cache.LRU
+	// Only the [droppedTxIDsCacheSize] most recently dropped txs are tracked.
+	droppedTxIDs linkedhashmap.LinkedHashmap[ids.ID, string]
+
+	// Key: Tx ID
+	// Value: Time the tx was added to the mempool
+	addedTimes map[ids.ID]time.Time
+	clk        *mockable.Clock
 
 	consumedUTXOs set.Set[ids.ID]
 
@@ -102,6 +126,8 @@ func NewMempool(
 	namespace string,
 	registerer prometheus.Registerer,
 	blkTimer BlockTimer,
+	djtxAssetID ids.ID,
+	clk *mockable.Clock,
 ) (Mempool, error) {
 	bytesAvailableMetric := prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
@@ -112,8 +138,21 @@ func NewMempool(
 		return nil, err
 	}
 
-	unissuedDecisionTxs, err := txheap.NewWithMetrics(
-		txheap.NewByAge(),
+	m := &mempool{
+		bytesAvailableMetric: bytesAvailableMetric,
+		bytesAvailable:       maxMempoolSize,
+		djtxAssetID:          djtxAssetID,
+		droppedTxIDs:         linkedhashmap.New[ids.ID, string](),
+		addedTimes:           make(map[ids.ID]time.Time),
+		clk:                  clk,
+		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
+		dropIncoming:         false, // enable tx adding by default
+		blkTimer:             blkTimer,
+	}
+
+	var err error
+	m.unissuedDecisionTxs, err = txheap.NewWithMetrics(
+		txheap.NewByFeeRate(m.feeRate),
 		fmt.Sprintf("%s_decision_txs", namespace),
 		registerer,
 	)
@@ -121,7 +160,7 @@ func NewMempool(
 		return nil, err
 	}
 
-	unissuedStakerTxs, err := txheap.NewWithMetrics(
+	m.unissuedStakerTxs, err = txheap.NewWithMetrics(
 		txheap.NewByStartTime(),
 		fmt.Sprintf("%s_staker_txs", namespace),
 		registerer,
@@ -131,16 +170,20 @@ func NewMempool(
 	}
 
 	bytesAvailableMetric.Set(maxMempoolSize)
-	return &mempool{
-		bytesAvailableMetric: bytesAvailableMetric,
-		bytesAvailable:       maxMempoolSize,
-		unissuedDecisionTxs:  unissuedDecisionTxs,
-		unissuedStakerTxs:    unissuedStakerTxs,
-		droppedTxIDs:         &cache.LRU{Size: droppedTxIDsCacheSize},
-		consumedUTXOs:        set.NewSet[ids.ID](initialConsumedUTXOsSize),
-		dropIncoming:         false, // enable tx adding by default
-		blkTimer:             blkTimer,
-	}, nil
+	return m, nil
+}
+
+// FeeRate returns the amount of [djtxAssetID] that [tx] burns per byte.
+func FeeRate(tx *txs.Tx, djtxAssetID ids.ID) (uint64, error) {
+	burned, err := utxo.Burned(tx.Unsigned, djtxAssetID)
+	if err != nil {
+		return 0, err
+	}
+	txSize := uint64(len(tx.Bytes()))
+	if txSize == 0 {
+		return burned, nil
+	}
+	return burned / txSize, nil
 }
 
 func (m *mempool) EnableAdding() {
@@ -166,20 +209,20 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	if len(txBytes) > targetTxSize {
 		return fmt.Errorf("tx %s size (%d) > target size (%d)", txID, len(txBytes), targetTxSize)
 	}
-	if len(txBytes) > m.bytesAvailable {
-		return fmt.Errorf("%w, tx %s size (%d) exceeds available space (%d)",
-			errMempoolFull,
-			txID,
-			len(txBytes),
-			m.bytesAvailable,
-		)
-	}
 
 	inputs := tx.Unsigned.InputIDs()
 	if m.consumedUTXOs.Overlaps(inputs) {
 		return fmt.Errorf("tx %s conflicts with a transaction in the mempool", txID)
 	}
 
+	feeRate, err := FeeRate(tx, m.djtxAssetID)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate the fee rate of tx %s: %w", txID, err)
+	}
+	if err := m.makeSpace(txID, len(txBytes), feeRate); err != nil {
+		return err
+	}
+
 	if err := tx.Unsigned.Visit(&issuer{
 		m:  m,
 		tx: tx,
@@ -191,7 +234,7 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	m.consumedUTXOs.Union(inputs)
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxIDs.Delete(txID)
 
 	m.blkTimer.ResetBlockTimer()
 	return nil
@@ -277,23 +320,86 @@ func (m *mempool) PeekStakerTx() *txs.Tx {
 
 func (m *mempool) MarkDropped(txID ids.ID, reason string) {
 	m.droppedTxIDs.Put(txID, reason)
+	if m.droppedTxIDs.Len() > droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) (string, bool) {
-	reason, exist := m.droppedTxIDs.Get(txID)
-	if !exist {
-		return "", false
+	return m.droppedTxIDs.Get(txID)
+}
+
+func (m *mempool) GetDroppedTxIDs() []ids.ID {
+	txIDs := make([]ids.ID, m.droppedTxIDs.Len())
+	i := len(txIDs)
+	it := m.droppedTxIDs.NewIterator()
+	for it.Next() {
+		i--
+		txIDs[i] = it.Key()
 	}
-	return reason.(string), true
+	return txIDs
+}
+
+func (m *mempool) GetAddedTime(txID ids.ID) (time.Time, bool) {
+	addedTime, ok := m.addedTimes[txID]
+	return addedTime, ok
+}
+
+// makeSpace evicts the decision txs that pay a lower fee rate than [feeRate],
+// starting with the lowest paying tx, until [size] bytes are available. If
+// evicting these txs doesn't make enough space, no txs are evicted.
+func (m *mempool) makeSpace(txID ids.ID, size int, feeRate uint64) error {
+	bytesAvailable := m.bytesAvailable
+	toEvict := []*txs.Tx(nil)
+	for bytesAvailable < size && m.unissuedDecisionTxs.Len() > 0 {
+		lowestTx := m.unissuedDecisionTxs.Peek()
+		if m.feeRate(lowestTx) >= feeRate {
+			break
+		}
+
+		m.unissuedDecisionTxs.RemoveTop()
+		toEvict = append(toEvict, lowestTx)
+		bytesAvailable += len(lowestTx.Bytes())
+	}
+
+	if bytesAvailable < size {
+		for _, tx := range toEvict {
+			m.unissuedDecisionTxs.Add(tx)
+		}
+		return fmt.Errorf("%w, tx %s size (%d) exceeds available space (%d)",
+			errMempoolFull,
+			txID,
+			size,
+			m.bytesAvailable,
+		)
+	}
+
+	for _, tx := range toEvict {
+		m.deregister(tx)
+		m.MarkDropped(tx.ID(), evictedReason)
+	}
+	return nil
+}
+
+// feeRate returns the fee rate of [tx]. Txs are only added to the mempool if
+// their fee rate can be calculated.
+func (m *mempool) feeRate(tx *txs.Tx) uint64 {
+	feeRate, _ := FeeRate(tx, m.djtxAssetID)
+	return feeRate
 }
 
 func (m *mempool) register(tx *txs.Tx) {
+	m.addedTimes[tx.ID()] = m.clk.Time()
+
 	txBytes := tx.Bytes()
 	m.bytesAvailable -= len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 }
 
 func (m *mempool) deregister(tx *txs.Tx) {
+	delete(m.addedTimes, tx.ID())
+
 	txBytes := tx.Bytes()
 	m.bytesAvailable += len(txBytes)
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
@@ -28,7 +28,11 @@ type noopBlkTimer struct{}
 
 func (*noopBlkTimer) ResetBlockTimer() {}
 
-var preFundedKeys = crypto.BuildTestKeys()
+var (
+	preFundedKeys = crypto.BuildTestKeys()
+
+	testAssetID = ids.ID{'a', 's', 's', 'e', 'r', 't'}
+)
 
 // shows that valid tx is not added to mempool if this would exceed its maximum
 // size
@@ -36,7 +40,7 @@ func TestBlockBuilderMaxMempoolSizeHandling(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, ids.Empty, &mockable.Clock{})
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(1)
@@ -60,7 +64,7 @@ func TestDecisionTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, ids.Empty, &mockable.Clock{})
 	require.NoError(err)
 
 	decisionTxs, err := createTestDecisionTxs(2)
@@ -112,7 +116,7 @@ func TestProposalTxsInMempool(t *testing.T) {
 	require := require.New(t)
 
 	registerer := prometheus.NewRegistry()
-	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, ids.Empty, &mockable.Clock{})
 	require.NoError(err)
 
 	// The proposal txs are ordered by decreasing start time. This means after
@@ -173,44 +177,129 @@ func TestProposalTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestDecisionTxsOrderedByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, testAssetID, &mockable.Clock{})
+	require.NoError(err)
+
+	lowTx, err := createTestDecisionTx(0, 1234+100_000)
+	require.NoError(err)
+	midTx, err := createTestDecisionTx(1, 1234+200_000)
+	require.NoError(err)
+	highTx, err := createTestDecisionTx(2, 1234+300_000)
+	require.NoError(err)
+
+	require.NoError(mpool.Add(midTx))
+	require.NoError(mpool.Add(lowTx))
+	require.NoError(mpool.Add(highTx))
+
+	feeRate, err := FeeRate(highTx, testAssetID)
+	require.NoError(err)
+	require.Equal(300_000/uint64(len(highTx.Bytes())), feeRate)
+
+	// The txs that pay the highest fee rate are included first.
+	require.Equal([]*txs.Tx{highTx, midTx, lowTx}, mpool.PeekTxs(math.MaxInt))
+}
+
+func TestDecisionTxsEvictedByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, testAssetID, &mockable.Clock{})
+	require.NoError(err)
+
+	lowTx, err := createTestDecisionTx(0, 1234+100_000)
+	require.NoError(err)
+	midTx, err := createTestDecisionTx(1, 1234+200_000)
+	require.NoError(err)
+	highTx, err := createTestDecisionTx(2, 1234+300_000)
+	require.NoError(err)
+
+	require.NoError(mpool.Add(lowTx))
+	require.NoError(mpool.Add(midTx))
+
+	// shortcut to simulate a full mempool
+	mpool.(*mempool).bytesAvailable = 0
+
+	// A tx that doesn't pay more than the txs in the mempool can't evict them.
+	sameTx, err := createTestDecisionTx(3, 1234+100_000)
+	require.NoError(err)
+	err = mpool.Add(sameTx)
+	require.ErrorIs(err, errMempoolFull)
+	require.True(mpool.Has(lowTx.ID()))
+	require.True(mpool.Has(midTx.ID()))
+
+	// A tx that pays more evicts the lowest paying tx.
+	require.NoError(mpool.Add(highTx))
+	require.False(mpool.Has(lowTx.ID()))
+	require.True(mpool.Has(midTx.ID()))
+	require.True(mpool.Has(highTx.ID()))
+
+	reason, ok := mpool.GetDropReason(lowTx.ID())
+	require.True(ok)
+	require.Equal(evictedReason, reason)
+
+	// The space of the evicted tx is reused.
+	require.Equal(len(lowTx.Bytes())-len(highTx.Bytes()), mpool.(*mempool).bytesAvailable)
+}
+
+func TestDroppedTxIDs(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, ids.Empty, &mockable.Clock{})
+	require.NoError(err)
+
+	droppedTxIDs := make([]ids.ID, droppedTxIDsCacheSize+1)
+	for i := range droppedTxIDs {
+		droppedTxIDs[i] = ids.GenerateTestID()
+		mpool.MarkDropped(droppedTxIDs[i], "dropped for testing")
+	}
+
+	// Only the most recently dropped txs are tracked, newest first.
+	expectedTxIDs := make([]ids.ID, 0, droppedTxIDsCacheSize)
+	for i := len(droppedTxIDs) - 1; i > 0; i-- {
+		expectedTxIDs = append(expectedTxIDs, droppedTxIDs[i])
+	}
+	require.Equal(expectedTxIDs, mpool.GetDroppedTxIDs())
+
+	_, ok := mpool.GetDropReason(droppedTxIDs[0])
+	require.False(ok)
+}
+
+func TestAddedTime(t *testing.T) {
+	require := require.New(t)
+
+	clk := &mockable.Clock{}
+	clk.Set(time.Unix(1000, 0))
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{}, ids.Empty, clk)
+	require.NoError(err)
+
+	decisionTxs, err := createTestDecisionTxs(1)
+	require.NoError(err)
+	tx := decisionTxs[0]
+
+	_, ok := mpool.GetAddedTime(tx.ID())
+	require.False(ok)
+
+	require.NoError(mpool.Add(tx))
+	addedTime, ok := mpool.GetAddedTime(tx.ID())
+	require.True(ok)
+	require.Equal(clk.Time(), addedTime)
+
+	mpool.Remove([]*txs.Tx{tx})
+	_, ok = mpool.GetAddedTime(tx.ID())
+	require.False(ok)
+}
+
 func createTestDecisionTxs(count int) ([]*txs.Tx, error) {
 	decisionTxs := make([]*txs.Tx, 0, count)
 	for i := uint32(0); i < uint32(count); i++ {
-		utx := &txs.CreateChainTx{
-			BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
-				NetworkID:    10,
-				BlockchainID: ids.Empty.Prefix(uint64(i)),
-				Ins: []*djtx.TransferableInput{{
-					UTXOID: djtx.UTXOID{
-						TxID:        ids.ID{'t', 'x', 'I', 'D'},
-						OutputIndex: i,
-					},
-					Asset: djtx.Asset{ID: ids.ID{'a', 's', 's', 'e', 'r', 't'}},
-					In: &secp256k1fx.TransferInput{
-						Amt:   uint64(5678),
-						Input: secp256k1fx.Input{SigIndices: []uint32{i}},
-					},
-				}},
-				Outs: []*djtx.TransferableOutput{{
-					Asset: djtx.Asset{ID: ids.ID{'a', 's', 's', 'e', 'r', 't'}},
-					Out: &secp256k1fx.TransferOutput{
-						Amt: uint64(1234),
-						OutputOwners: secp256k1fx.OutputOwners{
-							Threshold: 1,
-							Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
-						},
-					},
-				}},
-			}},
-			SubnetID:    ids.GenerateTestID(),
-			ChainName:   "chainName",
-			VMID:        ids.GenerateTestID(),
-			FxIDs:       []ids.ID{ids.GenerateTestID()},
-			GenesisData: []byte{'g', 'e', 'n', 'D', 'a', 't', 'a'},
-			SubnetAuth:  &secp256k1fx.Input{SigIndices: []uint32{1}},
-		}
-
-		tx, err := txs.NewSigned(utx, txs.Codec, nil)
+		tx, err := createTestDecisionTx(i, 5678)
 		if err != nil {
 			return nil, err
 		}
@@ -219,6 +308,46 @@ func createTestDecisionTxs(count int) ([]*txs.Tx, error) {
 	return decisionTxs, nil
 }
 
+// createTestDecisionTx returns a decision tx that consumes [consumed] and
+// produces 1234 of [testAssetID].
+func createTestDecisionTx(i uint32, consumed uint64) (*txs.Tx, error) {
+	utx := &txs.CreateChainTx{
+		BaseTx: txs.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    10,
+			BlockchainID: ids.Empty.Prefix(uint64(i)),
+			Ins: []*djtx.TransferableInput{{
+				UTXOID: djtx.UTXOID{
+					TxID:        ids.ID{'t', 'x', 'I', 'D'},
+					OutputIndex: i,
+				},
+				Asset: djtx.Asset{ID: testAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt:   consumed,
+					Input: secp256k1fx.Input{SigIndices: []uint32{i}},
+				},
+			}},
+			Outs: []*djtx.TransferableOutput{{
+				Asset: djtx.Asset{ID: testAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: uint64(1234),
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+					},
+				},
+			}},
+		}},
+		SubnetID:    ids.GenerateTestID(),
+		ChainName:   "chainName",
+		VMID:        ids.GenerateTestID(),
+		FxIDs:       []ids.ID{ids.GenerateTestID()},
+		GenesisData: []byte{'g', 'e', 'n', 'D', 'a', 't', 'a'},
+		SubnetAuth:  &secp256k1fx.Input{SigIndices: []uint32{1}},
+	}
+
+	return txs.NewSigned(utx, txs.Codec, nil)
+}
+
 // Proposal txs are sorted by decreasing start time
 func createTestProposalTxs(count int) ([]*txs.Tx, error) {
 	var clk mockable.Clock
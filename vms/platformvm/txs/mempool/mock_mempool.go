@@ -9,6 +9,7 @@ package mempool
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/lasthyphen/dijetsnodego/ids"
 	txs "github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
@@ -105,6 +106,35 @@ func (mr *MockMempoolMockRecorder) GetDropReason(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDropReason", reflect.TypeOf((*MockMempool)(nil).GetDropReason), arg0)
 }
 
+// GetAddedTime mocks base method.
+func (m *MockMempool) GetAddedTime(arg0 ids.ID) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddedTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetAddedTime indicates an expected call of GetAddedTime.
+func (mr *MockMempoolMockRecorder) GetAddedTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddedTime", reflect.TypeOf((*MockMempool)(nil).GetAddedTime), arg0)
+}
+
+// GetDroppedTxIDs mocks base method.
+func (m *MockMempool) GetDroppedTxIDs() []ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDroppedTxIDs")
+	ret0, _ := ret[0].([]ids.ID)
+	return ret0
+}
+
+// GetDroppedTxIDs indicates an expected call of GetDroppedTxIDs.
+func (mr *MockMempoolMockRecorder) GetDroppedTxIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDroppedTxIDs", reflect.TypeOf((*MockMempool)(nil).GetDroppedTxIDs))
+}
+
 // Has mocks base method.
 func (m *MockMempool) Has(arg0 ids.ID) bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"golang.org/x/exp/slices"
+
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ Heap = (*byFeeRate)(nil)
+
+type byFeeRate struct {
+	txHeap
+
+	feeRate func(*txs.Tx) uint64
+}
+
+// NewByFeeRate returns a heap whose top is the tx that pays the lowest
+// [feeRate]. Of the txs that pay the same fee rate, the most recently added tx
+// is at the top. List returns the txs in the reverse order, so that the txs
+// that pay the highest fee rate are listed first.
+func NewByFeeRate(feeRate func(*txs.Tx) uint64) Heap {
+	h := &byFeeRate{
+		feeRate: feeRate,
+	}
+	h.initialize(h)
+	return h
+}
+
+func (h *byFeeRate) List() []*txs.Tx {
+	htxs := slices.Clone(h.txs)
+	slices.SortFunc(htxs, func(a, b *heapTx) int {
+		switch {
+		case h.lessByFeeRate(b, a):
+			return -1
+		case h.lessByFeeRate(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	res := make([]*txs.Tx, len(htxs))
+	for i, htx := range htxs {
+		res[i] = htx.tx
+	}
+	return res
+}
+
+func (h *byFeeRate) Less(i, j int) bool {
+	return h.lessByFeeRate(h.txs[i], h.txs[j])
+}
+
+func (h *byFeeRate) Push(x interface{}) {
+	numTxs := len(h.txs)
+	h.txHeap.Push(x)
+	if len(h.txs) > numTxs {
+		htx := h.txs[numTxs]
+		htx.feeRate = h.feeRate(htx.tx)
+	}
+}
+
+func (*byFeeRate) lessByFeeRate(i, j *heapTx) bool {
+	if i.feeRate != j.feeRate {
+		return i.feeRate < j.feeRate
+	}
+	return i.age > j.age
+}
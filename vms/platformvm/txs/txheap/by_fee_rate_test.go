@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txheap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+func TestByFeeRate(t *testing.T) {
+	require := require.New(t)
+
+	feeRates := make(map[ids.ID]uint64)
+	txHeap := NewByFeeRate(func(tx *txs.Tx) uint64 {
+		return feeRates[tx.ID()]
+	})
+
+	newTx := func(feeRate uint64) *txs.Tx {
+		utx := &txs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{
+				Addrs: []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		}
+		tx := &txs.Tx{Unsigned: utx}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		feeRates[tx.ID()] = feeRate
+		return tx
+	}
+
+	tx0 := newTx(2)
+	tx1 := newTx(1)
+	tx2 := newTx(3)
+	tx3 := newTx(2)
+
+	txHeap.Add(tx0)
+	txHeap.Add(tx1)
+	txHeap.Add(tx2)
+	txHeap.Add(tx3)
+
+	// The txs that pay the most are listed first. Ties are listed by age.
+	require.Equal([]*txs.Tx{tx2, tx0, tx3, tx1}, txHeap.List())
+
+	// The txs that pay the least are removed first. Ties are removed starting
+	// with the most recently added tx.
+	require.Equal(tx1, txHeap.RemoveTop())
+	require.Equal(tx3, txHeap.RemoveTop())
+	require.Equal(tx0, txHeap.Peek())
+
+	require.Equal(tx0, txHeap.Remove(tx0.ID()))
+	require.Equal(tx2, txHeap.RemoveTop())
+	require.Zero(txHeap.Len())
+}
@@ -23,9 +23,10 @@ type Heap interface {
 }
 
 type heapTx struct {
-	tx    *txs.Tx
-	index int
-	age   int
+	tx      *txs.Tx
+	index   int
+	age     int
+	feeRate uint64
 }
 
 type txHeap struct {
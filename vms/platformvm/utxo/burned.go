@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utxo
+
+import (
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = (*burnedCalculator)(nil)
+
+// Burned returns the amount of [assetID] that is consumed by [tx] but not
+// produced by it. This includes the required fee of [tx] as well as any tip
+// that was paid on top of it.
+//
+// Precondition: [tx] has already been syntactically verified.
+func Burned(tx txs.UnsignedTx, assetID ids.ID) (uint64, error) {
+	c := &burnedCalculator{
+		assetID: assetID,
+	}
+	err := tx.Visit(c)
+	return c.burned, err
+}
+
+type burnedCalculator struct {
+	assetID ids.ID
+	burned  uint64
+}
+
+func (*burnedCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+func (*burnedCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return nil
+}
+
+func (c *burnedCalculator) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return c.calculate(tx.Ins, tx.Outs, tx.StakeOuts)
+}
+
+func (c *burnedCalculator) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return c.calculate(tx.Ins, tx.Outs)
+}
+
+func (c *burnedCalculator) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return c.calculate(tx.Ins, tx.Outs, tx.StakeOuts)
+}
+
+func (c *burnedCalculator) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return c.calculate(tx.Ins, tx.Outs)
+}
+
+func (c *burnedCalculator) CreateChainTx(tx *txs.CreateChainTx) error {
+	return c.calculate(tx.Ins, tx.Outs)
+}
+
+func (c *burnedCalculator) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return c.calculate(tx.Ins, tx.Outs)
+}
+
+func (c *burnedCalculator) ImportTx(tx *txs.ImportTx) error {
+	ins := make([]*djtx.TransferableInput, 0, len(tx.Ins)+len(tx.ImportedInputs))
+	ins = append(ins, tx.Ins...)
+	ins = append(ins, tx.ImportedInputs...)
+	return c.calculate(ins, tx.Outs)
+}
+
+func (c *burnedCalculator) ExportTx(tx *txs.ExportTx) error {
+	return c.calculate(tx.Ins, tx.Outs, tx.ExportedOutputs)
+}
+
+func (c *burnedCalculator) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return c.calculate(tx.Ins, tx.Outs)
+}
+
+func (c *burnedCalculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return c.calculate(tx.Ins, tx.Outs, tx.StakeOuts)
+}
+
+func (c *burnedCalculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return c.calculate(tx.Ins, tx.Outs, tx.StakeOuts)
+}
+
+func (c *burnedCalculator) calculate(
+	ins []*djtx.TransferableInput,
+	outs ...[]*djtx.TransferableOutput,
+) error {
+	consumed := uint64(0)
+	for _, in := range ins {
+		if in.AssetID() != c.assetID {
+			continue
+		}
+		var err error
+		consumed, err = math.Add64(consumed, in.In.Amount())
+		if err != nil {
+			return err
+		}
+	}
+
+	produced := uint64(0)
+	for _, outs := range outs {
+		for _, out := range outs {
+			if out.AssetID() != c.assetID {
+				continue
+			}
+			var err error
+			produced, err = math.Add64(produced, out.Out.Amount())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if produced > consumed {
+		return fmt.Errorf(
+			"tx produces more %q (%d) than it consumes (%d)",
+			c.assetID,
+			produced,
+			consumed,
+		)
+	}
+	c.burned = consumed - produced
+	return nil
+}
@@ -106,7 +106,9 @@ type Verifier interface {
 	// [creds] are the credentials of [tx], which allow [ins] to be spent.
 	// [unlockedProduced] is the map of assets that were produced and their
 	// amounts.
-	// The [ins] must have at least [unlockedProduced] than the [outs].
+	// The [ins] must have at least [unlockedProduced] than the [outs]. Any
+	// amount consumed in excess of this is burned as a tip, which prioritizes
+	// [tx] in the mempool.
 	//
 	// Precondition: [tx] has already been syntactically verified.
 	//
@@ -126,7 +128,9 @@ type Verifier interface {
 	// [creds] are the credentials of [tx], which allow [ins] to be spent.
 	// [unlockedProduced] is the map of assets that were produced and their
 	// amounts.
-	// The [ins] must have at least [unlockedProduced] more than the [outs].
+	// The [ins] must have at least [unlockedProduced] more than the [outs]. Any
+	// amount consumed in excess of this is burned as a tip, which prioritizes
+	// [tx] in the mempool.
 	//
 	// Precondition: [tx] has already been syntactically verified.
 	//
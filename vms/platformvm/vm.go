@@ -32,6 +32,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/json"
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
 	"github.com/lasthyphen/dijetsnodego/utils/timer/mockable"
 	"github.com/lasthyphen/dijetsnodego/utils/window"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
@@ -39,6 +40,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/api"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/blocks"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/config"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/fx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/metrics"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm/reward"
@@ -65,10 +67,11 @@ var (
 	_ secp256k1fx.VM             = (*VM)(nil)
 	_ validators.State           = (*VM)(nil)
 	_ validators.SubnetConnector = (*VM)(nil)
+	_ config.SubnetTracker       = (*VM)(nil)
 
-	errWrongCacheType      = errors.New("unexpectedly cached type")
-	errMissingValidatorSet = errors.New("missing validator set")
-	errMissingValidator    = errors.New("missing validator")
+	errMissingValidatorSet   = errors.New("missing validator set")
+	errMissingValidator      = errors.New("missing validator")
+	errDuplicateValidatorSet = errors.New("duplicate validator set")
 )
 
 type VM struct {
@@ -98,7 +101,7 @@ type VM struct {
 	// Maps caches for each subnet that is currently whitelisted.
 	// Key: Subnet ID
 	// Value: cache mapping height -> validator set map
-	validatorSetCaches map[ids.ID]cache.Cacher
+	validatorSetCaches map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]
 
 	// sliding window of blocks that were recently accepted
 	recentlyAccepted window.Window[ids.ID]
@@ -144,7 +147,7 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher)
+	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput])
 	vm.recentlyAccepted = window.New[ids.ID](
 		window.Config{
 			Clock:   &vm.clock,
@@ -171,6 +174,7 @@ func (vm *VM) Initialize(
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.state, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
+	vm.SubnetTracker.SetTracker(&chainCtx.Lock, vm)
 
 	vm.txBuilder = txbuilder.New(
 		vm.ctx,
@@ -195,7 +199,7 @@ func (vm *VM) Initialize(
 
 	// Note: There is a circular dependency between the mempool and block
 	//       builder which is broken by passing in the vm.
-	mempool, err := mempool.NewMempool("mempool", registerer, vm)
+	mempool, err := mempool.NewMempool("mempool", registerer, vm, vm.ctx.DJTXAssetID, &vm.clock)
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
@@ -273,6 +277,72 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 	return nil
 }
 
+// TrackSubnets starts tracking the subnets of [subnetIDs] that aren't tracked
+// yet. The validator sets of the new subnets are added to the node's validator
+// manager, their uptimes are tracked once the VM is bootstrapped and their
+// chains are created.
+func (vm *VM) TrackSubnets(subnetIDs []ids.ID) error {
+	newSubnetIDs := make([]ids.ID, 0, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		if subnetID != constants.PrimaryNetworkID && !vm.WhitelistedSubnets.Contains(subnetID) {
+			newSubnetIDs = append(newSubnetIDs, subnetID)
+		}
+	}
+	if len(newSubnetIDs) == 0 {
+		return nil
+	}
+
+	// The validator sets are built before any subnet is tracked, so that no
+	// subnet is tracked if one of them can't be built.
+	subnetValidators := make([]validators.Set, len(newSubnetIDs))
+	for i, subnetID := range newSubnetIDs {
+		if _, ok := vm.Validators.Get(subnetID); ok {
+			return fmt.Errorf("%w: %s", errDuplicateValidatorSet, subnetID)
+		}
+		vdrs, err := vm.state.ValidatorSet(subnetID)
+		if err != nil {
+			return err
+		}
+		subnetValidators[i] = vdrs
+	}
+
+	// The whitelisted subnets are shared with the node, so they are replaced
+	// rather than modified.
+	whitelistedSubnets := set.NewSet[ids.ID](vm.WhitelistedSubnets.Len() + len(newSubnetIDs))
+	whitelistedSubnets.Union(vm.WhitelistedSubnets)
+	whitelistedSubnets.Add(newSubnetIDs...)
+	vm.WhitelistedSubnets = whitelistedSubnets
+	for i, subnetID := range newSubnetIDs {
+		vm.Validators.Add(subnetID, subnetValidators[i])
+	}
+
+	if vm.bootstrapped.GetValue() {
+		for _, subnetID := range newSubnetIDs {
+			vdrIDs, exists := vm.getValidatorIDs(subnetID)
+			if !exists {
+				return errMissingValidatorSet
+			}
+			if err := vm.uptimeManager.StartTracking(vdrIDs, subnetID); err != nil {
+				return err
+			}
+		}
+		if err := vm.state.Commit(); err != nil {
+			return err
+		}
+	}
+
+	// Without staking, the chains of every subnet are already created.
+	if !vm.StakingEnabled {
+		return nil
+	}
+	for _, subnetID := range newSubnetIDs {
+		if err := vm.createSubnet(subnetID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
 	vm.bootstrapped.SetValue(false)
@@ -477,18 +547,14 @@ func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
 func (vm *VM) GetValidatorSet(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
 	validatorSetsCache, exists := vm.validatorSetCaches[subnetID]
 	if !exists {
-		validatorSetsCache = &cache.LRU{Size: validatorSetsCacheSize}
+		validatorSetsCache = cache.NewLRU[uint64, map[ids.NodeID]*validators.GetValidatorOutput](validatorSetsCacheSize)
 		// Only cache whitelisted subnets
 		if subnetID == constants.PrimaryNetworkID || vm.WhitelistedSubnets.Contains(subnetID) {
 			vm.validatorSetCaches[subnetID] = validatorSetsCache
 		}
 	}
 
-	if validatorSetIntf, ok := validatorSetsCache.Get(height); ok {
-		validatorSet, ok := validatorSetIntf.(map[ids.NodeID]*validators.GetValidatorOutput)
-		if !ok {
-			return nil, errWrongCacheType
-		}
+	if validatorSet, ok := validatorSetsCache.Get(height); ok {
 		vm.metrics.IncValidatorSetsCached()
 		return validatorSet, nil
 	}
@@ -504,32 +570,12 @@ func (vm *VM) GetValidatorSet(ctx context.Context, height uint64, subnetID ids.I
 	// get the start time to track metrics
 	startTime := vm.Clock().Time()
 
-	currentSubnetValidators, ok := vm.Validators.Get(subnetID)
-	if !ok {
-		return nil, errMissingValidatorSet
-	}
-	currentPrimaryNetworkValidators, ok := vm.Validators.Get(constants.PrimaryNetworkID)
-	if !ok {
-		// This should never happen
-		return nil, errMissingValidatorSet
-	}
-
-	currentSubnetValidatorList := currentSubnetValidators.List()
-	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(currentSubnetValidatorList))
-	for _, vdr := range currentSubnetValidatorList {
-		primaryVdr, ok := currentPrimaryNetworkValidators.Get(vdr.NodeID)
-		if !ok {
-			// This should never happen
-			return nil, fmt.Errorf("%w: %s", errMissingValidator, vdr.NodeID)
-		}
-		vdrSet[vdr.NodeID] = &validators.GetValidatorOutput{
-			NodeID:    vdr.NodeID,
-			PublicKey: primaryVdr.PublicKey,
-			Weight:    vdr.Weight,
-		}
+	vdrSet, startHeight, err := vm.getBaseValidatorSet(height, lastAcceptedHeight, subnetID)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := lastAcceptedHeight; i > height; i-- {
+	for i := startHeight; i > height; i-- {
 		weightDiffs, err := vm.state.GetValidatorWeightDiffs(i, subnetID)
 		if err != nil {
 			return nil, err
@@ -598,9 +644,65 @@ func (vm *VM) GetValidatorSet(ctx context.Context, height uint64, subnetID ids.I
 	vm.metrics.IncValidatorSetsCreated()
 	vm.metrics.AddValidatorSetsDuration(endTime.Sub(startTime))
 	vm.metrics.AddValidatorSetsHeightDiff(lastAcceptedHeight - height)
+	vm.metrics.AddValidatorSetsLookupDepth(startHeight - height)
 	return vdrSet, nil
 }
 
+// getBaseValidatorSet returns the validator set of [subnetID] at the first
+// height at or after [height] that it can be read at, along with that height.
+// The validator set at [height] can be computed by applying the diffs of the
+// blocks in between to the returned validator set.
+func (vm *VM) getBaseValidatorSet(
+	height uint64,
+	lastAcceptedHeight uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, uint64, error) {
+	checkpointHeight := height
+	if remainder := height % state.ValidatorSetCheckpointInterval; remainder != 0 {
+		checkpointHeight += state.ValidatorSetCheckpointInterval - remainder
+	}
+	if checkpointHeight < lastAcceptedHeight {
+		vdrSet, err := vm.state.GetValidatorSetCheckpoint(checkpointHeight, subnetID)
+		switch err {
+		case nil:
+			vm.metrics.IncValidatorSetsCheckpointed()
+			return vdrSet, checkpointHeight, nil
+		case database.ErrNotFound:
+			// Checkpoints aren't taken of blocks accepted before the subnet
+			// was whitelisted, or before checkpoints were introduced. The
+			// current validator set is used instead.
+		default:
+			return nil, 0, err
+		}
+	}
+
+	currentSubnetValidators, ok := vm.Validators.Get(subnetID)
+	if !ok {
+		return nil, 0, errMissingValidatorSet
+	}
+	currentPrimaryNetworkValidators, ok := vm.Validators.Get(constants.PrimaryNetworkID)
+	if !ok {
+		// This should never happen
+		return nil, 0, errMissingValidatorSet
+	}
+
+	currentSubnetValidatorList := currentSubnetValidators.List()
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(currentSubnetValidatorList))
+	for _, vdr := range currentSubnetValidatorList {
+		primaryVdr, ok := currentPrimaryNetworkValidators.Get(vdr.NodeID)
+		if !ok {
+			// This should never happen
+			return nil, 0, fmt.Errorf("%w: %s", errMissingValidator, vdr.NodeID)
+		}
+		vdrSet[vdr.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.NodeID,
+			PublicKey: primaryVdr.PublicKey,
+			Weight:    vdr.Weight,
+		}
+	}
+	return vdrSet, lastAcceptedHeight, nil
+}
+
 // GetMinimumHeight returns the height of the most recent block beyond the
 // horizon of our recentlyAccepted window.
 //
@@ -362,6 +362,7 @@ func TestUnverifiedParentPanicRegression(t *testing.T) {
 			Chains:                 chains.MockManager{},
 			Validators:             vdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			MinStakeDuration:       defaultMinStakingDuration,
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
@@ -38,6 +38,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/snow/networking/timeout"
 	"github.com/lasthyphen/dijetsnodego/snow/uptime"
 	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/compression"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
@@ -330,6 +331,7 @@ func defaultVM() (*VM, database.Database, *mutableSharedMemory) {
 		Config: config.Config{
 			Chains:                 chains.MockManager{},
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			Validators:             vdrs,
 			TxFee:                  defaultTxFee,
 			CreateSubnetTxFee:      100 * defaultTxFee,
@@ -434,6 +436,7 @@ func GenesisVMWithArgs(t *testing.T, args *api.BuildGenesisArgs) ([]byte, chan c
 			Chains:                 chains.MockManager{},
 			Validators:             vdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			TxFee:                  defaultTxFee,
 			MinValidatorStake:      defaultMinValidatorStake,
 			MaxValidatorStake:      defaultMaxValidatorStake,
@@ -1344,6 +1347,69 @@ func TestCreateSubnet(t *testing.T) {
 	require.ErrorIs(err, database.ErrNotFound)
 }
 
+// Ensure that subnets tracked while the node is running have their validator
+// sets tracked
+func TestTrackSubnets(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	subnetID := testSubnet1.ID()
+	nodeID := ids.NodeID(keys[0].PublicKey().Address())
+
+	startTime := vm.clock.Time().Add(txexecutor.SyncBound).Add(1 * time.Second)
+	endTime := startTime.Add(defaultMinStakingDuration)
+	tx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		subnetID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	require.NoError(err)
+	require.NoError(vm.Builder.AddUnverifiedTx(tx))
+
+	// Add the validator to the pending validator set
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+
+	// Move the validator to the current validator set
+	vm.clock.Set(startTime)
+	blk, err = vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), vm.manager.LastAccepted()))
+
+	// The subnet isn't tracked, so its validator set isn't either.
+	_, ok := vm.Validators.Get(subnetID)
+	require.False(ok)
+
+	require.NoError(vm.TrackSubnets([]ids.ID{subnetID}))
+	require.True(vm.WhitelistedSubnets.Contains(subnetID))
+	require.True(validators.Contains(vm.Validators, subnetID, nodeID))
+
+	// Tracking a subnet again doesn't change anything.
+	require.NoError(vm.TrackSubnets([]ids.ID{subnetID}))
+
+	// The validator set of the subnet is now kept up to date.
+	vm.clock.Set(endTime)
+	blk, err = vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+	require.False(validators.Contains(vm.Validators, subnetID, nodeID))
+}
+
 // test asset import
 func TestAtomicImport(t *testing.T) {
 	vm, baseDB, mutableSharedMemory := defaultVM()
@@ -1527,6 +1593,7 @@ func TestRestartFullyAccepted(t *testing.T) {
 			Chains:                 chains.MockManager{},
 			Validators:             firstVdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			MinStakeDuration:       defaultMinStakingDuration,
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
@@ -1618,6 +1685,7 @@ func TestRestartFullyAccepted(t *testing.T) {
 			Chains:                 chains.MockManager{},
 			Validators:             secondVdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			MinStakeDuration:       defaultMinStakingDuration,
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
@@ -1677,6 +1745,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 			Chains:                 chains.MockManager{},
 			Validators:             vdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			MinStakeDuration:       defaultMinStakingDuration,
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
@@ -1778,7 +1847,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	chainRouter := &router.ChainRouter{}
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	mc, err := message.NewCreator(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 	require.NoError(err)
 
 	err = chainRouter.Initialize(
@@ -1997,6 +2066,7 @@ func TestUnverifiedParent(t *testing.T) {
 			Chains:                 chains.MockManager{},
 			Validators:             vdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			MinStakeDuration:       defaultMinStakingDuration,
 			MaxStakeDuration:       defaultMaxStakingDuration,
 			RewardConfig:           defaultRewardConfig,
@@ -2166,6 +2236,7 @@ func TestUptimeDisallowedWithRestart(t *testing.T) {
 			RewardConfig:           defaultRewardConfig,
 			Validators:             firstVdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			BanffTime:              banffForkTime,
 		},
 	}}
@@ -2210,6 +2281,7 @@ func TestUptimeDisallowedWithRestart(t *testing.T) {
 			UptimePercentage:       .21,
 			Validators:             secondVdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			BanffTime:              banffForkTime,
 		},
 	}}
@@ -2349,6 +2421,7 @@ func TestUptimeDisallowedAfterNeverConnecting(t *testing.T) {
 			RewardConfig:           defaultRewardConfig,
 			Validators:             vdrs,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			BanffTime:              banffForkTime,
 		},
 	}}
@@ -2466,6 +2539,7 @@ func TestVM_GetValidatorSet(t *testing.T) {
 			RewardConfig:           defaultRewardConfig,
 			Validators:             vdrManager,
 			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			SubnetTracker:          config.NewLockedSubnetTracker(),
 			BanffTime:              mockable.MaxTime,
 		},
 	}}
@@ -2515,6 +2589,11 @@ func TestVM_GetValidatorSet(t *testing.T) {
 		// Validator sets at tip
 		currentPrimaryNetworkValidators []*validators.Validator
 		currentSubnetValidators         []*validators.Validator
+		// Whether the checkpoint after [height] is looked up, and what is
+		// returned when it is
+		checkpointLookedUp bool
+		checkpoint         map[ids.NodeID]*validators.GetValidatorOutput
+		checkpointErr      error
 		// Diff at tip, block before tip, etc.
 		// This must have [height] - [lastAcceptedHeight] elements
 		weightDiffs []map[ids.NodeID]*state.ValidatorWeightDiff
@@ -2782,6 +2861,105 @@ func TestVM_GetValidatorSet(t *testing.T) {
 		},
 	}
 
+	checkpointTests := []test{
+		{
+			name:               "before checkpoint",
+			height:             state.ValidatorSetCheckpointInterval - 1,
+			lastAcceptedHeight: 3 * state.ValidatorSetCheckpointInterval,
+			checkpointLookedUp: true,
+			checkpoint: map[ids.NodeID]*validators.GetValidatorOutput{
+				vdrs[0].NodeID: {
+					NodeID:    vdrs[0].NodeID,
+					PublicKey: vdrs[0].PublicKey,
+					Weight:    vdrs[0].Weight,
+				},
+			},
+			weightDiffs: []map[ids.NodeID]*state.ValidatorWeightDiff{
+				{
+					// At the checkpoint block vdrs[1] left
+					vdrs[1].NodeID: {
+						Decrease: true,
+						Amount:   vdrs[1].Weight,
+					},
+				},
+			},
+			pkDiffs: []map[ids.NodeID]*bls.PublicKey{
+				{
+					vdrs[1].NodeID: vdrs[1].PublicKey,
+				},
+			},
+			expectedVdrSet: map[ids.NodeID]*validators.GetValidatorOutput{
+				vdrs[0].NodeID: {
+					NodeID:    vdrs[0].NodeID,
+					PublicKey: vdrs[0].PublicKey,
+					Weight:    vdrs[0].Weight,
+				},
+				vdrs[1].NodeID: {
+					NodeID:    vdrs[1].NodeID,
+					PublicKey: vdrs[1].PublicKey,
+					Weight:    vdrs[1].Weight,
+				},
+			},
+		},
+		{
+			name:               "at checkpoint",
+			height:             state.ValidatorSetCheckpointInterval,
+			lastAcceptedHeight: 3 * state.ValidatorSetCheckpointInterval,
+			checkpointLookedUp: true,
+			checkpoint: map[ids.NodeID]*validators.GetValidatorOutput{
+				vdrs[0].NodeID: {
+					NodeID:    vdrs[0].NodeID,
+					PublicKey: vdrs[0].PublicKey,
+					Weight:    vdrs[0].Weight,
+				},
+			},
+			expectedVdrSet: map[ids.NodeID]*validators.GetValidatorOutput{
+				vdrs[0].NodeID: {
+					NodeID:    vdrs[0].NodeID,
+					PublicKey: vdrs[0].PublicKey,
+					Weight:    vdrs[0].Weight,
+				},
+			},
+		},
+		{
+			name:               "missing checkpoint",
+			height:             state.ValidatorSetCheckpointInterval - 2,
+			lastAcceptedHeight: state.ValidatorSetCheckpointInterval + 1,
+			currentPrimaryNetworkValidators: []*validators.Validator{
+				copyPrimaryValidator(vdrs[0]),
+			},
+			currentSubnetValidators: []*validators.Validator{
+				copySubnetValidator(vdrs[0]),
+			},
+			checkpointLookedUp: true,
+			checkpointErr:      database.ErrNotFound,
+			weightDiffs: []map[ids.NodeID]*state.ValidatorWeightDiff{
+				{},
+				{},
+				{
+					// 2 blocks before tip vdrs[0] gained weight
+					vdrs[0].NodeID: {
+						Decrease: false,
+						Amount:   1,
+					},
+				},
+			},
+			pkDiffs: []map[ids.NodeID]*bls.PublicKey{
+				{},
+				{},
+				{},
+			},
+			expectedVdrSet: map[ids.NodeID]*validators.GetValidatorOutput{
+				vdrs[0].NodeID: {
+					NodeID:    vdrs[0].NodeID,
+					PublicKey: vdrs[0].PublicKey,
+					Weight:    vdrs[0].Weight - 1,
+				},
+			},
+		},
+	}
+	tests = append(tests, checkpointTests...)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			require := require.New(t)
@@ -2810,6 +2988,13 @@ func TestVM_GetValidatorSet(t *testing.T) {
 			mockState := state.NewMockState(ctrl)
 			vm.state = mockState
 
+			// Tell state what checkpoint to report
+			if tt.checkpointLookedUp {
+				checkpointHeight := tt.height + state.ValidatorSetCheckpointInterval - 1
+				checkpointHeight -= checkpointHeight % state.ValidatorSetCheckpointInterval
+				mockState.EXPECT().GetValidatorSetCheckpoint(checkpointHeight, tt.subnetID).Return(tt.checkpoint, tt.checkpointErr)
+			}
+
 			// Tell state what diffs to report
 			for _, weightDiff := range tt.weightDiffs {
 				mockState.EXPECT().GetValidatorWeightDiffs(gomock.Any(), gomock.Any()).Return(weightDiff, nil)
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+var errInsufficientWeight = errors.New("insufficient weight of signatures")
+
+// SignatureGetter fetches the signature of a node over a Warp message.
+type SignatureGetter interface {
+	// GetSignature returns the BLS signature of [nodeID] over [msg]. The
+	// signature isn't verified.
+	GetSignature(ctx context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error)
+}
+
+// Aggregator collects the signatures of the validators of a subnet over Warp
+// messages, and aggregates them into a signed Warp message.
+type Aggregator struct {
+	log         logging.Logger
+	subnetID    ids.ID
+	pChainState validators.State
+	client      SignatureGetter
+}
+
+// New returns an Aggregator that collects the signatures of the validators of
+// [subnetID] with [client].
+func New(
+	log logging.Logger,
+	subnetID ids.ID,
+	pChainState validators.State,
+	client SignatureGetter,
+) *Aggregator {
+	return &Aggregator{
+		log:         log,
+		subnetID:    subnetID,
+		pChainState: pChainState,
+		client:      client,
+	}
+}
+
+type signatureResult struct {
+	index     int
+	signature *bls.Signature
+}
+
+// AggregateSignatures requests signatures over [msg] from the validators of
+// the subnet at [pChainHeight] until at least [quorumNum]/[quorumDen] of the
+// weight of the subnet signed it, and returns the signed message.
+//
+// The requests are abandoned when [ctx] is done, in which case an error is
+// returned if the quorum wasn't reached.
+func (a *Aggregator) AggregateSignatures(
+	ctx context.Context,
+	msg *warp.UnsignedMessage,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, error) {
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, a.pChainState, pChainHeight, a.subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop the outstanding requests once the quorum is reached.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each validator is asked through every node that registered its key, and
+	// at most one result is reported per node.
+	numRequests := 0
+	for _, vdr := range vdrs {
+		numRequests += len(vdr.NodeIDs)
+	}
+	results := make(chan signatureResult, numRequests)
+	for i, vdr := range vdrs {
+		for _, nodeID := range vdr.NodeIDs {
+			go a.getSignature(ctx, i, vdr, nodeID, msg, results)
+		}
+	}
+
+	var (
+		signers    = set.NewBits()
+		signatures = make([]*bls.Signature, 0, len(vdrs))
+		sigWeight  uint64
+	)
+	for received := 0; received < numRequests; received++ {
+		var result signatureResult
+		select {
+		case result = <-results:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %d/%d signed before %v", errInsufficientWeight, sigWeight, totalWeight, ctx.Err())
+		}
+		if result.signature == nil || signers.Contains(result.index) {
+			continue
+		}
+
+		signers.Add(result.index)
+		signatures = append(signatures, result.signature)
+		sigWeight += vdrs[result.index].Weight // Can't overflow as it's bounded by the total weight
+
+		if warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen) == nil {
+			return a.newMessage(msg, signers, signatures)
+		}
+	}
+	return nil, fmt.Errorf("%w: %d/%d signed", errInsufficientWeight, sigWeight, totalWeight)
+}
+
+// getSignature reports the signature of [vdr] over [msg] fetched from
+// [nodeID]. A nil signature is reported if it couldn't be fetched or is
+// invalid.
+func (a *Aggregator) getSignature(
+	ctx context.Context,
+	index int,
+	vdr *warp.Validator,
+	nodeID ids.NodeID,
+	msg *warp.UnsignedMessage,
+	results chan<- signatureResult,
+) {
+	result := signatureResult{
+		index: index,
+	}
+	defer func() {
+		results <- result
+	}()
+
+	signature, err := a.client.GetSignature(ctx, nodeID, msg)
+	if err != nil {
+		a.log.Debug("failed to fetch warp signature",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("messageID", msg.ID()),
+			zap.Error(err),
+		)
+		return
+	}
+	if !bls.Verify(vdr.PublicKey, signature, msg.Bytes()) {
+		a.log.Debug("dropping invalid warp signature",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("messageID", msg.ID()),
+		)
+		return
+	}
+	result.signature = signature
+}
+
+func (*Aggregator) newMessage(
+	msg *warp.UnsignedMessage,
+	signers set.Bits,
+	signatures []*bls.Signature,
+) (*warp.Message, error) {
+	aggSig, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	signature := &warp.BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggSig))
+	return warp.NewMessage(msg, signature)
+}
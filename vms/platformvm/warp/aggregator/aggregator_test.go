@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+var errUnknownMessage = errors.New("unknown message")
+
+type testVerifier struct {
+	err error
+}
+
+func (v *testVerifier) Verify(context.Context, *warp.UnsignedMessage) error {
+	return v.err
+}
+
+type testNode struct {
+	nodeID  ids.NodeID
+	sk      *bls.SecretKey
+	weight  uint64
+	handler *Handler
+}
+
+// newTestNetwork returns the nodes validating [chainID] with [weights], and a
+// Client of the first node that is connected to the Handlers of the others.
+// The nodes whose index is in [refusing] refuse to sign messages.
+func newTestNetwork(
+	t *testing.T,
+	chainID ids.ID,
+	weights []uint64,
+	refusing set.Set[int],
+) ([]*testNode, *Client, validators.State) {
+	nodes := make([]*testNode, len(weights))
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(weights))
+	for i, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+
+		nodes[i] = &testNode{
+			nodeID: ids.GenerateTestNodeID(),
+			sk:     sk,
+			weight: weight,
+		}
+		vdrSet[nodes[i].nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodes[i].nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    weight,
+		}
+	}
+
+	var client *Client
+	for i, node := range nodes {
+		node := node
+		verifier := &testVerifier{}
+		if refusing.Contains(i) {
+			verifier.err = errUnknownMessage
+		}
+		node.handler = NewHandler(
+			logging.NoLog{},
+			warp.NewSigner(node.sk, chainID),
+			verifier,
+			&common.SenderTest{
+				T: t,
+				SendAppResponseF: func(_ context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+					go client.AppResponse(node.nodeID, requestID, response)
+					return nil
+				},
+			},
+		)
+	}
+
+	nodesByID := make(map[ids.NodeID]*testNode, len(nodes))
+	for _, node := range nodes {
+		nodesByID[node.nodeID] = node
+	}
+	client = NewClient(
+		nodes[0].nodeID,
+		warp.NewSigner(nodes[0].sk, chainID),
+		&common.SenderTest{
+			T: t,
+			SendAppRequestF: func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+				for nodeID := range nodeIDs {
+					require.NoError(t, nodesByID[nodeID].handler.AppRequest(ctx, nodes[0].nodeID, requestID, request))
+				}
+				return nil
+			},
+		},
+	)
+
+	state := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrSet, nil
+		},
+	}
+	return nodes, client, state
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	_, client, state := newTestNetwork(t, chainID, []uint64{1, 1, 1, 1}, set.Set[int]{3: {}})
+
+	msg, err := warp.NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	a := New(logging.NoLog{}, subnetID, state, client)
+	signedMsg, err := a.AggregateSignatures(context.Background(), msg, 10, 3, 4)
+	require.NoError(err)
+	require.Equal(msg.Bytes(), signedMsg.UnsignedMessage.Bytes())
+	require.NoError(signedMsg.Signature.Verify(context.Background(), msg, state, subnetID, 10, 3, 4))
+
+	parsedMsg, err := warp.ParseMessage(signedMsg.Bytes())
+	require.NoError(err)
+	require.NoError(parsedMsg.Signature.Verify(context.Background(), &parsedMsg.UnsignedMessage, state, subnetID, 10, 3, 4))
+}
+
+func TestAggregateSignaturesInsufficientWeight(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	_, client, state := newTestNetwork(t, chainID, []uint64{1, 1, 1, 1}, set.Set[int]{2: {}, 3: {}})
+
+	msg, err := warp.NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	a := New(logging.NoLog{}, ids.GenerateTestID(), state, client)
+	_, err = a.AggregateSignatures(context.Background(), msg, 10, 3, 4)
+	require.ErrorIs(err, errInsufficientWeight)
+}
+
+func TestClientAppRequestFailed(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	var client *Client
+	client = NewClient(
+		ids.GenerateTestNodeID(),
+		nil,
+		&common.SenderTest{
+			T: t,
+			SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+				require.NotZero(requestID & requestIDFlag)
+				go client.AppRequestFailed(nodeID, requestID)
+				return nil
+			},
+		},
+	)
+
+	msg, err := warp.NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	_, err = client.GetSignature(context.Background(), nodeID, msg)
+	require.ErrorIs(err, errRequestFailed)
+
+	// Responses to the requests of the VM aren't handled by the client.
+	require.False(client.AppResponse(nodeID, 0, nil))
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+// requestIDFlag is set in the request IDs of the signature requests of
+// Clients, so that VMs can tell their responses apart from the responses to
+// their own requests.
+const requestIDFlag uint32 = 1 << 31
+
+var (
+	_ SignatureGetter = (*Client)(nil)
+
+	errRequestFailed = errors.New("signature request failed")
+)
+
+// Client fetches the signatures of nodes over Warp messages by sending them
+// AppRequests, which are answered by their Handler.
+//
+// The VM must forward the AppResponse and AppRequestFailed messages it
+// receives to the Client. The request IDs of the Client have their most
+// significant bit set, so VMs that send their own AppRequests must keep their
+// request IDs below 2^31.
+type Client struct {
+	nodeID    ids.NodeID
+	signer    warp.Signer
+	appSender common.AppSender
+
+	lock          sync.Mutex
+	nextRequestID uint32
+	// requestID -> the request that is waiting for its response
+	requests map[uint32]*signatureRequest
+}
+
+type signatureRequest struct {
+	nodeID ids.NodeID
+	// The response is nil if the request failed.
+	response chan []byte
+}
+
+// NewClient returns a Client that sends requests with [appSender]. Signatures
+// of [nodeID], the local node, are produced by [signer] instead of being
+// requested.
+func NewClient(nodeID ids.NodeID, signer warp.Signer, appSender common.AppSender) *Client {
+	return &Client{
+		nodeID:    nodeID,
+		signer:    signer,
+		appSender: appSender,
+		requests:  make(map[uint32]*signatureRequest),
+	}
+}
+
+func (c *Client) GetSignature(ctx context.Context, nodeID ids.NodeID, msg *warp.UnsignedMessage) (*bls.Signature, error) {
+	if nodeID == c.nodeID {
+		sigBytes, err := c.signer.Sign(msg)
+		if err != nil {
+			return nil, err
+		}
+		return bls.SignatureFromBytes(sigBytes)
+	}
+
+	request := &signatureRequest{
+		nodeID:   nodeID,
+		response: make(chan []byte, 1),
+	}
+
+	c.lock.Lock()
+	requestID := c.nextRequestID | requestIDFlag
+	c.nextRequestID = (c.nextRequestID + 1) &^ requestIDFlag
+	c.requests[requestID] = request
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		delete(c.requests, requestID)
+		c.lock.Unlock()
+	}()
+
+	nodeIDs := set.NewSet[ids.NodeID](1)
+	nodeIDs.Add(nodeID)
+	if err := c.appSender.SendAppRequest(ctx, nodeIDs, requestID, msg.Bytes()); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response := <-request.response:
+		if len(response) == 0 {
+			return nil, errRequestFailed
+		}
+		return bls.SignatureFromBytes(response)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AppResponse notifies the Client of a response to an AppRequest. Returns
+// false if [requestID] isn't the request ID of a request of the Client, in
+// which case the response should be handled by the VM.
+func (c *Client) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) bool {
+	if requestID&requestIDFlag == 0 {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// The request may have been abandoned already.
+	request, ok := c.requests[requestID]
+	if !ok || request.nodeID != nodeID {
+		return true
+	}
+	delete(c.requests, requestID)
+	request.response <- response
+	return true
+}
+
+// AppRequestFailed notifies the Client that an AppRequest failed. Returns
+// false if [requestID] isn't the request ID of a request of the Client, in
+// which case the failure should be handled by the VM.
+func (c *Client) AppRequestFailed(nodeID ids.NodeID, requestID uint32) bool {
+	return c.AppResponse(nodeID, requestID, nil)
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+func newTestMessage(t *testing.T, chainID ids.ID) *warp.UnsignedMessage {
+	msg, err := warp.NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(t, err)
+	return msg
+}
+
+func TestClientGetSignatureLocal(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	client := NewClient(
+		nodeID,
+		warp.NewSigner(sk, chainID),
+		&common.SenderTest{
+			T:                  t,
+			CantSendAppRequest: true,
+		},
+	)
+
+	msg := newTestMessage(t, chainID)
+	sig, err := client.GetSignature(context.Background(), nodeID, msg)
+	require.NoError(err)
+	require.True(bls.Verify(bls.PublicFromSecretKey(sk), sig, msg.Bytes()))
+}
+
+func TestClientGetSignatureResponse(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	peerID := ids.GenerateTestNodeID()
+	msg := newTestMessage(t, chainID)
+
+	var client *Client
+	client = NewClient(
+		ids.GenerateTestNodeID(),
+		nil,
+		&common.SenderTest{
+			T: t,
+			SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+				require.Equal(set.Set[ids.NodeID]{peerID: struct{}{}}, nodeIDs)
+				require.NotZero(requestID & requestIDFlag)
+				require.Equal(msg.Bytes(), request)
+
+				sig := bls.Sign(sk, request)
+				go client.AppResponse(peerID, requestID, bls.SignatureToBytes(sig))
+				return nil
+			},
+		},
+	)
+
+	sig, err := client.GetSignature(context.Background(), peerID, msg)
+	require.NoError(err)
+	require.True(bls.Verify(bls.PublicFromSecretKey(sk), sig, msg.Bytes()))
+	require.Empty(client.requests)
+}
+
+func TestClientGetSignatureFailed(t *testing.T) {
+	require := require.New(t)
+
+	peerID := ids.GenerateTestNodeID()
+
+	var client *Client
+	client = NewClient(
+		ids.GenerateTestNodeID(),
+		nil,
+		&common.SenderTest{
+			T: t,
+			SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+				go client.AppRequestFailed(peerID, requestID)
+				return nil
+			},
+		},
+	)
+
+	_, err := client.GetSignature(context.Background(), peerID, newTestMessage(t, ids.GenerateTestID()))
+	require.ErrorIs(err, errRequestFailed)
+	require.Empty(client.requests)
+}
+
+func TestClientGetSignatureIgnoresOtherNodes(t *testing.T) {
+	require := require.New(t)
+
+	peerID := ids.GenerateTestNodeID()
+
+	var client *Client
+	client = NewClient(
+		ids.GenerateTestNodeID(),
+		nil,
+		&common.SenderTest{
+			T: t,
+			SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+				// The response of another node must not be taken as the
+				// response of [peerID].
+				require.True(client.AppRequestFailed(ids.GenerateTestNodeID(), requestID))
+				return nil
+			},
+		},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetSignature(ctx, peerID, newTestMessage(t, ids.GenerateTestID()))
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Empty(client.requests)
+}
+
+func TestClientAppResponse(t *testing.T) {
+	require := require.New(t)
+
+	client := NewClient(ids.GenerateTestNodeID(), nil, &common.SenderTest{T: t})
+	nodeID := ids.GenerateTestNodeID()
+
+	// Request IDs without the flag belong to the VM.
+	require.False(client.AppResponse(nodeID, 1, nil))
+	require.False(client.AppRequestFailed(nodeID, 1))
+
+	// Request IDs with the flag belong to the Client, even if the request was
+	// abandoned.
+	require.True(client.AppResponse(nodeID, requestIDFlag|1, nil))
+	require.True(client.AppRequestFailed(nodeID, requestIDFlag|1))
+}
+
+func TestClientRequestIDWraps(t *testing.T) {
+	require := require.New(t)
+
+	var requestIDs []uint32
+	client := NewClient(
+		ids.GenerateTestNodeID(),
+		nil,
+		&common.SenderTest{
+			T: t,
+			SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+				requestIDs = append(requestIDs, requestID)
+				return errRequestFailed
+			},
+		},
+	)
+	client.nextRequestID = requestIDFlag - 1
+
+	msg := newTestMessage(t, ids.GenerateTestID())
+	for i := 0; i < 2; i++ {
+		_, err := client.GetSignature(context.Background(), ids.GenerateTestNodeID(), msg)
+		require.ErrorIs(err, errRequestFailed)
+	}
+	require.Equal([]uint32{^uint32(0), requestIDFlag}, requestIDs)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+// Verifier decides which Warp messages the node is willing to sign.
+type Verifier interface {
+	// Verify returns nil if [msg] was produced by the VM, and should
+	// therefore be signed.
+	Verify(ctx context.Context, msg *warp.UnsignedMessage) error
+}
+
+// Handler answers the signature requests sent by Clients.
+type Handler struct {
+	log       logging.Logger
+	signer    warp.Signer
+	verifier  Verifier
+	appSender common.AppSender
+}
+
+// NewHandler returns a Handler that signs the messages accepted by [verifier]
+// with [signer], and responds with [appSender].
+func NewHandler(
+	log logging.Logger,
+	signer warp.Signer,
+	verifier Verifier,
+	appSender common.AppSender,
+) *Handler {
+	return &Handler{
+		log:       log,
+		signer:    signer,
+		verifier:  verifier,
+		appSender: appSender,
+	}
+}
+
+// AppRequest answers the signature request [request] of [nodeID]. Requests for
+// messages that won't be signed are answered with an empty response, so that
+// the requester doesn't need to wait for the request to time out.
+func (h *Handler) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, request []byte) error {
+	signature, err := h.sign(ctx, request)
+	if err != nil {
+		h.log.Debug("refusing to sign warp message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+	}
+	return h.appSender.SendAppResponse(ctx, nodeID, requestID, signature)
+}
+
+func (h *Handler) sign(ctx context.Context, request []byte) ([]byte, error) {
+	msg, err := warp.ParseUnsignedMessage(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.verifier.Verify(ctx, msg); err != nil {
+		return nil, err
+	}
+	return h.signer.Sign(msg)
+}
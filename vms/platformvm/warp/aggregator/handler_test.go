@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/logging"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+func TestHandlerAppRequest(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	chainID := ids.GenerateTestID()
+	msg := newTestMessage(t, chainID)
+
+	tests := []struct {
+		name        string
+		request     []byte
+		verifierErr error
+		expectSig   bool
+	}{
+		{
+			name:      "signs verified message",
+			request:   msg.Bytes(),
+			expectSig: true,
+		},
+		{
+			name:        "refuses unverified message",
+			request:     msg.Bytes(),
+			verifierErr: errUnknownMessage,
+		},
+		{
+			name:    "refuses message of another chain",
+			request: newTestMessage(t, ids.GenerateTestID()).Bytes(),
+		},
+		{
+			name:    "refuses unparsable request",
+			request: []byte{1, 2, 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			nodeID := ids.GenerateTestNodeID()
+			requestID := uint32(1337)
+
+			responded := false
+			handler := NewHandler(
+				logging.NoLog{},
+				warp.NewSigner(sk, chainID),
+				&testVerifier{err: test.verifierErr},
+				&common.SenderTest{
+					T: t,
+					SendAppResponseF: func(_ context.Context, respNodeID ids.NodeID, respRequestID uint32, response []byte) error {
+						require.Equal(nodeID, respNodeID)
+						require.Equal(requestID, respRequestID)
+						responded = true
+
+						if !test.expectSig {
+							require.Empty(response)
+							return nil
+						}
+
+						sig, err := bls.SignatureFromBytes(response)
+						require.NoError(err)
+						require.True(bls.Verify(bls.PublicFromSecretKey(sk), sig, msg.Bytes()))
+						return nil
+					},
+				},
+			)
+
+			require.NoError(handler.AppRequest(context.Background(), nodeID, requestID, test.request))
+			require.True(responded)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math"
+
+	"github.com/lasthyphen/dijetsnodego/codec"
+	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
+	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
+)
+
+const codecVersion = 0
+
+// Codec does serialization and deserialization for Warp messages.
+var c codec.Manager
+
+func init() {
+	c = codec.NewManager(math.MaxInt)
+	lc := linearcodec.NewCustomMaxLength(math.MaxInt32)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		lc.RegisterType(&BitSetSignature{}),
+		c.RegisterCodec(codecVersion, lc),
+	)
+	if errs.Errored() {
+		panic(errs.Err)
+	}
+}
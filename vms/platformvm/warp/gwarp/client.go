@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gwarp
+
+import (
+	"context"
+
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+
+	pb "github.com/lasthyphen/dijetsnodego/proto/pb/warp"
+)
+
+var _ warp.Signer = (*Client)(nil)
+
+// Client is a Warp signer that talks over RPC.
+type Client struct {
+	client pb.SignerClient
+}
+
+func NewClient(client pb.SignerClient) *Client {
+	return &Client{client: client}
+}
+
+func (c *Client) Sign(msg *warp.UnsignedMessage) ([]byte, error) {
+	resp, err := c.client.Sign(context.Background(), &pb.SignRequest{
+		SourceChainId:      msg.SourceChainID[:],
+		DestinationChainId: msg.DestinationChainID[:],
+		Payload:            msg.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gwarp
+
+import (
+	"context"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+
+	pb "github.com/lasthyphen/dijetsnodego/proto/pb/warp"
+)
+
+var _ pb.SignerServer = (*Server)(nil)
+
+// Server is a Warp signer that is managed over RPC.
+type Server struct {
+	pb.UnsafeSignerServer
+	signer warp.Signer
+}
+
+func NewServer(signer warp.Signer) *Server {
+	return &Server{signer: signer}
+}
+
+func (s *Server) Sign(_ context.Context, req *pb.SignRequest) (*pb.SignResponse, error) {
+	sourceChainID, err := ids.ToID(req.SourceChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationChainID, err := ids.ToID(req.DestinationChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := warp.NewUnsignedMessage(
+		sourceChainID,
+		destinationChainID,
+		req.Payload,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := s.signer.Sign(msg)
+	return &pb.SignResponse{Signature: sig}, err
+}
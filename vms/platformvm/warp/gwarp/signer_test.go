@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gwarp
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/grpcutils"
+
+	pb "github.com/lasthyphen/dijetsnodego/proto/pb/warp"
+)
+
+const bufSize = 1024 * 1024
+
+type testSigner struct {
+	client  *Client
+	server  warp.Signer
+	sk      *bls.SecretKey
+	chainID ids.ID
+	closeFn func()
+}
+
+func setupSigner(t testing.TB) *testSigner {
+	t.Helper()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	chainID := ids.GenerateTestID()
+
+	s := &testSigner{
+		server:  warp.NewSigner(sk, chainID),
+		sk:      sk,
+		chainID: chainID,
+	}
+
+	listener := bufconn.Listen(bufSize)
+	serverCloser := grpcutils.ServerCloser{}
+
+	serverFunc := func(opts []grpc.ServerOption) *grpc.Server {
+		server := grpc.NewServer(opts...)
+		pb.RegisterSignerServer(server, NewServer(s.server))
+		serverCloser.Add(server)
+		return server
+	}
+
+	go grpcutils.Serve(listener, serverFunc)
+
+	dialer := grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		},
+	)
+
+	dopts := grpcutils.DefaultDialOptions
+	dopts = append(dopts, dialer)
+	conn, err := grpcutils.Dial("", dopts...)
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+
+	s.client = NewClient(pb.NewSignerClient(conn))
+	s.closeFn = func() {
+		serverCloser.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	}
+	return s
+}
+
+func TestSign(t *testing.T) {
+	require := require.New(t)
+
+	s := setupSigner(t)
+	defer s.closeFn()
+
+	msg, err := warp.NewUnsignedMessage(
+		s.chainID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	sigBytes, err := s.client.Sign(msg)
+	require.NoError(err)
+
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	require.NoError(err)
+
+	pk := bls.PublicFromSecretKey(s.sk)
+	require.True(bls.Verify(pk, sig, msg.Bytes()))
+}
+
+func TestSignWrongSourceChainID(t *testing.T) {
+	require := require.New(t)
+
+	s := setupSigner(t)
+	defer s.closeFn()
+
+	msg, err := warp.NewUnsignedMessage(
+		ids.GenerateTestID(),
+		s.chainID,
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	_, err = s.client.Sign(msg)
+	require.Error(err)
+}
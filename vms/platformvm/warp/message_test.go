@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+)
+
+func TestMessage(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   []byte{1, 2, 3},
+			Signature: [bls.SignatureLen]byte{4, 5, 6},
+		},
+	)
+	require.NoError(err)
+
+	parsedMsg, err := ParseMessage(msg.Bytes())
+	require.NoError(err)
+	require.Equal(msg, parsedMsg)
+	require.Equal(unsignedMsg.ID(), parsedMsg.ID())
+}
+
+func TestParseMessageJunk(t *testing.T) {
+	_, err := ParseMessage([]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	require.Error(t, err)
+}
+
+func TestUnsignedMessage(t *testing.T) {
+	require := require.New(t)
+
+	msg, err := NewUnsignedMessage(
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	parsedMsg, err := ParseUnsignedMessage(msg.Bytes())
+	require.NoError(err)
+	require.Equal(msg, parsedMsg)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+var (
+	_ Signature = (*BitSetSignature)(nil)
+
+	errInvalidBitSet      = errors.New("bitset is invalid")
+	errInsufficientWeight = errors.New("signature weight is insufficient")
+	errInvalidSignature   = errors.New("signature is invalid")
+	errParseSignature     = errors.New("failed to parse signature")
+)
+
+// Signature is the aggregate signature of a Warp message.
+type Signature interface {
+	// Verify that this signature was signed by at least [quorumNum]/[quorumDen]
+	// of the weight of the validators of [subnetID] at [pChainHeight].
+	//
+	// Invariant: [subnetID] must be the subnet that validates the source
+	// chain of [msg]. It must be looked up by the caller, as the message can't
+	// be trusted to report it.
+	Verify(
+		ctx context.Context,
+		msg *UnsignedMessage,
+		pChainState validators.State,
+		subnetID ids.ID,
+		pChainHeight uint64,
+		quorumNum uint64,
+		quorumDen uint64,
+	) error
+}
+
+// BitSetSignature is an aggregate BLS signature along with the validators, as
+// indices into the canonical validator set, whose signatures were aggregated.
+type BitSetSignature struct {
+	// Signers is a big-endian byte slice encoding which validators signed this
+	// message.
+	Signers   []byte                 `serialize:"true"`
+	Signature [bls.SignatureLen]byte `serialize:"true"`
+}
+
+func (s *BitSetSignature) Verify(
+	ctx context.Context,
+	msg *UnsignedMessage,
+	pChainState validators.State,
+	subnetID ids.ID,
+	pChainHeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	vdrs, totalWeight, err := GetCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return err
+	}
+
+	// Parse signer bit vector
+	//
+	// We assert that the length of [signerIndices.Bytes()] is equal
+	// to [len(s.Signers)] to ensure that [s.Signers] does not have
+	// any unnecessary zero-padding to represent the [set.Bits].
+	signerIndices := set.BitsFromBytes(s.Signers)
+	if len(signerIndices.Bytes()) != len(s.Signers) {
+		return errInvalidBitSet
+	}
+
+	// Get the validators that (allegedly) signed the message.
+	signers, err := FilterValidators(signerIndices, vdrs)
+	if err != nil {
+		return err
+	}
+
+	// Because [signers] is a subset of [vdrs], this can never error.
+	sigWeight, _ := SumWeight(signers)
+
+	// Make sure the signature's weight is sufficient.
+	if err := VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen); err != nil {
+		return err
+	}
+
+	// Parse the aggregate signature
+	aggSig, err := bls.SignatureFromBytes(s.Signature[:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", errParseSignature, err)
+	}
+
+	// Create the aggregate public key
+	aggPubKey, err := AggregatePublicKeys(signers)
+	if err != nil {
+		return err
+	}
+
+	// Verify the signature
+	if !bls.Verify(aggPubKey, aggSig, msg.Bytes()) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// VerifyWeight returns [nil] if [sigWeight] is at least [quorumNum]/[quorumDen]
+// of [totalWeight].
+// If [sigWeight >= totalWeight * quorumNum / quorumDen] then return [nil]
+func VerifyWeight(
+	sigWeight uint64,
+	totalWeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	// Verifies that quorumNum * totalWeight <= quorumDen * sigWeight
+	scaledTotalWeight := new(big.Int).SetUint64(totalWeight)
+	scaledTotalWeight.Mul(scaledTotalWeight, new(big.Int).SetUint64(quorumNum))
+	scaledSigWeight := new(big.Int).SetUint64(sigWeight)
+	scaledSigWeight.Mul(scaledSigWeight, new(big.Int).SetUint64(quorumDen))
+	if scaledTotalWeight.Cmp(scaledSigWeight) == 1 {
+		return fmt.Errorf(
+			"%w: %d*%d > %d*%d",
+			errInsufficientWeight,
+			quorumNum,
+			totalWeight,
+			quorumDen,
+			sigWeight,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	vdr    *Validator
+}
+
+func newTestValidator(t *testing.T, weight uint64) *testValidator {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	nodeID := ids.GenerateTestNodeID()
+	pk := bls.PublicFromSecretKey(sk)
+	return &testValidator{
+		nodeID: nodeID,
+		sk:     sk,
+		vdr: &Validator{
+			PublicKey:      pk,
+			PublicKeyBytes: bls.PublicKeyToBytes(pk),
+			Weight:         weight,
+			NodeIDs:        []ids.NodeID{nodeID},
+		},
+	}
+}
+
+// newTestState returns the validators of [subnetID], sorted canonically, and
+// a validator state that reports them at [height]. A validator without a BLS
+// key with [weightWithoutKey] is also reported.
+func newTestState(
+	t *testing.T,
+	subnetID ids.ID,
+	height uint64,
+	weights []uint64,
+	weightWithoutKey uint64,
+) ([]*testValidator, validators.State) {
+	vdrs := make([]*testValidator, len(weights))
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(weights)+1)
+	for i, weight := range weights {
+		vdrs[i] = newTestValidator(t, weight)
+		vdrSet[vdrs[i].nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdrs[i].nodeID,
+			PublicKey: vdrs[i].vdr.PublicKey,
+			Weight:    weight,
+		}
+	}
+	nodeIDWithoutKey := ids.GenerateTestNodeID()
+	vdrSet[nodeIDWithoutKey] = &validators.GetValidatorOutput{
+		NodeID: nodeIDWithoutKey,
+		Weight: weightWithoutKey,
+	}
+
+	// Sort the validators as GetCanonicalValidatorSet does.
+	slices.SortFunc(vdrs, func(a, b *testValidator) int {
+		return bytes.Compare(a.vdr.PublicKeyBytes, b.vdr.PublicKeyBytes)
+	})
+
+	state := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(_ context.Context, h uint64, s ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			require.Equal(t, height, h)
+			require.Equal(t, subnetID, s)
+			return vdrSet, nil
+		},
+	}
+	return vdrs, state
+}
+
+func newTestSignature(msg *UnsignedMessage, vdrs []*testValidator, signers ...int) *BitSetSignature {
+	bits := set.NewBits()
+	sigs := make([]*bls.Signature, 0, len(signers))
+	for _, i := range signers {
+		bits.Add(i)
+		sigs = append(sigs, bls.Sign(vdrs[i].sk, msg.Bytes()))
+	}
+	aggSig, _ := bls.AggregateSignatures(sigs)
+
+	sig := &BitSetSignature{
+		Signers: bits.Bytes(),
+	}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+	return sig
+}
+
+func TestGetCanonicalValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	vdrs, state := newTestState(t, subnetID, 10, []uint64{1, 2, 3}, 4)
+
+	canonicalVdrs, totalWeight, err := GetCanonicalValidatorSet(context.Background(), state, 10, subnetID)
+	require.NoError(err)
+	require.Equal(uint64(10), totalWeight)
+	require.Len(canonicalVdrs, len(vdrs))
+	for i, vdr := range vdrs {
+		require.Equal(vdr.vdr, canonicalVdrs[i])
+	}
+}
+
+func TestGetCanonicalValidatorSetMergesSharedKeys(t *testing.T) {
+	require := require.New(t)
+
+	vdr := newTestValidator(t, 1)
+	otherNodeID := ids.GenerateTestNodeID()
+	state := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				vdr.nodeID: {
+					NodeID:    vdr.nodeID,
+					PublicKey: vdr.vdr.PublicKey,
+					Weight:    1,
+				},
+				otherNodeID: {
+					NodeID:    otherNodeID,
+					PublicKey: vdr.vdr.PublicKey,
+					Weight:    2,
+				},
+			}, nil
+		},
+	}
+
+	canonicalVdrs, totalWeight, err := GetCanonicalValidatorSet(context.Background(), state, 0, ids.Empty)
+	require.NoError(err)
+	require.Equal(uint64(3), totalWeight)
+	require.Len(canonicalVdrs, 1)
+	require.Equal(uint64(3), canonicalVdrs[0].Weight)
+	require.ElementsMatch([]ids.NodeID{vdr.nodeID, otherNodeID}, canonicalVdrs[0].NodeIDs)
+}
+
+func TestBitSetSignatureVerify(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	vdrs, state := newTestState(t, subnetID, 10, []uint64{1, 2, 3}, 4)
+
+	msg, err := NewUnsignedMessage(ids.GenerateTestID(), ids.GenerateTestID(), []byte("payload"))
+	require.NoError(t, err)
+
+	otherMsg, err := NewUnsignedMessage(ids.GenerateTestID(), ids.GenerateTestID(), []byte("other payload"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		sig         *BitSetSignature
+		quorumNum   uint64
+		expectedErr error
+	}{
+		{
+			name:      "valid",
+			sig:       newTestSignature(msg, vdrs, 0, 1, 2),
+			quorumNum: 6,
+		},
+		{
+			name:        "insufficient weight",
+			sig:         newTestSignature(msg, vdrs, 0, 1, 2),
+			quorumNum:   7,
+			expectedErr: errInsufficientWeight,
+		},
+		{
+			name: "padded bitset",
+			sig: func() *BitSetSignature {
+				sig := newTestSignature(msg, vdrs, 0, 1, 2)
+				sig.Signers = append([]byte{0}, sig.Signers...)
+				return sig
+			}(),
+			quorumNum:   1,
+			expectedErr: errInvalidBitSet,
+		},
+		{
+			name: "unknown signer",
+			sig: &BitSetSignature{
+				Signers: set.NewBits(3).Bytes(),
+			},
+			quorumNum:   1,
+			expectedErr: errUnknownValidator,
+		},
+		{
+			name:      "subset of validators",
+			sig:       newTestSignature(msg, vdrs, 0, 1),
+			quorumNum: 1,
+		},
+		{
+			name: "signature doesn't match signers",
+			sig: func() *BitSetSignature {
+				sig := newTestSignature(msg, vdrs, 0, 1)
+				sig.Signers = set.NewBits(1, 2).Bytes()
+				return sig
+			}(),
+			quorumNum:   1,
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "signature of another message",
+			sig:         newTestSignature(otherMsg, vdrs, 0, 1, 2),
+			quorumNum:   1,
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "unparsable signature",
+			sig:         &BitSetSignature{Signers: set.NewBits(0).Bytes()},
+			quorumNum:   1,
+			expectedErr: errParseSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.sig.Verify(context.Background(), msg, state, subnetID, 10, test.quorumNum, 10)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSignerSign(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	s := NewSigner(sk, chainID)
+
+	msg, err := NewUnsignedMessage(chainID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	sigBytes, err := s.Sign(msg)
+	require.NoError(err)
+
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	require.NoError(err)
+	require.True(bls.Verify(bls.PublicFromSecretKey(sk), sig, msg.Bytes()))
+
+	otherMsg, err := NewUnsignedMessage(ids.GenerateTestID(), ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	_, err = s.Sign(otherMsg)
+	require.ErrorIs(err, errWrongSourceChainID)
+}
+
+func TestVerifyWeight(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(VerifyWeight(67, 100, 67, 100))
+	require.ErrorIs(VerifyWeight(66, 100, 67, 100), errInsufficientWeight)
+	require.NoError(VerifyWeight(0, 0, 67, 100))
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+)
+
+var (
+	_ Signer = (*signer)(nil)
+
+	errWrongSourceChainID = errors.New("wrong SourceChainID")
+)
+
+// Signer signs Warp messages with the BLS key of the node.
+type Signer interface {
+	// Returns this node's BLS signature over an unsigned message. If the
+	// message isn't from the chain of this signer, an error is returned.
+	Sign(msg *UnsignedMessage) ([]byte, error)
+}
+
+// NewSigner returns a Signer that only signs messages from [chainID] with
+// [sk].
+func NewSigner(sk *bls.SecretKey, chainID ids.ID) Signer {
+	return &signer{
+		sk:      sk,
+		chainID: chainID,
+	}
+}
+
+type signer struct {
+	sk      *bls.SecretKey
+	chainID ids.ID
+}
+
+func (s *signer) Sign(msg *UnsignedMessage) ([]byte, error) {
+	if msg.SourceChainID != s.chainID {
+		return nil, errWrongSourceChainID
+	}
+
+	sig := bls.Sign(s.sk, msg.Bytes())
+	return bls.SignatureToBytes(sig), nil
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/snow/validators"
+	"github.com/lasthyphen/dijetsnodego/utils"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+	"github.com/lasthyphen/dijetsnodego/utils/set"
+)
+
+var (
+	_ utils.Sortable[*Validator] = (*Validator)(nil)
+
+	errUnknownValidator = errors.New("unknown validator")
+	errWeightOverflow   = errors.New("weight overflowed")
+)
+
+// Validator is a BLS key of the validator set of a subnet, along with the
+// weight and the nodes that registered the key.
+type Validator struct {
+	PublicKey      *bls.PublicKey
+	PublicKeyBytes []byte
+	Weight         uint64
+	NodeIDs        []ids.NodeID
+}
+
+func (v *Validator) Less(o *Validator) bool {
+	return bytes.Compare(v.PublicKeyBytes, o.PublicKeyBytes) < 0
+}
+
+// GetCanonicalValidatorSet returns the validator set of [subnetID] at
+// [pChainHeight] in a canonical ordering. Also returns the total weight on
+// [subnetID]. Validators without a BLS key can't sign Warp messages, so they
+// are excluded from the set, but their weight is included in the total weight.
+func GetCanonicalValidatorSet(
+	ctx context.Context,
+	pChainState validators.State,
+	pChainHeight uint64,
+	subnetID ids.ID,
+) ([]*Validator, uint64, error) {
+	// Get the validator set at the given height.
+	vdrSet, err := pChainState.GetValidatorSet(ctx, pChainHeight, subnetID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch validator set (P-Chain Height: %d, SubnetID: %s): %w", pChainHeight, subnetID, err)
+	}
+
+	var (
+		vdrs        = make(map[string]*Validator, len(vdrSet))
+		totalWeight uint64
+	)
+	for _, vdr := range vdrSet {
+		totalWeight, err = math.Add64(totalWeight, vdr.Weight)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", errWeightOverflow, err)
+		}
+
+		if vdr.PublicKey == nil {
+			continue
+		}
+
+		pkBytes := bls.PublicKeyToBytes(vdr.PublicKey)
+		uniqueVdr, ok := vdrs[string(pkBytes)]
+		if !ok {
+			uniqueVdr = &Validator{
+				PublicKey:      vdr.PublicKey,
+				PublicKeyBytes: pkBytes,
+			}
+			vdrs[string(pkBytes)] = uniqueVdr
+		}
+
+		// Nodes that registered the same BLS key are treated as a single
+		// signer, so their weight is combined.
+		uniqueVdr.Weight += vdr.Weight // Impossible to overflow here
+		uniqueVdr.NodeIDs = append(uniqueVdr.NodeIDs, vdr.NodeID)
+	}
+
+	// Sort validators by public key
+	vdrList := make([]*Validator, 0, len(vdrs))
+	for _, vdr := range vdrs {
+		vdrList = append(vdrList, vdr)
+	}
+	utils.Sort(vdrList)
+	return vdrList, totalWeight, nil
+}
+
+// FilterValidators returns the validators in [vdrs] whose bit is set to 1 in
+// [indices].
+//
+// Returns an error if [indices] references an unknown validator.
+func FilterValidators(
+	indices set.Bits,
+	vdrs []*Validator,
+) ([]*Validator, error) {
+	// Verify that all alleged signers exist
+	if indices.BitLen() > len(vdrs) {
+		return nil, fmt.Errorf(
+			"%w: NumIndices (%d) >= NumFilteredValidators (%d)",
+			errUnknownValidator,
+			indices.BitLen()-1, // -1 to convert from length to index
+			len(vdrs),
+		)
+	}
+
+	filteredVdrs := make([]*Validator, 0, len(vdrs))
+	for i, vdr := range vdrs {
+		if !indices.Contains(i) {
+			continue
+		}
+
+		filteredVdrs = append(filteredVdrs, vdr)
+	}
+	return filteredVdrs, nil
+}
+
+// SumWeight returns the total weight of the provided validators.
+func SumWeight(vdrs []*Validator) (uint64, error) {
+	var (
+		weight uint64
+		err    error
+	)
+	for _, vdr := range vdrs {
+		weight, err = math.Add64(weight, vdr.Weight)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", errWeightOverflow, err)
+		}
+	}
+	return weight, nil
+}
+
+// AggregatePublicKeys returns the public key of the provided validators.
+//
+// Invariant: All of the public keys in [vdrs] are valid.
+func AggregatePublicKeys(vdrs []*Validator) (*bls.PublicKey, error) {
+	pks := make([]*bls.PublicKey, len(vdrs))
+	for i, vdr := range vdrs {
+		pks[i] = vdr.PublicKey
+	}
+	return bls.AggregatePublicKeys(pks)
+}
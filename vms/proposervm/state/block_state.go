@@ -33,7 +33,7 @@ type BlockState interface {
 type blockState struct {
 	// Caches BlockID -> Block. If the Block is nil, that means the block is not
 	// in storage.
-	blkCache cache.Cacher
+	blkCache cache.Cacher[ids.ID, *blockWrapper]
 
 	db database.Database
 }
@@ -47,7 +47,7 @@ type blockWrapper struct {
 
 func NewBlockState(db database.Database) BlockState {
 	return &blockState{
-		blkCache: &cache.LRU{Size: blockCacheSize},
+		blkCache: cache.NewLRU[ids.ID, *blockWrapper](blockCacheSize),
 		db:       db,
 	}
 }
@@ -56,7 +56,7 @@ func NewMeteredBlockState(db database.Database, namespace string, metrics promet
 	blkCache, err := metercacher.New(
 		fmt.Sprintf("%s_block_cache", namespace),
 		metrics,
-		&cache.LRU{Size: blockCacheSize},
+		cache.NewLRU[ids.ID, *blockWrapper](blockCacheSize),
 	)
 
 	return &blockState{
@@ -66,12 +66,8 @@ func NewMeteredBlockState(db database.Database, namespace string, metrics promet
 }
 
 func (s *blockState) GetBlock(blkID ids.ID) (block.Block, choices.Status, error) {
-	if blkIntf, found := s.blkCache.Get(blkID); found {
-		if blkIntf == nil {
-			return nil, choices.Unknown, database.ErrNotFound
-		}
-		blk, ok := blkIntf.(*blockWrapper)
-		if !ok {
+	if blk, found := s.blkCache.Get(blkID); found {
+		if blk == nil {
 			return nil, choices.Unknown, database.ErrNotFound
 		}
 		return blk.block, blk.Status, nil
@@ -44,6 +44,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/version"
 	"github.com/lasthyphen/dijetsnodego/vms/components/chain"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp/gwarp"
 	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/ghttp"
 	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/grpcutils"
 	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/gsubnetlookup"
@@ -59,6 +60,7 @@ import (
 	subnetlookuppb "github.com/lasthyphen/dijetsnodego/proto/pb/subnetlookup"
 	validatorstatepb "github.com/lasthyphen/dijetsnodego/proto/pb/validatorstate"
 	vmpb "github.com/lasthyphen/dijetsnodego/proto/pb/vm"
+	warppb "github.com/lasthyphen/dijetsnodego/proto/pb/warp"
 )
 
 const (
@@ -100,6 +102,7 @@ type VMClient struct {
 	snLookup             *gsubnetlookup.Server
 	appSender            *appsender.Server
 	validatorStateServer *gvalidators.Server
+	warpSignerServer     *gwarp.Server
 
 	serverCloser grpcutils.ServerCloser
 	conns        []*grpc.ClientConn
@@ -188,6 +191,9 @@ func (vm *VMClient) Initialize(
 	vm.snLookup = gsubnetlookup.NewServer(chainCtx.SNLookup)
 	vm.appSender = appsender.NewServer(appSender)
 	vm.validatorStateServer = gvalidators.NewServer(chainCtx.ValidatorState)
+	if chainCtx.WarpSigner != nil {
+		vm.warpSignerServer = gwarp.NewServer(chainCtx.WarpSigner)
+	}
 
 	serverListener, err := grpcutils.NewListener()
 	if err != nil {
@@ -327,6 +333,11 @@ func (vm *VMClient) getInitServer(opts []grpc.ServerOption) *grpc.Server {
 	appsenderpb.RegisterAppSenderServer(server, vm.appSender)
 	healthpb.RegisterHealthServer(server, grpcHealth)
 	validatorstatepb.RegisterValidatorStateServer(server, vm.validatorStateServer)
+	// If this node has no BLS key, the signer isn't served and the plugin's
+	// Sign calls fail as unimplemented.
+	if vm.warpSignerServer != nil {
+		warppb.RegisterSignerServer(server, vm.warpSignerServer)
+	}
 
 	// Ensure metric counters are zeroed on restart
 	grpc_prometheus.Register(server)
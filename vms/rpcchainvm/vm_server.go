@@ -35,6 +35,7 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/logging"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
 	"github.com/lasthyphen/dijetsnodego/version"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp/gwarp"
 	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/ghttp"
 	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/grpcutils"
 	"github.com/lasthyphen/dijetsnodego/vms/rpcchainvm/gsubnetlookup"
@@ -50,6 +51,7 @@ import (
 	subnetlookuppb "github.com/lasthyphen/dijetsnodego/proto/pb/subnetlookup"
 	validatorstatepb "github.com/lasthyphen/dijetsnodego/proto/pb/validatorstate"
 	vmpb "github.com/lasthyphen/dijetsnodego/proto/pb/vm"
+	warppb "github.com/lasthyphen/dijetsnodego/proto/pb/warp"
 )
 
 var (
@@ -189,6 +191,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 	snLookupClient := gsubnetlookup.NewClient(subnetlookuppb.NewSubnetLookupClient(clientConn))
 	appSenderClient := appsender.NewClient(appsenderpb.NewAppSenderClient(clientConn))
 	validatorStateClient := gvalidators.NewClient(validatorstatepb.NewValidatorStateClient(clientConn))
+	warpSignerClient := gwarp.NewClient(warppb.NewSignerClient(clientConn))
 
 	toEngine := make(chan common.Message, 1)
 	vm.closed = make(chan struct{})
@@ -227,6 +230,8 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		ValidatorState: validatorStateClient,
 		// TODO: support remaining snowman++ fields
 
+		WarpSigner: warpSignerClient,
+
 		ChainDataDir: req.ChainDataDir,
 	}
 
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lasthyphen/dijetsnodego/database/manager"
+	"github.com/lasthyphen/dijetsnodego/snow"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/common"
+	"github.com/lasthyphen/dijetsnodego/snow/engine/snowman/block/mocks"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto/bls"
+	"github.com/lasthyphen/dijetsnodego/version"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm/warp"
+)
+
+var (
+	// The plugin runs in a separate process, so both sides derive the same
+	// key from these bytes.
+	warpSignerTestSKBytes = []byte{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+		0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17,
+		0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+	}
+
+	errInvalidWarpSignature = errors.New("invalid warp signature")
+)
+
+func warpSignerTestPlugin(t *testing.T, loadExpectations bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "warpSignerTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	ssVM := StateSyncEnabledMock{
+		MockChainVM:         mocks.NewMockChainVM(ctrl),
+		MockStateSyncableVM: mocks.NewMockStateSyncableVM(ctrl),
+	}
+
+	if loadExpectations {
+		gomock.InOrder(
+			ssVM.MockChainVM.EXPECT().Initialize(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(),
+			).DoAndReturn(
+				func(
+					_ context.Context,
+					chainCtx *snow.Context,
+					_ manager.Manager,
+					_ []byte,
+					_ []byte,
+					_ []byte,
+					_ chan<- common.Message,
+					_ []*common.Fx,
+					_ common.AppSender,
+				) error {
+					// Sign a message through the node and check the signature
+					// against the node's key.
+					sk, err := bls.SecretKeyFromBytes(warpSignerTestSKBytes)
+					if err != nil {
+						return err
+					}
+
+					msg, err := warp.NewUnsignedMessage(
+						chainCtx.ChainID,
+						chainCtx.CChainID,
+						[]byte("payload"),
+					)
+					if err != nil {
+						return err
+					}
+
+					sigBytes, err := chainCtx.WarpSigner.Sign(msg)
+					if err != nil {
+						return err
+					}
+
+					sig, err := bls.SignatureFromBytes(sigBytes)
+					if err != nil {
+						return err
+					}
+
+					pk := bls.PublicFromSecretKey(sk)
+					if !bls.Verify(pk, sig, msg.Bytes()) {
+						return errInvalidWarpSignature
+					}
+					return nil
+				},
+			).Times(1),
+			ssVM.MockChainVM.EXPECT().LastAccepted(gomock.Any()).Return(preSummaryBlk.ID(), nil).Times(1),
+			ssVM.MockChainVM.EXPECT().GetBlock(gomock.Any(), gomock.Any()).Return(preSummaryBlk, nil).Times(1),
+		)
+	}
+
+	return New(ssVM), ctrl
+}
+
+// Show that the VM can sign Warp messages with the node's BLS key
+func TestWarpSigner(t *testing.T) {
+	require := require.New(t)
+	testKey := warpSignerTestKey
+
+	mockedPlugin, ctrl := warpSignerTestPlugin(t, false /*loadExpectations*/)
+	defer ctrl.Finish()
+
+	// Create and start the plugin
+	vm, c := buildClientHelper(require, testKey, mockedPlugin)
+	defer c.Kill()
+
+	sk, err := bls.SecretKeyFromBytes(warpSignerTestSKBytes)
+	require.NoError(err)
+
+	ctx := snow.DefaultContextTest()
+	ctx.WarpSigner = warp.NewSigner(sk, ctx.ChainID)
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	dbManager = dbManager.NewPrefixDBManager([]byte{})
+
+	require.NoError(vm.Initialize(context.Background(), ctx, dbManager, nil, nil, nil, nil, nil, nil))
+}
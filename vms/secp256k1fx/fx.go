@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/lasthyphen/dijetsnodego/cache"
+	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/hashing"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
@@ -52,7 +53,7 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	log.Debug("initializing secp256k1 fx")
 
 	fx.SECPFactory = crypto.FactorySECP256K1R{
-		Cache: cache.LRU{Size: defaultCacheSize},
+		PublicKeyCache: cache.NewLRU[ids.ID, *crypto.PublicKeySECP256K1R](defaultCacheSize),
 	}
 	c := fx.VM.CodecRegistry()
 	errs := wrappers.Errs{}
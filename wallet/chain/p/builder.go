@@ -591,21 +591,30 @@ func (b *builder) NewImportTx(
 		outputs      = make([]*djtx.TransferableOutput, 0, len(importedAmounts))
 		importedDJTX = importedAmounts[djtxAssetID]
 	)
-	if importedDJTX > txFee {
-		importedAmounts[djtxAssetID] -= txFee
-	} else {
-		if importedDJTX < txFee { // imported amount goes toward paying tx fee
-			toBurn := map[ids.ID]uint64{
-				djtxAssetID: txFee - importedDJTX,
-			}
-			toStake := map[ids.ID]uint64{}
-			var err error
-			inputs, outputs, _, err = b.spend(toBurn, toStake, ops)
-			if err != nil {
-				return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
-			}
-		}
+	txFeeWithTip, err := math.Add64(txFee, ops.Tip())
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case importedDJTX > txFeeWithTip:
+		importedAmounts[djtxAssetID] -= txFeeWithTip
+	case importedDJTX == txFeeWithTip:
 		delete(importedAmounts, djtxAssetID)
+	default:
+		// The imported amount goes toward paying the tx fee. The rest of the
+		// tx fee and the tip are paid by [b.spend].
+		toBurn := map[ids.ID]uint64{}
+		if importedDJTX > txFee {
+			importedAmounts[djtxAssetID] -= txFee
+		} else {
+			toBurn[djtxAssetID] = txFee - importedDJTX
+			delete(importedAmounts, djtxAssetID)
+		}
+		toStake := map[ids.ID]uint64{}
+		inputs, outputs, _, err = b.spend(toBurn, toStake, ops)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		}
 	}
 
 	for assetID, amount := range importedAmounts {
@@ -866,7 +875,8 @@ func (b *builder) getBalance(
 //     producing an output. This is typically used for fees. However, it can
 //     also be used to consume some of an asset that will be produced in
 //     separate outputs, such as ExportedOutputs. Only unlocked UTXOs are able
-//     to be burned here.
+//     to be burned here. The tip requested in [options] is burned in addition
+//     to these amounts.
 //   - [amountsToStake] maps assetID to the amount of the asset to spend and
 //     place into the staked outputs. First locked UTXOs are attempted to be
 //     used for these funds, and then unlocked UTXOs will be attempted to be
@@ -881,6 +891,15 @@ func (b *builder) spend(
 	stakeOutputs []*djtx.TransferableOutput,
 	err error,
 ) {
+	if tip := options.Tip(); tip > 0 {
+		djtxAssetID := b.backend.DJTXAssetID()
+		amountToBurn, err := math.Add64(amountsToBurn[djtxAssetID], tip)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		amountsToBurn[djtxAssetID] = amountToBurn
+	}
+
 	utxos, err := b.backend.UTXOs(options.Context(), constants.PlatformChainID)
 	if err != nil {
 		return nil, nil, nil, err
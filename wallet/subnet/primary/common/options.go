@@ -31,6 +31,8 @@ type Options struct {
 
 	memo []byte
 
+	tip uint64
+
 	assumeDecided bool
 
 	pollFrequencySet bool
@@ -93,6 +95,12 @@ func (o *Options) Memo() []byte {
 	return o.memo
 }
 
+// Tip is the amount of the fee asset that is burned in addition to the fee of
+// the tx. Paying a tip prioritizes the tx over txs that pay a lower fee rate.
+func (o *Options) Tip() uint64 {
+	return o.tip
+}
+
 func (o *Options) AssumeDecided() bool {
 	return o.assumeDecided
 }
@@ -142,6 +150,12 @@ func WithMemo(memo []byte) Option {
 	}
 }
 
+func WithTip(tip uint64) Option {
+	return func(o *Options) {
+		o.tip = tip
+	}
+}
+
 func WithAssumeDecided() Option {
 	return func(o *Options) {
 		o.assumeDecided = true